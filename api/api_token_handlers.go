@@ -0,0 +1,120 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APITokenHandler 구조체 정의
+type APITokenHandler struct {
+	APITokenService *service.APITokenService
+	Audit           *service.AuditService
+}
+
+// NewAPITokenHandler 함수 정의
+func NewAPITokenHandler(apiTokenService *service.APITokenService, audit *service.AuditService) *APITokenHandler {
+	return &APITokenHandler{APITokenService: apiTokenService, Audit: audit}
+}
+
+// CreateAPITokenRequest 구조체 정의 (expires_in_days가 0이면 만료 없음)
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// APITokenResponse 구조체 정의 (토큰 원문은 생성 응답에만 포함)
+type APITokenResponse struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Prefix       string   `json:"prefix"`
+	Scopes       []string `json:"scopes"`
+	ExpiresTime  *string  `json:"expires_time"`
+	LastUsedTime *string  `json:"last_used_time"`
+	CreatedTime  string   `json:"created_time"`
+}
+
+func apiTokenToResponse(token *model.APIToken) APITokenResponse {
+	return APITokenResponse{
+		ID:           token.ID,
+		Name:         token.Name,
+		Prefix:       token.Prefix,
+		Scopes:       token.Scopes,
+		ExpiresTime:  formatOptionalTime(token.ExpiresTime),
+		LastUsedTime: formatOptionalTime(token.LastUsedTime),
+		CreatedTime:  formatTime(token.CreatedTime),
+	}
+}
+
+// CreateAPITokenHandler 함수 정의
+func (h *APITokenHandler) CreateAPITokenHandler(c echo.Context) error {
+	var req CreateAPITokenRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	expiresIn := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	token, raw, err := h.APITokenService.CreateToken(currentUserID(c), req.Name, req.Scopes, expiresIn)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, model.AuditEntry{
+		Action:     service.AuditAPITokenCreate,
+		TargetType: service.AuditTargetAPIToken,
+		TargetID:   token.ID,
+		Details:    "scopes=" + strings.Join(token.Scopes, ","),
+	})
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":    "API token created successfully",
+		"token":      raw,
+		"token_info": apiTokenToResponse(token),
+	})
+}
+
+// GetAPITokensHandler 함수 정의
+func (h *APITokenHandler) GetAPITokensHandler(c echo.Context) error {
+	tokens, err := h.APITokenService.GetTokens(currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]APITokenResponse, len(tokens))
+	for i, token := range tokens {
+		responses[i] = apiTokenToResponse(token)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "API tokens retrieved successfully",
+		"tokens":  responses,
+	})
+}
+
+// DeleteAPITokenHandler 함수 정의
+func (h *APITokenHandler) DeleteAPITokenHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if err := h.APITokenService.RevokeToken(currentUserID(c), id); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, model.AuditEntry{
+		Action:     service.AuditAPITokenRevoke,
+		TargetType: service.AuditTargetAPIToken,
+		TargetID:   id,
+	})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "API token revoked successfully",
+	})
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AssistHandler 구조체 정의
+type AssistHandler struct {
+	NoteService   *service.NoteService
+	AssistService *service.AssistService
+	Audit         *service.AuditService
+	// 적용 시 If-Match 헤더 필수 여부
+	RequireIfMatch bool
+}
+
+// NewAssistHandler 함수 정의
+func NewAssistHandler(noteService *service.NoteService, assistService *service.AssistService, audit *service.AuditService, requireIfMatch bool) *AssistHandler {
+	return &AssistHandler{NoteService: noteService, AssistService: assistService, Audit: audit, RequireIfMatch: requireIfMatch}
+}
+
+// AssistRequest 구조체 정의
+type AssistRequest struct {
+	Action       string                      `json:"action"`
+	Instructions string                      `json:"instructions"`
+	Overrides    service.GenerationOverrides `json:"overrides"`
+}
+
+// ApplyAssistRequest 구조체 정의
+// base_revision은 제안의 base_revision 그대로 (리비전이 없던 노트는 0)
+type ApplyAssistRequest struct {
+	Content      string `json:"content" validate:"required,max=100000"`
+	BaseRevision *int   `json:"base_revision" validate:"present"`
+}
+
+// AssistNoteHandler 함수 정의 (AI 제안 내용과 diff 반환, 노트는 변경하지 않음)
+func (h *AssistHandler) AssistNoteHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req AssistRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	if err := service.ValidateAction(req.Action); err != nil {
+		return err
+	}
+	if _, err := h.AssistService.Gemini.Catalog.Resolve(req.Overrides); err != nil {
+		return err
+	}
+
+	note, err := h.NoteService.GetNoteByID(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	proposal, err := h.AssistService.Propose(c.Request().Context(), note, req.Action, req.Instructions, req.Overrides)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Proposal created successfully",
+		"proposal": proposal,
+	})
+}
+
+// ApplyAssistHandler 함수 정의 (제안 내용을 새 리비전으로 저장)
+func (h *AssistHandler) ApplyAssistHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req ApplyAssistRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	before, err := h.NoteService.GetNoteByID(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatchVersion(c, before, h.RequireIfMatch)
+	if err != nil {
+		return err
+	}
+
+	note, err := h.AssistService.Apply(currentScope(c), id, version, req.Content, *req.BaseRevision)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteAssistApply, note.ID, before, note))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Proposal applied successfully",
+		"note_info": noteToResponse(note),
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AttachmentHandler 구조체 정의
+type AttachmentHandler struct {
+	AttachmentService *service.AttachmentService
+	MaxUploadBytes    int64
+}
+
+// NewAttachmentHandler 함수 정의
+func NewAttachmentHandler(attachmentService *service.AttachmentService, maxUploadBytes int64) *AttachmentHandler {
+	return &AttachmentHandler{AttachmentService: attachmentService, MaxUploadBytes: maxUploadBytes}
+}
+
+// AttachmentResponse 구조체 정의
+type AttachmentResponse struct {
+	ID            int    `json:"id"`
+	NoteID        int    `json:"note_id"`
+	Filename      string `json:"filename"`
+	Path          string `json:"path"`
+	MimeType      string `json:"mime_type"`
+	ExtractedText string `json:"extracted_text"`
+	ThumbnailPath string `json:"thumbnail_path"`
+	CreatedTime   string `json:"created_time"`
+}
+
+func attachmentToResponse(attachment *model.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:            attachment.ID,
+		NoteID:        attachment.NoteID,
+		Filename:      attachment.Filename,
+		Path:          attachment.Path,
+		MimeType:      attachment.MimeType,
+		ExtractedText: attachment.ExtractedText,
+		ThumbnailPath: attachment.ThumbnailPath,
+		CreatedTime:   formatTime(attachment.CreatedTime),
+	}
+}
+
+// UploadAttachmentHandler 함수 정의 (multipart "file" 필드로 이미지 업로드, 본문이 MaxUploadBytes를 넘으면 413)
+func (h *AttachmentHandler) UploadAttachmentHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, h.MaxUploadBytes)
+	fileHeader, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return model.NewError(model.ErrCodePayloadTooLarge, fmt.Sprintf("upload must be at most %d bytes", h.MaxUploadBytes))
+	}
+	if err != nil {
+		return model.Validation("File is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return model.Validation("Unable to read file")
+	}
+	defer file.Close()
+
+	attachment, err := h.AttachmentService.UploadAttachment(c.Request().Context(), currentScope(c), id, fileHeader.Filename, file)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.NotFound("Note not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":         "Attachment uploaded successfully",
+		"attachment_info": attachmentToResponse(attachment),
+	})
+}
+
+// GetAttachmentsHandler 함수 정의 (노트의 첨부 목록)
+func (h *AttachmentHandler) GetAttachmentsHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	attachments, err := h.AttachmentService.GetAttachmentsByNoteID(currentScope(c), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.NotFound("Note not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	responses := make([]AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = attachmentToResponse(attachment)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Attachments retrieved successfully",
+		"attachments": responses,
+	})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 감사 기록 조회 기본/최대 개수
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// recordAudit 함수 정의 (요청한 사용자, 워크스페이스, IP, 요청 ID를 채워 감사 기록)
+func recordAudit(c echo.Context, audit *service.AuditService, entry model.AuditEntry) {
+	if audit == nil {
+		return
+	}
+	scope := currentScope(c)
+	entry.ActorID = scope.UserID
+	if entry.WorkspaceID == 0 {
+		entry.WorkspaceID = scope.WorkspaceID
+	}
+	entry.IP = c.RealIP()
+	entry.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	audit.Record(&entry)
+}
+
+// noteAudit 함수 정의 (노트 대상 감사 기록 항목)
+func noteAudit(action string, noteID int, before, after *model.Note) model.AuditEntry {
+	return model.AuditEntry{
+		Action:     action,
+		TargetType: service.AuditTargetNote,
+		TargetID:   noteID,
+		BeforeHash: service.NoteHash(before),
+		AfterHash:  service.NoteHash(after),
+	}
+}
+
+// workspaceAudit 함수 정의 (워크스페이스 관리 감사 기록 항목)
+func workspaceAudit(action string, workspaceID int, details string) model.AuditEntry {
+	return model.AuditEntry{
+		Action:      action,
+		TargetType:  service.AuditTargetWorkspace,
+		TargetID:    workspaceID,
+		WorkspaceID: workspaceID,
+		Details:     details,
+	}
+}
+
+// promptAudit 함수 정의 (프롬프트 템플릿 감사 기록 항목)
+func promptAudit(action string, templateID int, before, after *model.PromptTemplate) model.AuditEntry {
+	return model.AuditEntry{
+		Action:     action,
+		TargetType: service.AuditTargetPrompt,
+		TargetID:   templateID,
+		BeforeHash: service.PromptHash(before),
+		AfterHash:  service.PromptHash(after),
+	}
+}
+
+// AuditHandler 구조체 정의
+type AuditHandler struct {
+	AuditService *service.AuditService
+}
+
+// NewAuditHandler 함수 정의
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{AuditService: auditService}
+}
+
+// AuditEntryResponse 구조체 정의
+type AuditEntryResponse struct {
+	ID          int    `json:"id"`
+	ActorID     int    `json:"actor_id"`
+	Action      string `json:"action"`
+	TargetType  string `json:"target_type"`
+	TargetID    int    `json:"target_id"`
+	WorkspaceID int    `json:"workspace_id"`
+	BeforeHash  string `json:"before_hash"`
+	AfterHash   string `json:"after_hash"`
+	Details     string `json:"details"`
+	IP          string `json:"ip"`
+	RequestID   string `json:"request_id"`
+	CreatedTime string `json:"created_time"`
+}
+
+func auditEntryToResponse(entry *model.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:          entry.ID,
+		ActorID:     entry.ActorID,
+		Action:      entry.Action,
+		TargetType:  entry.TargetType,
+		TargetID:    entry.TargetID,
+		WorkspaceID: entry.WorkspaceID,
+		BeforeHash:  entry.BeforeHash,
+		AfterHash:   entry.AfterHash,
+		Details:     entry.Details,
+		IP:          entry.IP,
+		RequestID:   entry.RequestID,
+		CreatedTime: entry.CreatedTime.Format(time.RFC3339),
+	}
+}
+
+// parseAuditFilter 함수 정의 (actor_id, action, target_type, target_id, workspace_id, since, until 쿼리, 시각은 RFC3339 또는 YYYY-MM-DD)
+func parseAuditFilter(c echo.Context) (model.AuditFilter, error) {
+	var filter model.AuditFilter
+	ints := map[string]*int{
+		"actor_id":     &filter.ActorID,
+		"target_id":    &filter.TargetID,
+		"workspace_id": &filter.WorkspaceID,
+		"limit":        &filter.Limit,
+		"offset":       &filter.Offset,
+	}
+	for name, dst := range ints {
+		if value := c.QueryParam(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return filter, model.Validation("invalid " + name)
+			}
+			*dst = n
+		}
+	}
+	times := map[string]**time.Time{
+		"since": &filter.Since,
+		"until": &filter.Until,
+	}
+	for name, dst := range times {
+		if value := c.QueryParam(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				t, err = time.Parse("2006-01-02", value)
+			}
+			if err != nil {
+				return filter, model.Validation("invalid " + name + ", use RFC3339 or YYYY-MM-DD")
+			}
+			t = t.UTC()
+			*dst = &t
+		}
+	}
+	filter.Action = c.QueryParam("action")
+	filter.TargetType = c.QueryParam("target_type")
+	return filter, nil
+}
+
+// GetAuditLogHandler 함수 정의 (필터 조건에 맞는 최근 감사 기록)
+func (h *AuditHandler) GetAuditLogHandler(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+
+	entries, err := h.AuditService.Query(filter)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = auditEntryToResponse(entry)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Audit log retrieved successfully",
+		"entries": responses,
+	})
+}
+
+// ExportAuditLogHandler 함수 정의 (필터 조건에 맞는 감사 기록을 JSON Lines로 내보내기, limit이 없으면 전체)
+func (h *AuditHandler) ExportAuditLogHandler(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	entries, err := h.AuditService.Query(filter)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit-log.jsonl"`)
+	c.Response().WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(c.Response())
+	for _, entry := range entries {
+		if err := encoder.Encode(auditEntryToResponse(entry)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuthHandler 구조체 정의
+type AuthHandler struct {
+	AuthService *service.AuthService
+}
+
+// NewAuthHandler 함수 정의
+func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+	return &AuthHandler{AuthService: authService}
+}
+
+// CredentialsRequest 구조체 정의 (회원가입, 로그인)
+type CredentialsRequest struct {
+	Email    string `json:"email" validate:"required,max=254"`
+	Password string `json:"password" validate:"required"`
+}
+
+// RefreshTokenRequest 구조체 정의 (토큰 갱신, 로그아웃)
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// UserResponse 구조체 정의
+type UserResponse struct {
+	ID          int    `json:"id"`
+	Email       string `json:"email"`
+	CreatedTime string `json:"created_time"`
+}
+
+func userToResponse(user *model.User) UserResponse {
+	return UserResponse{
+		ID:          user.ID,
+		Email:       user.Email,
+		CreatedTime: formatTime(user.CreatedTime),
+	}
+}
+
+// SignupHandler 함수 정의 (가입 후 바로 토큰 발급)
+func (h *AuthHandler) SignupHandler(c echo.Context) error {
+	var req CredentialsRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.AuthService.Signup(req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := h.AuthService.Login(req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":   "User created successfully",
+		"user_info": userToResponse(user),
+		"tokens":    tokens,
+	})
+}
+
+// LoginHandler 함수 정의
+func (h *AuthHandler) LoginHandler(c echo.Context) error {
+	var req CredentialsRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	tokens, err := h.AuthService.Login(req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Logged in successfully",
+		"tokens":  tokens,
+	})
+}
+
+// RefreshHandler 함수 정의 (사용한 리프레시 토큰은 폐기되고 새 토큰 쌍 발급)
+func (h *AuthHandler) RefreshHandler(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	tokens, err := h.AuthService.Refresh(req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Token refreshed successfully",
+		"tokens":  tokens,
+	})
+}
+
+// LogoutHandler 함수 정의 (리프레시 토큰 폐기)
+func (h *AuthHandler) LogoutHandler(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	err := h.AuthService.Logout(req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Logged out successfully",
+	})
+}
+
+// GetMeHandler 함수 정의 (현재 로그인한 사용자 정보)
+func (h *AuthHandler) GetMeHandler(c echo.Context) error {
+	user, err := h.AuthService.GetUser(currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "User retrieved successfully",
+		"user_info": userToResponse(user),
+	})
+}
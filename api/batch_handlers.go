@@ -0,0 +1,195 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 일괄 처리 모드
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// BatchHandler 구조체 정의 (RequireIfMatch가 true이면 생성 외 연산에 version 필수)
+type BatchHandler struct {
+	BatchService   *service.BatchService
+	Audit          *service.AuditService
+	RequireIfMatch bool
+}
+
+// NewBatchHandler 함수 정의
+func NewBatchHandler(batchService *service.BatchService, audit *service.AuditService, requireIfMatch bool) *BatchHandler {
+	return &BatchHandler{BatchService: batchService, Audit: audit, RequireIfMatch: requireIfMatch}
+}
+
+// batchOpTag 태그 연산 (노트에 태그가 없어 지원하지 않음, 알 수 없는 연산과 구분해 안내)
+const batchOpTag = "tag"
+
+// BatchOperationRequest 구조체 정의 (op: create, update, delete, move, version은 If-Match와 같은 용도, tag는 지원하지 않음)
+type BatchOperationRequest struct {
+	Op          string `json:"op"`
+	ID          int    `json:"id"`
+	Version     int    `json:"version"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Img         string `json:"img"`
+	WorkspaceID int    `json:"workspace_id"`
+}
+
+// BatchRequest 구조체 정의 (mode 기본값 atomic)
+type BatchRequest struct {
+	Mode       string                  `json:"mode"`
+	Operations []BatchOperationRequest `json:"operations"`
+}
+
+// validateFields 함수 정의 (형식이 잘못된 연산이 있으면 모드와 관계없이 아무것도 실행하지 않음)
+func (r *BatchRequest) validateFields() []model.FieldError {
+	var fields []model.FieldError
+	if r.Mode != "" && r.Mode != BatchModeAtomic && r.Mode != BatchModeBestEffort {
+		fields = append(fields, model.FieldError{Field: "mode", Message: fmt.Sprintf("must be %q or %q", BatchModeAtomic, BatchModeBestEffort)})
+	}
+	if len(r.Operations) == 0 {
+		fields = append(fields, model.FieldError{Field: "operations", Message: "is required"})
+	}
+	if len(r.Operations) > service.MaxBatchOperations {
+		fields = append(fields, model.FieldError{Field: "operations", Message: fmt.Sprintf("must have at most %d items", service.MaxBatchOperations)})
+	}
+
+	for i, op := range r.Operations {
+		prefix := fmt.Sprintf("operations[%d].", i)
+		var err error
+		switch op.Op {
+		case service.BatchOpCreate:
+			err = validateStruct(&CreateNoteRequest{Title: op.Title, Content: op.Content, Img: op.Img})
+		case service.BatchOpUpdate:
+			err = validateStruct(&UpdateNoteRequest{Title: &op.Title, Content: &op.Content, Img: &op.Img})
+		case service.BatchOpDelete, service.BatchOpMove:
+		case batchOpTag:
+			fields = append(fields, model.FieldError{Field: prefix + "op", Message: "tag operations are not supported: notes have no tags"})
+			continue
+		default:
+			fields = append(fields, model.FieldError{Field: prefix + "op", Message: "must be one of create, update, delete, move"})
+			continue
+		}
+		if op.Op != service.BatchOpCreate && op.ID <= 0 {
+			fields = append(fields, model.FieldError{Field: prefix + "id", Message: "is required"})
+		}
+		var appErr *model.Error
+		if errors.As(err, &appErr) {
+			details, _ := appErr.Details.([]model.FieldError)
+			for _, field := range details {
+				fields = append(fields, model.FieldError{Field: prefix + field.Field, Message: field.Message})
+			}
+		}
+	}
+	return fields
+}
+
+// BatchResultResponse 구조체 정의 (status는 연산을 따로 요청했을 때의 HTTP 상태 코드)
+type BatchResultResponse struct {
+	Index  int           `json:"index"`
+	Op     string        `json:"op"`
+	Status int           `json:"status"`
+	Note   *NoteResponse `json:"note_info,omitempty"`
+	Error  *ErrorBody    `json:"error,omitempty"`
+}
+
+// 연산별 감사 기록 종류
+var batchAuditActions = map[string]string{
+	service.BatchOpCreate: service.AuditNoteCreate,
+	service.BatchOpUpdate: service.AuditNoteUpdate,
+	service.BatchOpDelete: service.AuditNoteDelete,
+	service.BatchOpMove:   service.AuditNoteMove,
+}
+
+// BatchNotesHandler 함수 정의 (여러 노트 연산을 한 트랜잭션으로 실행, atomic 모드에서 실패하면 실패한 연산의 오류 코드로 응답)
+func (h *BatchHandler) BatchNotesHandler(c echo.Context) error {
+	var req BatchRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	// If-Match가 필수인 서버에서는 version이 같은 역할
+	if h.RequireIfMatch {
+		var fields []model.FieldError
+		for i, op := range req.Operations {
+			if op.Op != service.BatchOpCreate && op.Version <= 0 {
+				fields = append(fields, model.FieldError{Field: fmt.Sprintf("operations[%d].version", i), Message: "is required because this server requires If-Match"})
+			}
+		}
+		if len(fields) > 0 {
+			return model.InvalidFields(fields)
+		}
+	}
+	if req.Mode == "" {
+		req.Mode = BatchModeAtomic
+	}
+	atomic := req.Mode == BatchModeAtomic
+
+	ops := make([]service.BatchOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		ops[i] = service.BatchOperation{
+			Op:          op.Op,
+			ID:          op.ID,
+			Version:     op.Version,
+			Title:       op.Title,
+			Content:     op.Content,
+			Img:         op.Img,
+			WorkspaceID: op.WorkspaceID,
+		}
+	}
+
+	results, committed, err := h.BatchService.Execute(currentScope(c), ops, atomic)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]BatchResultResponse, len(results))
+	failed := 0
+	var firstErr error
+	firstIndex := 0
+	for i, result := range results {
+		responses[i] = BatchResultResponse{Index: i, Op: result.Op, Status: http.StatusOK}
+		if result.Err != nil {
+			status, body := errorResponse(result.Err)
+			responses[i].Status = status
+			responses[i].Error = &body
+			failed++
+			if firstErr == nil && !errors.Is(result.Err, service.ErrBatchAborted) {
+				firstErr, firstIndex = result.Err, i
+			}
+			continue
+		}
+		if result.Op == service.BatchOpCreate {
+			responses[i].Status = http.StatusCreated
+		}
+		if result.Note != nil {
+			note := noteToResponse(result.Note)
+			responses[i].Note = &note
+		}
+		if committed {
+			id := ops[i].ID
+			if result.Note != nil {
+				id = result.Note.ID
+			}
+			recordAudit(c, h.Audit, noteAudit(batchAuditActions[result.Op], id, result.Before, result.Note))
+		}
+	}
+
+	if !committed {
+		_, body := errorResponse(firstErr)
+		return model.NewError(body.Code, fmt.Sprintf("batch rolled back: operation %d failed: %s", firstIndex, body.Message)).WithDetails(responses)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Batch processed successfully",
+		"mode":    req.Mode,
+		"failed":  failed,
+		"results": responses,
+	})
+}
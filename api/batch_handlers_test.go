@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestBatchRequiresVersionsWhenIfMatchIsRequired(t *testing.T) {
+	body := `{"operations": [
+		{"op": "create", "content": "new"},
+		{"op": "update", "id": 1, "title": "", "content": "changed", "img": ""},
+		{"op": "delete", "id": 2, "version": 3},
+		{"op": "move", "id": 3, "workspace_id": 1}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	// 검증에서 거부되므로 BatchService까지 가지 않음
+	h := &BatchHandler{RequireIfMatch: true}
+	fields := fieldErrors(t, h.BatchNotesHandler(c))
+	if len(fields) != 2 || fields["operations[1].version"] == "" || fields["operations[3].version"] == "" {
+		t.Fatalf("field errors = %v, want versions of the update and the move", fields)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DuplicateHandler 구조체 정의
+type DuplicateHandler struct {
+	DuplicateService *service.DuplicateService
+	Audit            *service.AuditService
+}
+
+// NewDuplicateHandler 함수 정의
+func NewDuplicateHandler(duplicateService *service.DuplicateService, audit *service.AuditService) *DuplicateHandler {
+	return &DuplicateHandler{DuplicateService: duplicateService, Audit: audit}
+}
+
+// MergeNotesRequest 구조체 정의
+type MergeNotesRequest struct {
+	TargetID  int   `json:"target_id"`
+	SourceIDs []int `json:"source_ids"`
+}
+
+// GetDuplicatesHandler 함수 정의 (threshold 기본값 0.8)
+func (h *DuplicateHandler) GetDuplicatesHandler(c echo.Context) error {
+	threshold := 0.8
+	if v := c.QueryParam("threshold"); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return model.Validation("Invalid threshold")
+		}
+		threshold = t
+	}
+
+	groups, err := h.DuplicateService.FindDuplicates(currentScope(c), threshold)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Duplicates retrieved successfully",
+		"threshold": threshold,
+		"groups":    groups,
+	})
+}
+
+// MergeNotesHandler 함수 정의 (source 노트들을 target 노트로 병합)
+func (h *DuplicateHandler) MergeNotesHandler(c echo.Context) error {
+	var req MergeNotesRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	if req.TargetID == 0 || len(req.SourceIDs) == 0 {
+		return model.Validation("target_id and source_ids are required")
+	}
+
+	note, err := h.DuplicateService.MergeNotes(currentScope(c), req.TargetID, req.SourceIDs)
+	if err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditNoteMerge, note.ID, nil, note)
+	entry.Details = fmt.Sprintf("source_ids=%v", req.SourceIDs)
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Notes merged successfully",
+		"note_info": noteToResponse(note),
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"myapp/model"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorBody 구조체 정의 (오류 응답 {"error": {...}})
+type ErrorBody struct {
+	Code      model.ErrorCode `json:"code"`
+	Message   string          `json:"message"`
+	Details   interface{}     `json:"details,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+}
+
+// 오류 코드별 HTTP 상태 코드
+var errorStatuses = map[model.ErrorCode]int{
+	model.ErrCodeValidation:          http.StatusBadRequest,
+	model.ErrCodeUnauthorized:        http.StatusUnauthorized,
+	model.ErrCodeForbidden:           http.StatusForbidden,
+	model.ErrCodeNotFound:            http.StatusNotFound,
+	model.ErrCodeConflict:            http.StatusConflict,
+	model.ErrCodePreconditionFailed:  http.StatusPreconditionFailed,
+	model.ErrCodePreconditionNeeded:  http.StatusPreconditionRequired,
+	model.ErrCodeGone:                http.StatusGone,
+	model.ErrCodeUnsupportedMedia:    http.StatusUnsupportedMediaType,
+	model.ErrCodePayloadTooLarge:     http.StatusRequestEntityTooLarge,
+	model.ErrCodeRateLimited:         http.StatusTooManyRequests,
+	model.ErrCodeUpstream:            http.StatusBadGateway,
+	model.ErrCodeUpstreamUnavailable: http.StatusServiceUnavailable,
+	model.ErrCodeUpstreamTimeout:     http.StatusGatewayTimeout,
+	model.ErrCodeInternal:            http.StatusInternalServerError,
+}
+
+// statusCode 함수 정의 (echo.HTTPError 등 상태 코드만 있는 오류의 오류 코드)
+func statusCode(status int) model.ErrorCode {
+	for code, s := range errorStatuses {
+		if s == status {
+			return code
+		}
+	}
+	if status < http.StatusInternalServerError {
+		return model.ErrCodeValidation
+	}
+	return model.ErrCodeInternal
+}
+
+// errorResponse 함수 정의 (분류되지 않은 오류는 내용을 숨기고 500)
+func errorResponse(err error) (int, ErrorBody) {
+	var appErr *model.Error
+	if errors.As(err, &appErr) {
+		status, ok := errorStatuses[appErr.Code]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		return status, ErrorBody{Code: appErr.Code, Message: appErr.Message, Details: appErr.Details}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message, ok := httpErr.Message.(string)
+		if !ok {
+			message = http.StatusText(httpErr.Code)
+		}
+		return httpErr.Code, ErrorBody{Code: statusCode(httpErr.Code), Message: message}
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound, ErrorBody{Code: model.ErrCodeNotFound, Message: "resource not found"}
+	}
+	return http.StatusInternalServerError, ErrorBody{Code: model.ErrCodeInternal, Message: "internal server error"}
+}
+
+// HTTPErrorHandler 함수 정의 (핸들러와 미들웨어가 반환한 오류를 {"error": {code, message, details, request_id}}로 응답)
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, body := errorResponse(err)
+	body.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+	if status >= http.StatusInternalServerError {
+		log.Printf("request %s %s %s failed: %v", body.RequestID, c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, map[string]interface{}{"error": body})
+	}
+	if err != nil {
+		log.Printf("could not write error response: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 조건부 요청 헤더
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// ErrIfMatchRequired If-Match 헤더가 필수인데 없을 때 반환
+var ErrIfMatchRequired = model.NewError(model.ErrCodePreconditionNeeded, "If-Match header is required to modify a note")
+
+// noteETag 함수 정의 (노트 버전으로 만든 강한 ETag)
+func noteETag(note *model.Note) string {
+	return fmt.Sprintf(`"%d"`, note.Version)
+}
+
+// notesETag 함수 정의 (목록의 노트 ID와 버전으로 만든 약한 ETag)
+func notesETag(notes []*model.Note) string {
+	h := sha256.New()
+	for _, note := range notes {
+		fmt.Fprintf(h, "%d:%d;", note.ID, note.Version)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil))[:32] + `"`
+}
+
+// parseETags 함수 정의 (쉼표로 구분된 ETag 목록)
+func parseETags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ifMatchVersion 함수 정의 (If-Match가 현재 노트와 맞으면 수정 조건으로 쓸 버전, 헤더가 없으면 0)
+func ifMatchVersion(c echo.Context, current *model.Note, required bool) (int, error) {
+	header := c.Request().Header.Get(HeaderIfMatch)
+	if header == "" {
+		if required {
+			return 0, ErrIfMatchRequired
+		}
+		return 0, nil
+	}
+	// If-Match는 강한 비교만 허용하므로 W/ 태그는 일치하지 않음
+	for _, tag := range parseETags(header) {
+		if tag == "*" || tag == noteETag(current) {
+			return current.Version, nil
+		}
+	}
+	return 0, service.ErrVersionMismatch
+}
+
+// notModified 함수 정의 (If-None-Match가 etag와 맞으면 true, 약한 비교)
+func notModified(c echo.Context, etag string) bool {
+	header := c.Request().Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	for _, tag := range parseETags(header) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
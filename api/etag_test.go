@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func etagContext(header, value string) echo.Context {
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	note := &model.Note{ID: 1, Version: 4}
+	tests := []struct {
+		name     string
+		header   string
+		required bool
+		version  int
+		err      error
+	}{
+		{"missing", "", false, 0, nil},
+		{"missing but required", "", true, 0, ErrIfMatchRequired},
+		{"current", `"4"`, false, 4, nil},
+		{"one of several", `"2", "4"`, true, 4, nil},
+		{"wildcard", `*`, false, 4, nil},
+		{"stale", `"3"`, false, 0, service.ErrVersionMismatch},
+		{"weak tags never match", `W/"4"`, false, 0, service.ErrVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := ""
+			if tt.header != "" {
+				header = HeaderIfMatch
+			}
+			version, err := ifMatchVersion(etagContext(header, tt.header), note, tt.required)
+			if !errors.Is(err, tt.err) || (err == nil && tt.err != nil) || version != tt.version {
+				t.Fatalf("ifMatchVersion = %d, %v; want %d, %v", version, err, tt.version, tt.err)
+			}
+		})
+	}
+}
+
+func TestNotModifiedUsesWeakComparison(t *testing.T) {
+	etag := noteETag(&model.Note{Version: 7})
+	for header, want := range map[string]bool{
+		`"7"`:        true,
+		`W/"7"`:      true,
+		`"6", W/"7"`: true,
+		`*`:          true,
+		`"6"`:        false,
+		`"70"`:       false,
+	} {
+		if got := notModified(etagContext(HeaderIfNoneMatch, header), etag); got != want {
+			t.Fatalf("If-None-Match %s against %s = %v, want %v", header, etag, got, want)
+		}
+	}
+	if notModified(etagContext("", ""), etag) {
+		t.Fatal("request without If-None-Match reported as not modified")
+	}
+}
+
+func TestNotesETagChangesWithAnyVersion(t *testing.T) {
+	notes := []*model.Note{{ID: 1, Version: 1}, {ID: 2, Version: 3}}
+	before := notesETag(notes)
+	if before != notesETag([]*model.Note{{ID: 1, Version: 1}, {ID: 2, Version: 3}}) {
+		t.Fatal("notesETag is not stable for the same list")
+	}
+	notes[1].Version++
+	if notesETag(notes) == before {
+		t.Fatal("notesETag did not change after a note version changed")
+	}
+	if notesETag(notes[:1]) == notesETag(notes) {
+		t.Fatal("notesETag did not change after a note was removed")
+	}
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// HeaderLastEventID SSE 클라이언트가 다시 연결할 때 보내는 마지막 이벤트 ID 헤더
+const HeaderLastEventID = "Last-Event-ID"
+
+// MIMETextEventStream SSE 응답 형식
+const MIMETextEventStream = "text/event-stream"
+
+// 이어받을 때 저장된 이벤트를 한 번에 읽는 수
+const eventReplayPage = 500
+
+// EventHandler 구조체 정의 (Heartbeat마다 연결 유지용 메시지 전송, Auth는 스트림 토큰 발급에 사용)
+type EventHandler struct {
+	Events    *service.EventBus
+	Auth      *service.AuthService
+	Heartbeat time.Duration
+}
+
+// NewEventHandler 함수 정의
+func NewEventHandler(events *service.EventBus, auth *service.AuthService) *EventHandler {
+	return &EventHandler{Events: events, Auth: auth, Heartbeat: 25 * time.Second}
+}
+
+// StreamTokenResponse 구조체 정의
+type StreamTokenResponse struct {
+	Token       string `json:"token"`
+	ExpiresTime string `json:"expires_time"`
+}
+
+// CreateStreamTokenHandler 함수 정의 (현재 사용자와 X-Workspace-ID 워크스페이스로 이벤트 스트림 연결용 토큰 발급)
+func (h *EventHandler) CreateStreamTokenHandler(c echo.Context) error {
+	scope := currentScope(c)
+	token, expiresAt, err := h.Auth.IssueStreamToken(scope.UserID, scope.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":      "Stream token created successfully",
+		"stream_token": StreamTokenResponse{Token: token, ExpiresTime: formatTime(expiresAt)},
+	})
+}
+
+// NoteEventResponse 구조체 정의 (from_*는 note.moved 이벤트의 이전 위치)
+type NoteEventResponse struct {
+	ID              int64  `json:"id"`
+	Type            string `json:"type"`
+	NoteID          int    `json:"note_id"`
+	OwnerID         int    `json:"owner_id"`
+	WorkspaceID     int    `json:"workspace_id"`
+	FromOwnerID     int    `json:"from_owner_id,omitempty"`
+	FromWorkspaceID int    `json:"from_workspace_id,omitempty"`
+	Version         int    `json:"version"`
+	CreatedTime     string `json:"created_time"`
+}
+
+func noteEventToResponse(event *model.NoteEvent) NoteEventResponse {
+	return NoteEventResponse{
+		ID:              event.ID,
+		Type:            event.Type,
+		NoteID:          event.NoteID,
+		OwnerID:         event.OwnerID,
+		WorkspaceID:     event.WorkspaceID,
+		FromOwnerID:     event.FromOwnerID,
+		FromWorkspaceID: event.FromWorkspaceID,
+		Version:         event.Version,
+		CreatedTime:     formatTime(event.CreatedTime),
+	}
+}
+
+// parseEventFilter 함수 정의 (types는 쉼표로 구분, 마지막 이벤트 ID는 Last-Event-ID 헤더 또는 last_event_id 쿼리)
+func parseEventFilter(c echo.Context) (model.NoteEventFilter, error) {
+	var filter model.NoteEventFilter
+	var fields []model.FieldError
+
+	if types := c.QueryParam("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			t = strings.TrimSpace(t)
+			if !isNoteEventType(t) {
+				fields = append(fields, model.FieldError{Field: "types", Message: fmt.Sprintf("unknown event type %q (must be one of %s)", t, strings.Join(model.NoteEventTypes, ", "))})
+				continue
+			}
+			filter.Types = append(filter.Types, t)
+		}
+	}
+	if noteID := c.QueryParam("note_id"); noteID != "" {
+		id, err := strconv.Atoi(noteID)
+		if err != nil || id <= 0 {
+			fields = append(fields, model.FieldError{Field: "note_id", Message: "must be a positive integer"})
+		}
+		filter.NoteID = id
+	}
+	// 노트에 태그가 없으므로 태그 조건은 받지 않음 (노트 묶음은 X-Workspace-ID로 구분)
+	if c.QueryParam("tag") != "" {
+		fields = append(fields, model.FieldError{Field: "tag", Message: "tag filters are not supported"})
+	}
+
+	lastEventID := c.Request().Header.Get(HeaderLastEventID)
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			fields = append(fields, model.FieldError{Field: "last_event_id", Message: "must be an event ID"})
+		}
+		filter.AfterID = id
+	}
+
+	if len(fields) > 0 {
+		return filter, model.InvalidFields(fields)
+	}
+	return filter, nil
+}
+
+func isNoteEventType(t string) bool {
+	for _, known := range model.NoteEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResume 함수 정의 (이어받을 이벤트가 보관 기간이 지나 삭제되었으면 응답을 시작하기 전에 410)
+func (h *EventHandler) checkResume(filter model.NoteEventFilter) error {
+	if filter.AfterID == 0 {
+		return nil
+	}
+	return h.Events.CheckCursor(filter.AfterID)
+}
+
+// stream 함수 정의 (filter.AfterID가 있으면 그 뒤의 저장된 이벤트를 먼저 보내고 실시간 이벤트로 이어감)
+func (h *EventHandler) stream(ctx context.Context, scope model.Scope, filter model.NoteEventFilter, send func(*model.NoteEvent) error, ping func() error) error {
+	// 이어받기 중 발생한 이벤트를 놓치지 않도록 저장된 이벤트를 읽기 전에 구독
+	sub := h.Events.Subscribe(scope, filter)
+	defer h.Events.Unsubscribe(sub)
+
+	var replayed int64
+	if filter.AfterID > 0 {
+		page := filter
+		page.Limit = eventReplayPage
+		for {
+			events, err := h.Events.History(scope, page)
+			if err != nil {
+				return err
+			}
+			for _, event := range events {
+				if err := send(event); err != nil {
+					return nil
+				}
+				page.AfterID = event.ID
+			}
+			if len(events) < page.Limit {
+				break
+			}
+		}
+		replayed = page.AfterID
+	}
+
+	ticker := time.NewTicker(h.Heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := ping(); err != nil {
+				return nil
+			}
+		case event, ok := <-sub.Events:
+			// 이벤트를 제때 받지 못해 구독이 끊김, 클라이언트는 마지막 ID로 다시 연결
+			if !ok {
+				return nil
+			}
+			// 이어받기로 이미 보낸 이벤트
+			if event.ID <= replayed {
+				continue
+			}
+			if err := send(event); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// StreamEventsHandler 함수 정의 (노트 변경 이벤트를 SSE로 전송, X-Workspace-ID가 없으면 개인 노트의 이벤트)
+func (h *EventHandler) StreamEventsHandler(c echo.Context) error {
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkResume(filter); err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	// 프록시가 응답을 모아서 보내지 않도록
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	send := func(event *model.NoteEvent) error {
+		data, err := json.Marshal(noteEventToResponse(event))
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+	ping := func() error {
+		if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+	// 응답을 시작한 뒤라 오류 응답을 보낼 수 없으므로 기록만 남김
+	if err := h.stream(c.Request().Context(), currentScope(c), filter, send, ping); err != nil {
+		log.Printf("event stream failed: %v", err)
+	}
+	return nil
+}
+
+// StreamEventsWebSocketHandler 함수 정의 (SSE와 같은 이벤트를 WebSocket 텍스트 메시지로 전송, 이어받기는 last_event_id 쿼리)
+func (h *EventHandler) StreamEventsWebSocketHandler(c echo.Context) error {
+	filter, err := parseEventFilter(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkResume(filter); err != nil {
+		return err
+	}
+	scope := currentScope(c)
+
+	server := websocket.Server{
+		// Bearer 토큰으로 인증하므로 Origin은 확인하지 않음 (쿠키 인증이 아니어서 다른 사이트가 대신 연결할 수 없음)
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			ctx, cancel := context.WithCancel(c.Request().Context())
+			defer cancel()
+
+			// 클라이언트가 보내는 메시지는 읽고 버림, 연결이 끊기면 전송 중단
+			go func() {
+				defer cancel()
+				var discard string
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+			}()
+
+			send := func(event *model.NoteEvent) error {
+				return websocket.JSON.Send(ws, noteEventToResponse(event))
+			}
+			ping := func() error {
+				return websocket.JSON.Send(ws, map[string]string{"type": "ping"})
+			}
+			if err := h.stream(ctx, scope, filter, send, ping); err != nil {
+				log.Printf("event stream failed: %v", err)
+			}
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
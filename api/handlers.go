@@ -1,10 +1,15 @@
 package api
 
 import (
-	"context"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
 	"log"
+	"mime"
 	"myapp/model"
 	"myapp/service"
+	"myapp/utils"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,13 +21,31 @@ import (
 type NoteHandler struct {
 	NoteService   *service.NoteService
 	GeminiService *service.GeminiService
+	JobService    *service.JobService
+	PromptService *service.PromptService
+
+	TranslationService *service.TranslationService
+	Audit              *service.AuditService
+
+	// 노트 가져오기 시 클라이언트가 보낸 createdAt, updatedAt 사용 허용
+	AllowClientTimestamps bool
+	// 노트 수정, 삭제 시 If-Match 헤더 필수
+	RequireIfMatch bool
 }
 
 // NewNoteHandler 함수 정의
-func NewNoteHandler(noteService *service.NoteService, geminiService *service.GeminiService) *NoteHandler {
+func NewNoteHandler(noteService *service.NoteService, geminiService *service.GeminiService, jobService *service.JobService, promptService *service.PromptService, translationService *service.TranslationService, audit *service.AuditService, allowClientTimestamps, requireIfMatch bool) *NoteHandler {
 	return &NoteHandler{
 		NoteService:   noteService,
 		GeminiService: geminiService,
+		JobService:    jobService,
+		PromptService: promptService,
+
+		TranslationService: translationService,
+		Audit:              audit,
+
+		AllowClientTimestamps: allowClientTimestamps,
+		RequireIfMatch:        requireIfMatch,
 	}
 }
 
@@ -34,6 +57,7 @@ type NoteResponse struct {
 	Content     string  `json:"content"`
 	CreatedTime string  `json:"created_time"`
 	UpdatedTime *string `json:"updated_time"`
+	Version     int     `json:"version"`
 }
 
 func formatTime(t time.Time) string {
@@ -48,37 +72,71 @@ func formatOptionalTime(t *time.Time) *string {
 	return &s
 }
 
+// CreateNoteRequest 구조체 정의 (createdAt, updatedAt은 가져오기가 허용된 경우에만 사용)
 type CreateNoteRequest struct {
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Img       string `json:"img"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	Title     string `json:"title" validate:"max=200"`
+	Content   string `json:"content" validate:"required,max=100000"`
+	Img       string `json:"img" validate:"max=2048,img"`
+	CreatedAt string `json:"createdAt" validate:"timestamp"`
+	UpdatedAt string `json:"updatedAt" validate:"timestamp"`
+}
+
+// clientTimes 함수 정의 (createdAt이 없으면 현재 시각)
+func (r *CreateNoteRequest) clientTimes() (time.Time, *time.Time) {
+	created := time.Now()
+	if r.CreatedAt != "" {
+		created, _ = time.Parse(time.RFC3339, r.CreatedAt)
+	}
+	if r.UpdatedAt == "" {
+		return created, nil
+	}
+	updated, _ := time.Parse(time.RFC3339, r.UpdatedAt)
+	return created, &updated
+}
+
+func (r *CreateNoteRequest) validateFields() []model.FieldError {
+	if r.UpdatedAt != "" && r.CreatedAt == "" {
+		return []model.FieldError{{Field: "createdAt", Message: "is required when updatedAt is set"}}
+	}
+	if r.CreatedAt == "" {
+		return nil
+	}
+	created, updated := r.clientTimes()
+	fields := checkClientTime("createdAt", created)
+	if updated != nil {
+		fields = append(fields, checkClientTime("updatedAt", *updated)...)
+		if updated.Before(created) {
+			fields = append(fields, model.FieldError{Field: "updatedAt", Message: "must not be before createdAt"})
+		}
+	}
+	return fields
+}
+
+// UpdateNoteRequest 구조체 정의 (PUT은 노트 전체를 바꾸므로 빠진 항목을 빈 값으로 덮어쓰지 않도록 세 항목 모두 키 필수)
+type UpdateNoteRequest struct {
+	Title   *string `json:"title" validate:"present,max=200"`
+	Content *string `json:"content" validate:"required,max=100000"`
+	Img     *string `json:"img" validate:"present,max=2048,img"`
 }
 
 // CreateNoteHandler 함수 정의
 func (h *NoteHandler) CreateNoteHandler(c echo.Context) error {
 	// JSON 데이터 수신
 	var req CreateNoteRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Invalid request format",
-		})
+	if err := bindStrict(c, &req); err != nil {
+		return err
 	}
-
-	// 기본 값 설정
-	img := req.Img
-	if img == "" {
-		img = "" // 서버에서 빈 문자열로 설정
+	if (req.CreatedAt != "" || req.UpdatedAt != "") && !h.AllowClientTimestamps {
+		return model.InvalidFields([]model.FieldError{{Field: "createdAt", Message: "client timestamps are not permitted on this server"}})
 	}
 
-	// 노트 생성
-	note, err := h.NoteService.CreateNote(req.Title, req.Content, img)
+	// 노트 생성 (가져오기면 원래 작성 시각 유지)
+	createdTime, updatedTime := req.clientTimes()
+	note, err := h.NoteService.CreateNoteAt(currentScope(c), req.Title, req.Content, req.Img, createdTime, updatedTime)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
 	}
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteCreate, note.ID, nil, note))
 	// 로그에 데이터 출력
 	log.Printf("Received request: %+v\n", req)
 
@@ -92,9 +150,11 @@ func (h *NoteHandler) CreateNoteHandler(c echo.Context) error {
 			Content:     note.Content,
 			CreatedTime: formatTime(note.CreatedTime),
 			UpdatedTime: formatOptionalTime(note.UpdatedTime),
+			Version:     note.Version,
 		},
 	}
 
+	c.Response().Header().Set(HeaderETag, noteETag(note))
 	return c.JSON(http.StatusCreated, response)
 }
 
@@ -103,31 +163,31 @@ func (h *NoteHandler) UpdateNoteHandler(c echo.Context) error {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Invalid ID format",
-		})
+		return model.Validation("Invalid ID format")
 	}
 
 	// JSON 데이터 파싱
-	var req struct {
-		Title   string `json:"title"`
-		Content string `json:"content"`
-		Img     string `json:"img"`
-	}
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Invalid request format",
-		})
+	var req UpdateNoteRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
 	}
 
-	// 노트 업데이트
-	note, err := h.NoteService.UpdateNote(id, req.Title, req.Content, req.Img)
+	// 노트 업데이트 (If-Match 확인과 감사 기록용으로 변경 전 노트 조회)
+	before, _, err := h.NoteService.GetAccessibleNote(currentScope(c), id)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
+	}
+	version, err := ifMatchVersion(c, before, h.RequireIfMatch)
+	if err != nil {
+		return err
+	}
+	note, err := h.NoteService.UpdateNoteIfVersion(currentScope(c), id, version, *req.Title, *req.Content, *req.Img)
+	if err != nil {
+		return err
 	}
 
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteUpdate, note.ID, before, note))
+
 	// 응답 생성
 	response := map[string]interface{}{
 		"message": "Note updated successfully",
@@ -138,19 +198,107 @@ func (h *NoteHandler) UpdateNoteHandler(c echo.Context) error {
 			Content:     note.Content,
 			CreatedTime: formatTime(note.CreatedTime),
 			UpdatedTime: formatOptionalTime(note.UpdatedTime),
+			Version:     note.Version,
 		},
 	}
 
+	c.Response().Header().Set(HeaderETag, noteETag(note))
 	return c.JSON(http.StatusOK, response)
 }
 
+// PATCH 요청 본문 형식
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+// PatchNoteHandler 함수 정의 (보낸 항목만 변경, application/json-patch+json이면 JSON Patch, 그 외 JSON은 JSON Merge Patch)
+func (h *NoteHandler) PatchNoteHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != MIMEJSONPatch && mediaType != MIMEMergePatch && mediaType != echo.MIMEApplicationJSON {
+		return model.NewError(model.ErrCodeUnsupportedMedia, "Content-Type must be "+MIMEMergePatch+" or "+MIMEJSONPatch)
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return model.Validation("Invalid request format")
+	}
+
+	before, _, err := h.NoteService.GetAccessibleNote(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+	// If-Match가 없어도 패치를 적용한 버전에서만 저장
+	version, err := ifMatchVersion(c, before, h.RequireIfMatch)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		version = before.Version
+	}
+
+	// 현재 노트에 패치 적용
+	doc := map[string]interface{}{
+		"title":   before.Title,
+		"content": before.Content,
+		"img":     before.Img,
+	}
+	var patched map[string]interface{}
+	if mediaType == MIMEJSONPatch {
+		patched, err = utils.ApplyJSONPatch(doc, body)
+	} else {
+		patched, err = utils.ApplyMergePatch(doc, body)
+	}
+	if errors.Is(err, utils.ErrPatchTestFailed) {
+		return model.Conflict(err.Error()).Wrap(err)
+	}
+	if err != nil {
+		return model.Validation(err.Error()).Wrap(err)
+	}
+
+	// 패치로 지운 제목과 이미지는 빈 값으로 저장하고 (내용은 지울 수 없음), 결과는 PUT과 같은 규칙으로 검증
+	for _, key := range []string{"title", "img"} {
+		if value, ok := patched[key]; !ok || value == nil {
+			patched[key] = ""
+		}
+	}
+	data, err := json.Marshal(patched)
+	if err != nil {
+		return err
+	}
+	var req UpdateNoteRequest
+	if err := decodeStrict(bytes.NewReader(data), &req); err != nil {
+		return err
+	}
+
+	note, err := h.NoteService.UpdateNoteIfVersion(currentScope(c), id, version, *req.Title, *req.Content, *req.Img)
+	if err != nil {
+		return err
+	}
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteUpdate, note.ID, before, note))
+
+	c.Response().Header().Set(HeaderETag, noteETag(note))
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Note updated successfully",
+		"note_info": noteToResponse(note),
+	})
+}
+
 // GetAllNotesHandler 함수 정의(노트 싹다 가져오기)
 func (h *NoteHandler) GetAllNotesHandler(c echo.Context) error {
-	notes, err := h.NoteService.GetAllNotes()
+	notes, err := h.NoteService.GetAllNotes(currentScope(c))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
+	}
+
+	etag := notesETag(notes)
+	c.Response().Header().Set(HeaderETag, etag)
+	if notModified(c, etag) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
 	// 응답 생성
@@ -163,50 +311,168 @@ func (h *NoteHandler) GetAllNotesHandler(c echo.Context) error {
 }
 
 // Note를 NoteResponse로 변환하는 함수
+func noteToResponse(note *model.Note) NoteResponse {
+	return NoteResponse{
+		ID:          note.ID,
+		Img:         note.Img,
+		Title:       note.Title,
+		Content:     note.Content,
+		CreatedTime: formatTime(note.CreatedTime),
+		UpdatedTime: formatOptionalTime(note.UpdatedTime),
+		Version:     note.Version,
+	}
+}
+
+// Note 목록을 NoteResponse 목록으로 변환하는 함수
 func notesToResponse(notes []*model.Note) []NoteResponse {
 	responses := make([]NoteResponse, len(notes))
 	for i, note := range notes {
-		responses[i] = NoteResponse{
-			ID:          note.ID,
-			Img:         note.Img,
-			Title:       note.Title,
-			Content:     note.Content,
-			CreatedTime: formatTime(note.CreatedTime),
-			UpdatedTime: formatOptionalTime(note.UpdatedTime),
-		}
+		responses[i] = noteToResponse(note)
 	}
 	return responses
 }
 
+// NoteRevisionResponse 구조체 정의
+type NoteRevisionResponse struct {
+	Revision    int    `json:"revision"`
+	Img         string `json:"img"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	CreatedTime string `json:"created_time"`
+}
+
+// GetNoteRevisionsHandler 함수 정의(노트 리비전 목록, 최신순)
+func (h *NoteHandler) GetNoteRevisionsHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if _, err := h.NoteService.GetNoteByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	revisions, err := h.NoteService.GetNoteRevisions(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]NoteRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = NoteRevisionResponse{
+			Revision:    revision.Revision,
+			Img:         revision.Img,
+			Title:       revision.Title,
+			Content:     revision.Content,
+			CreatedTime: formatTime(revision.CreatedTime),
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Revisions retrieved successfully",
+		"revisions": responses,
+	})
+}
+
+// RestoreNoteRevisionHandler 함수 정의 (노트를 해당 리비전 내용으로 되돌리고 새 리비전으로 저장)
+func (h *NoteHandler) RestoreNoteRevisionHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		return model.Validation("Invalid revision format")
+	}
+
+	before, _, err := h.NoteService.GetAccessibleNote(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatchVersion(c, before, h.RequireIfMatch)
+	if err != nil {
+		return err
+	}
+	note, err := h.NoteService.RestoreRevision(currentScope(c), id, version, revision)
+	if err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditNoteRestore, note.ID, before, note)
+	entry.Details = "restored revision " + strconv.Itoa(revision)
+	recordAudit(c, h.Audit, entry)
+
+	c.Response().Header().Set(HeaderETag, noteETag(note))
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Note restored successfully",
+		"note_info": noteToResponse(note),
+	})
+}
+
+// SearchNotesHandler 함수 정의(제목, 내용, 첨부 이미지 텍스트 검색)
+func (h *NoteHandler) SearchNotesHandler(c echo.Context) error {
+	query := c.QueryParam("q")
+	if query == "" {
+		return model.Validation("Query is required")
+	}
+
+	notes, err := h.NoteService.SearchNotes(currentScope(c), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Notes retrieved successfully",
+		"notes":   notesToResponse(notes),
+	})
+}
+
 // GetNoteByIDHandler 함수 정의(원하는 id에 해당하는 노트만 가져오기)
 func (h *NoteHandler) GetNoteByIDHandler(c echo.Context) error {
 	// URL에서 ID 추출
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return model.Validation("Invalid ID format")
 	}
-	// 서비스에서 ID에 해당하는 노트 조회
-	note, err := h.NoteService.GetNoteByID(id)
+	// 서비스에서 ID에 해당하는 노트 조회 (공유받은 노트 포함)
+	note, role, err := h.NoteService.GetAccessibleNote(currentScope(c), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
+	}
+
+	// 번역본은 원본 버전과 따로 바뀌므로 원본 조회에만 ETag 사용
+	if c.QueryParam("lang") == "" {
+		etag := noteETag(note)
+		c.Response().Header().Set(HeaderETag, etag)
+		if notModified(c, etag) {
+			return c.NoContent(http.StatusNotModified)
+		}
 	}
 
 	// 응답 생성
 	response := map[string]interface{}{
-		"message": "Note retrieved successfully",
-		"note_info": NoteResponse{
-			ID:          note.ID,
-			Img:         note.Img,
-			Title:       note.Title,
-			Content:     note.Content,
-			CreatedTime: formatTime(note.CreatedTime),
-			UpdatedTime: formatOptionalTime(note.UpdatedTime),
-		},
+		"message":     "Note retrieved successfully",
+		"note_info":   noteToResponse(note),
+		"access_role": role,
+	}
+
+	// lang 지정 시 번역본의 제목과 내용으로 응답
+	if lang := c.QueryParam("lang"); lang != "" {
+		// 잘못된 언어 코드는 400, 번역본이 없으면 404
+		translation, err := h.TranslationService.GetTranslation(id, lang)
+		if err != nil {
+			return err
+		}
+		translated := *note
+		translated.Title = translation.Title
+		translated.Content = translation.Content
+		response["note_info"] = noteToResponse(&translated)
+		response["translation_info"] = map[string]interface{}{
+			"language":        translation.Language,
+			"source_revision": translation.SourceRevision,
+			"stale":           translation.Stale,
+		}
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -217,32 +483,54 @@ func (h *NoteHandler) DeleteNoteHandler(c echo.Context) error {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Invalid ID format",
-		})
+		return model.Validation("Invalid ID format")
 	}
 
 	// 삭제할 노트 조회
-	_, err = h.NoteService.GetNoteByID(id)
+	before, err := h.NoteService.GetNoteByID(currentScope(c), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
+	}
+
+	version, err := ifMatchVersion(c, before, h.RequireIfMatch)
+	if err != nil {
+		return err
 	}
 
 	// 서비스 레이어에서 노트 삭제
-	err = h.NoteService.DeleteNote(id)
+	err = h.NoteService.DeleteNoteIfVersion(currentScope(c), id, version)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
 	}
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteDelete, id, before, nil))
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "Note deleted successfully",
 	})
 }
 
+// parseGenerationOverrides 함수 정의 (model, temperature, max_tokens 폼 값)
+func parseGenerationOverrides(c echo.Context) (service.GenerationOverrides, error) {
+	overrides := service.GenerationOverrides{Model: c.FormValue("model")}
+	if v := c.FormValue("temperature"); v != "" {
+		t, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return overrides, model.Validation("invalid temperature")
+		}
+		temperature := float32(t)
+		overrides.Temperature = &temperature
+	}
+	if v := c.FormValue("max_tokens"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return overrides, model.Validation("invalid max_tokens")
+		}
+		maxTokens := int32(n)
+		overrides.MaxOutputTokens = &maxTokens
+	}
+	return overrides, nil
+}
+
 // AnalyzeNoteHandler 함수 정의
 func (h *NoteHandler) AnalyzeNoteHandler(c echo.Context) error {
 	// ID 추출
@@ -251,35 +539,67 @@ func (h *NoteHandler) AnalyzeNoteHandler(c echo.Context) error {
 	// 노트 가져오기
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Invalid ID format",
-		})
+		return model.Validation("Invalid ID format")
 	}
 
 	// 노트 데이터 가져오기
-	note, err := h.NoteService.GetNoteByID(id)
+	note, err := h.NoteService.GetNoteByID(currentScope(c), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
 	}
 
-	// 요청 데이터 추출
+	// 요청 데이터 추출 (template을 지정하지 않으면 request 필수)
 	requestText := c.FormValue("request")
-	if requestText == "" {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error message": "Request text is required",
+	templateName := c.FormValue("template")
+	language := c.FormValue("language")
+	if requestText == "" && (templateName == "" || templateName == service.DefaultPromptTemplate) {
+		return model.Validation("Request text is required")
+	}
+
+	// 프롬프트 템플릿 적용
+	prompt, err := h.PromptService.RenderForNote(templateName, note, requestText, language)
+	if err != nil {
+		return err
+	}
+
+	// 모델, 생성 파라미터 변경 확인 (허용 목록 밖이면 400)
+	overrides, err := parseGenerationOverrides(c)
+	if err == nil {
+		_, err = h.GeminiService.Catalog.Resolve(overrides)
+	}
+	if err != nil {
+		return err
+	}
+
+	// async=true 이면 작업 큐에 넣고 작업 ID 반환
+	if c.FormValue("async") == "true" {
+		job, err := h.JobService.Enqueue(currentScope(c), service.JobTypeAnalyzeNote, service.AnalyzeJobPayload{
+			NoteID:   note.ID,
+			Request:  requestText,
+			Template: templateName,
+			Language: language,
+
+			Overrides: overrides,
+		})
+		if err != nil {
+			return err
+		}
+		recordAudit(c, h.Audit, noteAudit(service.AuditNoteAnalyze, note.ID, note, note))
+		return c.JSON(http.StatusAccepted, map[string]interface{}{
+			"message":  "Note analysis queued",
+			"job_info": jobToResponse(job),
 		})
 	}
 
 	// Gemini API 호출
-	analysisResult, err := h.GeminiService.AnalyzeNoteContentAndImage(context.Background(), note, requestText)
+	ctx := service.WithUsageInfo(c.Request().Context(), service.UsageInfo{NoteID: note.ID})
+	analysisResult, err := h.GeminiService.GenerateFromPrompt(ctx, prompt, overrides)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error message": err.Error(),
-		})
+		return err
 	}
 
+	recordAudit(c, h.Audit, noteAudit(service.AuditNoteAnalyze, note.ID, note, note))
+
 	// 응답 생성
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "Note analyzed successfully",
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// JobHandler 구조체 정의
+type JobHandler struct {
+	JobService *service.JobService
+}
+
+// NewJobHandler 함수 정의
+func NewJobHandler(jobService *service.JobService) *JobHandler {
+	return &JobHandler{JobService: jobService}
+}
+
+// JobResponse 구조체 정의
+type JobResponse struct {
+	ID          int     `json:"id"`
+	Type        string  `json:"type"`
+	Status      string  `json:"status"`
+	Attempts    int     `json:"attempts"`
+	MaxAttempts int     `json:"max_attempts"`
+	RunAt       string  `json:"run_at"`
+	LastError   string  `json:"last_error"`
+	Result      string  `json:"result"`
+	CreatedTime string  `json:"created_time"`
+	UpdatedTime *string `json:"updated_time"`
+}
+
+func jobToResponse(job *model.Job) JobResponse {
+	return JobResponse{
+		ID:          job.ID,
+		Type:        job.Type,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       formatTime(job.RunAt),
+		LastError:   job.LastError,
+		Result:      job.Result,
+		CreatedTime: formatTime(job.CreatedTime),
+		UpdatedTime: formatOptionalTime(job.UpdatedTime),
+	}
+}
+
+// GetJobHandler 함수 정의 (작업 상태 조회)
+func (h *JobHandler) GetJobHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	job, err := h.JobService.GetJob(currentUserID(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Job retrieved successfully",
+		"job_info": jobToResponse(job),
+	})
+}
+
+// CancelJobHandler 함수 정의 (대기 중이거나 실행 중인 작업 취소)
+func (h *JobHandler) CancelJobHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	job, err := h.JobService.CancelJob(currentUserID(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Job cancelled successfully",
+		"job_info": jobToResponse(job),
+	})
+}
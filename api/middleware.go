@@ -0,0 +1,183 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	userIDKey        = "user_id"
+	tokenScopesKey   = "token_scopes"
+	workspaceIDKey   = "workspace_id"
+	workspaceRoleKey = "workspace_role"
+)
+
+// HeaderWorkspaceID 요청할 워크스페이스를 지정하는 헤더 (없으면 개인 노트)
+const HeaderWorkspaceID = "X-Workspace-ID"
+
+// QueryAccessToken 이벤트 스트림 토큰을 보내는 쿼리 항목 (헤더를 붙일 수 없는 브라우저 EventSource, WebSocket용)
+const QueryAccessToken = "access_token"
+
+// AuthMiddleware 함수 정의 (Authorization: Bearer <access token 또는 개인 API 토큰> 검증 후 사용자 ID 저장)
+func AuthMiddleware(authService *service.AuthService, tokenService *service.APITokenService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			tokenString, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || tokenString == "" {
+				return model.Unauthorized("missing bearer token")
+			}
+
+			var userID int
+			if service.IsAPIToken(tokenString) {
+				token, err := tokenService.Authenticate(tokenString)
+				if err != nil {
+					return err
+				}
+				userID = token.UserID
+				c.Set(tokenScopesKey, token.Scopes)
+			} else {
+				id, err := authService.ParseAccessToken(tokenString)
+				if err != nil {
+					return err
+				}
+				userID = id
+			}
+
+			c.Set(userIDKey, userID)
+			// AI 사용량 기록에 사용자 정보 남김
+			ctx := service.WithUsageInfo(c.Request().Context(), service.UsageInfo{UserID: userID})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// StreamAuthMiddleware 함수 정의 (이벤트 스트림용, access_token 쿼리가 있으면 스트림 토큰으로 인증하고 없으면 AuthMiddleware와 같음)
+func StreamAuthMiddleware(authService *service.AuthService, tokenService *service.APITokenService) echo.MiddlewareFunc {
+	headerAuth := AuthMiddleware(authService, tokenService)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withHeader := headerAuth(next)
+		return func(c echo.Context) error {
+			tokenString := c.QueryParam(QueryAccessToken)
+			if tokenString == "" {
+				return withHeader(c)
+			}
+			userID, workspaceID, err := authService.ParseStreamToken(tokenString)
+			if err != nil {
+				return err
+			}
+
+			// 워크스페이스는 토큰에 담긴 값만 사용하고, 연결할 때 WorkspaceMiddleware가 멤버인지 다시 확인
+			c.Request().Header.Del(HeaderWorkspaceID)
+			if workspaceID != 0 {
+				c.Request().Header.Set(HeaderWorkspaceID, strconv.Itoa(workspaceID))
+			}
+			c.Set(userIDKey, userID)
+			// 스트림 토큰은 읽기 전용 API 토큰처럼 취급
+			c.Set(tokenScopesKey, []string{service.ScopeNotesRead})
+			return next(c)
+		}
+	}
+}
+
+// RequireScope 함수 정의 (개인 API 토큰은 scope 권한이 있어야 통과, 로그인 세션은 모든 권한)
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scopes, isAPIToken := c.Get(tokenScopesKey).([]string)
+			if !isAPIToken {
+				return next(c)
+			}
+			for _, s := range scopes {
+				if s == scope {
+					return next(c)
+				}
+			}
+			return model.Forbidden("token is missing scope " + scope)
+		}
+	}
+}
+
+// RequireSession 함수 정의 (개인 API 토큰으로는 접근 불가, 토큰 관리 등에 사용)
+func RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, isAPIToken := c.Get(tokenScopesKey).([]string); isAPIToken {
+			return model.Forbidden("this endpoint requires a login session")
+		}
+		return next(c)
+	}
+}
+
+// RequireAdmin 함수 정의 (관리자만 접근 가능)
+func RequireAdmin(authService *service.AuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			isAdmin, err := authService.IsAdmin(currentUserID(c))
+			if err != nil {
+				return err
+			}
+			if !isAdmin {
+				return model.Forbidden("admin privileges are required")
+			}
+			return next(c)
+		}
+	}
+}
+
+// WorkspaceMiddleware 함수 정의 (X-Workspace-ID 헤더가 있으면 멤버인지 확인 후 워크스페이스와 역할 저장, AuthMiddleware 다음에 사용)
+func WorkspaceMiddleware(workspaceService *service.WorkspaceService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(HeaderWorkspaceID)
+			if header == "" {
+				return next(c)
+			}
+			workspaceID, err := strconv.Atoi(header)
+			if err != nil || workspaceID <= 0 {
+				return model.Validation("Invalid " + HeaderWorkspaceID + " header")
+			}
+
+			role, err := workspaceService.GetRole(workspaceID, currentUserID(c))
+			if err != nil {
+				return err
+			}
+
+			c.Set(workspaceIDKey, workspaceID)
+			c.Set(workspaceRoleKey, role)
+			// AI 사용량을 워크스페이스별로 집계
+			ctx := service.WithUsageInfo(c.Request().Context(), service.UsageInfo{WorkspaceID: workspaceID})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// RequireWorkspaceRole 함수 정의 (워크스페이스 요청이면 min 이상의 역할 필요, 개인 노트 요청은 통과)
+func RequireWorkspaceRole(min string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, ok := c.Get(workspaceRoleKey).(string)
+			if ok && !model.WorkspaceRoleAtLeast(role, min) {
+				return model.Forbidden("workspace role " + min + " or higher is required")
+			}
+			return next(c)
+		}
+	}
+}
+
+// currentUserID 함수 정의 (AuthMiddleware가 저장한 사용자 ID)
+func currentUserID(c echo.Context) int {
+	userID, _ := c.Get(userIDKey).(int)
+	return userID
+}
+
+// currentScope 함수 정의 (사용자와 X-Workspace-ID로 지정한 워크스페이스)
+func currentScope(c echo.Context) model.Scope {
+	workspaceID, _ := c.Get(workspaceIDKey).(int)
+	return model.Scope{UserID: currentUserID(c), WorkspaceID: workspaceID}
+}
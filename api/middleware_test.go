@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"myapp/service"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestAPITokensOnlyReachRoutesTheirScopesAllow(t *testing.T) {
+	db, err := repository.Open(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	auth := service.NewAuthService(repository.NewUserRepository(db), repository.NewNoteRepository(db), strings.Repeat("k", 32), time.Minute, time.Hour)
+	if _, err := auth.Signup("script@example.com", "password123"); err != nil {
+		t.Fatal(err)
+	}
+	session, err := auth.Login("script@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := service.NewAPITokenService(repository.NewAPITokenRepository(db))
+	readToken, readOnly, err := tokens.CreateToken(1, "read", []string{service.ScopeNotesRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, writer, err := tokens.CreateToken(1, "write", []string{service.ScopeNotesRead, service.ScopeNotesWrite}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	authenticate := AuthMiddleware(auth, tokens)
+	routes := map[string]echo.HandlerFunc{
+		"read":    authenticate(RequireScope(service.ScopeNotesRead)(ok)),
+		"write":   authenticate(RequireScope(service.ScopeNotesWrite)(ok)),
+		"analyze": authenticate(RequireScope(service.ScopeAIAnalyze)(ok)),
+		"session": authenticate(RequireSession(ok)),
+	}
+	serve := func(route, token string) error {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		return routes[route](echo.New().NewContext(req, httptest.NewRecorder()))
+	}
+
+	for _, tt := range []struct {
+		token   string
+		name    string
+		allowed map[string]bool
+	}{
+		{session.AccessToken, "login session", map[string]bool{"read": true, "write": true, "analyze": true, "session": true}},
+		{readOnly, "notes:read token", map[string]bool{"read": true}},
+		{writer, "notes:read and notes:write token", map[string]bool{"read": true, "write": true}},
+	} {
+		for route := range routes {
+			err := serve(route, tt.token)
+			var forbidden *model.Error
+			switch {
+			case tt.allowed[route] && err != nil:
+				t.Errorf("%s on the %s route: %v, want allowed", tt.name, route, err)
+			case !tt.allowed[route] && (!errors.As(err, &forbidden) || forbidden.Code != model.ErrCodeForbidden):
+				t.Errorf("%s on the %s route: %v, want forbidden", tt.name, route, err)
+			}
+		}
+	}
+
+	if err := tokens.RevokeToken(1, readToken.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := serve("read", readOnly); !errors.Is(err, service.ErrInvalidToken) {
+		t.Fatalf("revoked token: %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestStreamAuthAcceptsOnlyStreamTokensInTheQuery(t *testing.T) {
+	db, err := repository.Open(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	auth := service.NewAuthService(repository.NewUserRepository(db), repository.NewNoteRepository(db), strings.Repeat("k", 32), time.Minute, time.Hour)
+	if _, err := auth.Signup("stream@example.com", "password123"); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := auth.Login("stream@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamToken, _, err := auth.IssueStreamToken(1, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scope model.Scope
+	var scopes []string
+	var workspaceHeader string
+	handler := StreamAuthMiddleware(auth, service.NewAPITokenService(repository.NewAPITokenRepository(db)))(func(c echo.Context) error {
+		scope = currentScope(c)
+		scopes, _ = c.Get(tokenScopesKey).([]string)
+		workspaceHeader = c.Request().Header.Get(HeaderWorkspaceID)
+		return c.NoContent(http.StatusOK)
+	})
+	serve := func(query, authorization string) error {
+		req := httptest.NewRequest(http.MethodGet, "/events?"+query, nil)
+		req.Header.Set(HeaderWorkspaceID, "3")
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+authorization)
+		}
+		return handler(echo.New().NewContext(req, httptest.NewRecorder()))
+	}
+
+	// 토큰에 담긴 워크스페이스가 헤더를 대신하고, 읽기 권한만 가짐
+	if err := serve(QueryAccessToken+"="+streamToken, ""); err != nil {
+		t.Fatal(err)
+	}
+	if scope.UserID != 1 || workspaceHeader != "7" || len(scopes) != 1 || scopes[0] != service.ScopeNotesRead {
+		t.Fatalf("stream token gave user %d, workspace header %q, scopes %v", scope.UserID, workspaceHeader, scopes)
+	}
+
+	// 오래 쓰는 액세스 토큰은 URL에 담을 수 없음
+	if err := serve(QueryAccessToken+"="+tokens.AccessToken, ""); !errors.Is(err, service.ErrInvalidToken) {
+		t.Fatalf("access token in the query: %v, want ErrInvalidToken", err)
+	}
+	// 스트림 토큰은 Authorization 헤더로도 다른 라우트에서도 쓸 수 없음
+	if err := serve("", streamToken); !errors.Is(err, service.ErrInvalidToken) {
+		t.Fatalf("stream token as a bearer token: %v, want ErrInvalidToken", err)
+	}
+	if err := serve("", tokens.AccessToken); err != nil || workspaceHeader != "3" {
+		t.Fatalf("bearer access token: %v (workspace header %q)", err, workspaceHeader)
+	}
+}
+
+func TestWorkspaceMiddlewareChecksMembershipAndRole(t *testing.T) {
+	db, err := repository.Open(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	users := repository.NewUserRepository(db)
+	for _, email := range []string{"owner@example.com", "guest@example.com", "outsider@example.com"} {
+		if _, err := users.Create(&model.User{Email: email, PasswordHash: "x", CreatedTime: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	workspaces := service.NewWorkspaceService(repository.NewWorkspaceRepository(db), users)
+	workspace, err := workspaces.CreateWorkspace(1, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := workspaces.Repo.AddMember(&model.WorkspaceMember{WorkspaceID: workspace.ID, UserID: 2, Role: model.WorkspaceRoleGuest, CreatedTime: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	var scope model.Scope
+	handler := WorkspaceMiddleware(workspaces)(RequireWorkspaceRole(model.WorkspaceRoleMember)(func(c echo.Context) error {
+		scope = currentScope(c)
+		return c.NoContent(http.StatusOK)
+	}))
+	serve := func(userID int, header string) error {
+		scope = model.Scope{}
+		req := httptest.NewRequest(http.MethodPost, "/notes", nil)
+		if header != "" {
+			req.Header.Set(HeaderWorkspaceID, header)
+		}
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		c.Set(userIDKey, userID)
+		return handler(c)
+	}
+	header := strconv.Itoa(workspace.ID)
+
+	if err := serve(1, header); err != nil || scope != (model.Scope{UserID: 1, WorkspaceID: workspace.ID}) {
+		t.Fatalf("owner: %v, scope %+v", err, scope)
+	}
+	// 개인 노트 요청은 역할과 상관없이 통과
+	if err := serve(2, ""); err != nil || scope != (model.Scope{UserID: 2}) {
+		t.Fatalf("personal request: %v, scope %+v", err, scope)
+	}
+	var forbidden *model.Error
+	if err := serve(2, header); !errors.As(err, &forbidden) || forbidden.Code != model.ErrCodeForbidden {
+		t.Fatalf("guest writing to the workspace: %v, want forbidden", err)
+	}
+	if err := serve(3, header); !errors.Is(err, service.ErrNotWorkspaceMember) {
+		t.Fatalf("outsider: %v, want ErrNotWorkspaceMember", err)
+	}
+	if err := serve(1, "0"); !errors.Is(err, model.Validation("Invalid "+HeaderWorkspaceID+" header")) {
+		t.Fatalf("invalid header: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"myapp/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ModelHandler 구조체 정의
+type ModelHandler struct {
+	Catalog *service.ModelCatalog
+}
+
+// NewModelHandler 함수 정의
+func NewModelHandler(catalog *service.ModelCatalog) *ModelHandler {
+	return &ModelHandler{Catalog: catalog}
+}
+
+// GetModelsHandler 함수 정의 (설정된 모델과 기능 목록)
+func (h *ModelHandler) GetModelsHandler(c echo.Context) error {
+	defaults := h.Catalog.Defaults
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Models retrieved successfully",
+		"models":  h.Catalog.Models,
+		"defaults": map[string]interface{}{
+			"model":              defaults.Model,
+			"temperature":        defaults.Temperature,
+			"max_output_tokens":  defaults.MaxOutputTokens,
+			"system_instruction": defaults.SystemInstruction != "",
+		},
+	})
+}
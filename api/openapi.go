@@ -0,0 +1,473 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// OpenAPISpec 구조체 정의 (OpenAPI 3 문서, 라우트 목록과 operationDocs로 생성)
+type OpenAPISpec struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       map[string]string                `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components map[string]interface{}           `json:"components"`
+}
+
+// Operation 구조체 정의
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter 구조체 정의
+type Parameter struct {
+	Name        string                 `json:"name"`
+	In          string                 `json:"in"`
+	Description string                 `json:"description,omitempty"`
+	Required    bool                   `json:"required,omitempty"`
+	Schema      map[string]interface{} `json:"schema"`
+}
+
+// RequestBody 구조체 정의
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response 구조체 정의
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header 구조체 정의
+type Header struct {
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema"`
+}
+
+// MediaType 구조체 정의
+type MediaType struct {
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// param 구조체 정의 (쿼리, 헤더, form 항목, Type은 string, integer, number, boolean, file)
+type param struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+}
+
+// resp 구조체 정의 (Body가 nil이면 본문 없음, MIME이 JSON이 아니면 Body 대신 문자열 본문)
+type resp struct {
+	Status      int
+	Description string
+	Body        interface{}
+	MIME        string
+	ETag        bool
+}
+
+// object 응답 본문 예시 (키별 값의 타입으로 스키마 생성)
+type object map[string]interface{}
+
+// doc 구조체 정의 (라우트 하나의 설명)
+type doc struct {
+	Summary     string
+	Description string
+	Tag         string
+	Query       []param
+	Headers     []param
+	Body        interface{}
+	Bodies      map[string]interface{}
+	Form        []param
+	Multipart   bool
+	Responses   []resp
+}
+
+// 문서 자체를 제공하는 경로 (명세에는 포함하지 않음)
+const (
+	openAPIPath    = "/openapi.json"
+	docsPath       = "/docs"
+	docsAssetsPath = docsPath + "/assets"
+)
+
+// routeKey 함수 정의 (operationDocs 키 "METHOD /path")
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// openAPIPathOf 함수 정의 (echo 경로의 :param을 {param}으로 변환)
+func openAPIPathOf(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operationID 함수 정의 (핸들러 이름에서 Handler를 뺀 이름, 예: createNote)
+func operationID(handler echo.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	name = name[strings.LastIndex(name, ".")+1:]
+	name = strings.TrimSuffix(name, "Handler")
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// schemaBuilder 구조체 정의 (이름 있는 구조체는 components.schemas에 한 번만 등록)
+type schemaBuilder struct {
+	components map[string]interface{}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaOf 함수 정의
+func (b *schemaBuilder) schemaOf(v interface{}) map[string]interface{} {
+	if obj, ok := v.(object); ok {
+		properties := map[string]interface{}{}
+		for name, value := range obj {
+			properties[name] = b.schemaOf(value)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	}
+	if v == nil {
+		return map[string]interface{}{}
+	}
+	return b.schemaOfType(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schemaOfType(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := b.schemaOfType(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t == rawMessageType {
+			return map[string]interface{}{}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		if _, ok := b.components[t.Name()]; !ok {
+			// 재귀 구조체를 위해 먼저 자리 확보
+			b.components[t.Name()] = map[string]interface{}{}
+			b.components[t.Name()] = b.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema 함수 정의 (json 태그와 validate 태그로 속성, 필수 항목, 길이 제한 표시)
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if parts := strings.Split(tag, ","); parts[0] != "" {
+				name = parts[0]
+			}
+		}
+		schema := b.schemaOfType(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			switch {
+			case rule == "required" || rule == "present":
+				required = append(required, name)
+			case rule == "timestamp":
+				schema["format"] = "date-time"
+			case strings.HasPrefix(rule, "max="):
+				if n, err := strconv.Atoi(strings.TrimPrefix(rule, "max=")); err == nil {
+					schema["maxLength"] = n
+				}
+			}
+		}
+		properties[name] = schema
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// paramSchema 함수 정의
+func paramSchema(typ string) map[string]interface{} {
+	if typ == "file" {
+		return map[string]interface{}{"type": "string", "format": "binary"}
+	}
+	return map[string]interface{}{"type": typ}
+}
+
+// pathParameters 함수 정의 (token은 문자열, 나머지 경로 변수는 정수 ID)
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		name := segment[1:]
+		typ := "integer"
+		if name == "token" {
+			typ = "string"
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: paramSchema(typ)})
+	}
+	return params
+}
+
+// buildOperation 함수 정의
+func (b *schemaBuilder) buildOperation(r route, d doc, authenticated bool) *Operation {
+	op := &Operation{
+		OperationID: operationID(r.Handler),
+		Summary:     d.Summary,
+		Description: d.Description,
+		Parameters:  pathParameters(r.Path),
+		Responses:   map[string]Response{},
+	}
+	if d.Tag != "" {
+		op.Tags = []string{d.Tag}
+	}
+	for _, q := range d.Query {
+		op.Parameters = append(op.Parameters, Parameter{Name: q.Name, In: "query", Description: q.Description, Required: q.Required, Schema: paramSchema(q.Type)})
+	}
+	for _, h := range d.Headers {
+		op.Parameters = append(op.Parameters, Parameter{Name: h.Name, In: "header", Description: h.Description, Required: h.Required, Schema: paramSchema(h.Type)})
+	}
+	if authenticated {
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+		op.Parameters = append(op.Parameters, Parameter{Name: HeaderWorkspaceID, In: "header", Description: "Workspace to act in (personal notes when omitted)", Schema: paramSchema("integer")})
+	}
+
+	// 요청 본문
+	content := map[string]MediaType{}
+	if d.Body != nil {
+		content[echo.MIMEApplicationJSON] = MediaType{Schema: b.schemaOf(d.Body)}
+	}
+	for mime, body := range d.Bodies {
+		content[mime] = MediaType{Schema: b.schemaOf(body)}
+	}
+	if len(d.Form) > 0 {
+		properties := map[string]interface{}{}
+		var required []string
+		for _, f := range d.Form {
+			schema := paramSchema(f.Type)
+			if f.Description != "" {
+				schema["description"] = f.Description
+			}
+			properties[f.Name] = schema
+			if f.Required {
+				required = append(required, f.Name)
+			}
+		}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		mime := echo.MIMEApplicationForm
+		if d.Multipart {
+			mime = echo.MIMEMultipartForm
+		}
+		content[mime] = MediaType{Schema: schema}
+	}
+	if len(content) > 0 {
+		op.RequestBody = &RequestBody{Required: true, Content: content}
+	}
+
+	// 응답
+	for _, r := range d.Responses {
+		response := Response{Description: r.Description}
+		if response.Description == "" {
+			response.Description = http.StatusText(r.Status)
+		}
+		switch {
+		case r.MIME != "":
+			response.Content = map[string]MediaType{r.MIME: {Schema: map[string]interface{}{"type": "string"}}}
+		case r.Body != nil:
+			response.Content = map[string]MediaType{echo.MIMEApplicationJSON: {Schema: b.schemaOf(r.Body)}}
+		}
+		if r.ETag {
+			response.Headers = map[string]Header{HeaderETag: {Description: "Current version of the resource", Schema: paramSchema("string")}}
+		}
+		op.Responses[strconv.Itoa(r.Status)] = response
+	}
+	op.Responses["default"] = Response{
+		Description: "Error",
+		Content:     map[string]MediaType{echo.MIMEApplicationJSON: {Schema: b.schemaOf(object{"error": ErrorBody{}})}},
+	}
+	return op
+}
+
+// buildOpenAPI 함수 정의 (v1 경로와 사용 중단된 이전 경로를 모두 포함)
+func buildOpenAPI(public, authenticated []route) *OpenAPISpec {
+	b := &schemaBuilder{components: map[string]interface{}{}}
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.3",
+		Info: map[string]string{
+			"title":       "myapp notes API",
+			"version":     "1.0.0",
+			"description": "Notes with AI analysis, sharing and workspaces. Unversioned paths are deprecated aliases of " + APIV1Prefix + ".",
+		},
+		Paths: map[string]map[string]*Operation{},
+	}
+
+	add := func(path, method string, op *Operation) {
+		p := openAPIPathOf(path)
+		if spec.Paths[p] == nil {
+			spec.Paths[p] = map[string]*Operation{}
+		}
+		spec.Paths[p][strings.ToLower(method)] = op
+	}
+	for _, group := range []struct {
+		routes        []route
+		authenticated bool
+	}{{public, false}, {authenticated, true}} {
+		for _, r := range group.routes {
+			d := operationDocs[routeKey(r.Method, r.Path)]
+			op := b.buildOperation(r, d, group.authenticated)
+			add(APIV1Prefix+r.Path, r.Method, op)
+			if r.Legacy != "" {
+				legacy := *op
+				legacy.Deprecated = true
+				if legacy.OperationID != "" {
+					legacy.OperationID += "Legacy"
+				}
+				add(r.Legacy, r.Method, &legacy)
+			}
+		}
+	}
+
+	spec.Components = map[string]interface{}{
+		"schemas": b.components,
+		"securitySchemes": map[string]interface{}{
+			"bearerAuth": map[string]string{"type": "http", "scheme": "bearer", "description": "Access token or personal API token"},
+		},
+	}
+	return spec
+}
+
+// CheckOpenAPI 함수 정의 (등록된 라우트와 명세가 서로 빠짐없이 일치하는지 확인)
+func CheckOpenAPI(routes []*echo.Route, spec *OpenAPISpec) error {
+	var problems []string
+	registered := map[string]bool{}
+	for _, r := range routes {
+		if r.Method == echo.RouteNotFound || r.Path == openAPIPath || r.Path == docsPath || strings.HasPrefix(r.Path, docsAssetsPath+"/") {
+			continue
+		}
+		key := routeKey(r.Method, r.Path)
+		if registered[key] {
+			continue
+		}
+		registered[key] = true
+		op := spec.Paths[openAPIPathOf(r.Path)][strings.ToLower(r.Method)]
+		switch {
+		case op == nil:
+			problems = append(problems, key+" is not in the spec")
+		case op.Summary == "":
+			problems = append(problems, key+" has no entry in operationDocs")
+		}
+	}
+	for path, ops := range spec.Paths {
+		for method := range ops {
+			echoPath := strings.NewReplacer("{", ":", "}", "").Replace(path)
+			if !registered[routeKey(strings.ToUpper(method), echoPath)] {
+				problems = append(problems, routeKey(strings.ToUpper(method), path)+" is in the spec but not registered")
+			}
+		}
+	}
+	for key := range operationDocs {
+		parts := strings.SplitN(key, " ", 2)
+		if !registered[routeKey(parts[0], APIV1Prefix+parts[1])] {
+			problems = append(problems, "operationDocs entry "+key+" has no route")
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%d problem(s):\n  %s", len(problems), strings.Join(problems, "\n  "))
+}
+
+// docsPage Swagger UI 페이지 (스크립트와 스타일은 바이너리에 포함된 swaggo/files 버전에서 제공, 외부 CDN을 쓰지 않음)
+const docsPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>myapp API docs</title>
+  <link rel="stylesheet" href="` + docsAssetsPath + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="` + docsAssetsPath + `/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + openAPIPath + `", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// registerDocs 함수 정의 (/openapi.json과 /docs 등록)
+func registerDocs(e *echo.Echo, spec *OpenAPISpec) {
+	e.GET(openAPIPath, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, spec)
+	})
+	e.GET(docsPath, func(c echo.Context) error {
+		return c.HTML(http.StatusOK, docsPage)
+	})
+	e.GET(docsAssetsPath+"/*", echo.WrapHandler(http.StripPrefix(docsAssetsPath, http.FileServer(http.FS(swaggerFiles.FS)))))
+}
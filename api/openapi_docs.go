@@ -0,0 +1,329 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"myapp/utils"
+	"net/http"
+)
+
+func ok(body object) resp       { return resp{Status: http.StatusOK, Body: body} }
+func created(body object) resp  { return resp{Status: http.StatusCreated, Body: body} }
+func accepted(body object) resp { return resp{Status: http.StatusAccepted, Body: body} }
+
+// withMessage 함수 정의 (성공 응답은 모두 message 항목 포함)
+func withMessage(fields object) object {
+	body := object{"message": ""}
+	for k, v := range fields {
+		body[k] = v
+	}
+	return body
+}
+
+// 자주 쓰는 응답, 헤더, 쿼리
+var (
+	messageOnly = ok(withMessage(nil))
+	noteBody    = withMessage(object{"note_info": NoteResponse{}})
+	jobQueued   = accepted(withMessage(object{"job_info": JobResponse{}}))
+
+	unchanged = resp{Status: http.StatusNotModified, Description: "If-None-Match matched the current ETag"}
+
+	ifMatch     = param{Name: HeaderIfMatch, Type: "string", Description: "ETag from a previous read; 412 when the note has changed since (required when REQUIRE_IF_MATCH is enabled)"}
+	ifNoneMatch = param{Name: HeaderIfNoneMatch, Type: "string", Description: "ETag from a previous read; 304 when unchanged"}
+
+	eventQuery = []param{
+		{Name: "types", Type: "string", Description: "Comma-separated event types to receive (default all)"},
+		{Name: "note_id", Type: "integer", Description: "Only events of this note"},
+		{Name: "last_event_id", Type: "integer", Description: "Resume after this event ID (" + HeaderLastEventID + " header takes precedence)"},
+		{Name: QueryAccessToken, Type: "string", Description: "Stream token from POST " + APIV1Prefix + "/events/token, for browsers that cannot send an Authorization header; " +
+			"it selects the workspace it was issued for and replaces the header"},
+	}
+
+	// 노트에 태그와 노트북이 없으므로 이벤트는 종류, 노트, 워크스페이스로만 고름
+	eventDescription = "Notes have no tags or notebooks, so a tag filter is rejected and the workspace in " + HeaderWorkspaceID +
+		" takes the place of a notebook filter. Events are kept for NOTE_EVENT_RETENTION_DAYS (30 by default); resuming from an older " +
+		"event ID returns 410 gone, after which the client reloads the notes and resumes from the latest event. " +
+		"Browsers pass a stream token in " + QueryAccessToken + " instead of the Authorization header; it is checked when the stream connects, " +
+		"so an open stream is not closed when the token expires."
+	cursorGone = resp{Status: http.StatusGone, Description: "The events after this cursor were pruned; reload everything and resume from the new cursor"}
+
+	usageQuery = []param{
+		{Name: "group_by", Type: "string", Description: "day (default), user, note or model"},
+		{Name: "from", Type: "string", Description: "YYYY-MM-DD"},
+		{Name: "to", Type: "string", Description: "YYYY-MM-DD, inclusive"},
+	}
+	// 비용은 AI_PRICING(모델별 토큰 100만 개당 USD)으로 계산한 추정치, 가격이 없는 모델은 0으로 계산하고 unpriced_models에 표시
+	usageBody = ok(withMessage(object{"group_by": "", "from": "", "to": "", "daily_token_quota": 0,
+		"estimated_cost_usd": 0.0, "unpriced_models": []string{}, "usage": []model.AIUsageSummary{}}))
+
+	// 기능이 이름으로 찾는 템플릿은 관리자도 바꿀 수 없음
+	internalTemplateNote = "Templates marked internal (analyze, extract_action_items, translate_variant and assist_*) are used by built-in features; " +
+		"creating, changing or deleting them returns 403."
+
+	generationForm = []param{
+		{Name: "model", Type: "string", Description: "Model from GET /models"},
+		{Name: "temperature", Type: "number"},
+		{Name: "max_tokens", Type: "integer"},
+	}
+)
+
+// operationDocs 라우트별 설명 ("METHOD /path", 경로는 APIV1Prefix 아래 경로)
+// 새 라우트를 추가하면 여기에도 추가해야 서버가 시작되고 테스트가 통과함 (CheckOpenAPI, openapi_test.go)
+var operationDocs = map[string]doc{
+	// 인증
+	"POST /auth/signup": {Summary: "Create an account and log in", Tag: "auth", Body: CredentialsRequest{},
+		Description: "Passwords must be 8 to 72 bytes. A new account never has admin rights; the operator grants them with `myapp grant-admin <email>`.",
+		Responses:   []resp{created(withMessage(object{"user_info": UserResponse{}, "tokens": service.AuthTokens{}}))}},
+	"POST /auth/login": {Summary: "Log in with email and password", Tag: "auth", Body: CredentialsRequest{},
+		Responses: []resp{ok(withMessage(object{"tokens": service.AuthTokens{}}))}},
+	"POST /auth/refresh": {Summary: "Exchange a refresh token for new tokens", Tag: "auth", Body: RefreshTokenRequest{},
+		Responses: []resp{ok(withMessage(object{"tokens": service.AuthTokens{}}))}},
+	"POST /auth/logout": {Summary: "Revoke a refresh token", Tag: "auth", Body: RefreshTokenRequest{},
+		Responses: []resp{messageOnly}},
+	"GET /auth/me": {Summary: "Current user", Tag: "auth",
+		Responses: []resp{ok(withMessage(object{"user_info": UserResponse{}}))}},
+	"POST /auth/tokens": {Summary: "Create a personal API token (the raw token is only returned here)", Tag: "auth", Body: CreateAPITokenRequest{},
+		Responses: []resp{created(withMessage(object{"token": "", "token_info": APITokenResponse{}}))}},
+	"GET /auth/tokens": {Summary: "List personal API tokens", Tag: "auth",
+		Responses: []resp{ok(withMessage(object{"tokens": []APITokenResponse{}}))}},
+	"DELETE /auth/tokens/:id": {Summary: "Revoke a personal API token", Tag: "auth",
+		Responses: []resp{messageOnly}},
+
+	// 공개 링크
+	"GET /public/notes/:token": {Summary: "Read a note through a public share link (HTML when Accept is text/html)", Tag: "public",
+		Description: "Passwords are only accepted in the " + HeaderSharePassword + " header or through POST. img is a signed image URL valid for a few minutes.",
+		Headers:     []param{{Name: HeaderSharePassword, Type: "string", Description: "Link password"}},
+		Responses:   []resp{ok(noteBody)}},
+	"POST /public/notes/:token": {Summary: "Read a password-protected note by submitting the password form", Tag: "public",
+		Form:      []param{{Name: "password", Type: "string", Required: true}},
+		Responses: []resp{ok(noteBody)}},
+	"GET /public/notes/:token/image": {Summary: "Image of a publicly shared note (signed URL from the note response, or the password header)", Tag: "public",
+		Query: []param{
+			{Name: "expires", Type: "integer", Description: "Signature expiry (unix seconds)"},
+			{Name: "sig", Type: "string", Description: "Signature from the note response"},
+		},
+		Headers:   []param{{Name: HeaderSharePassword, Type: "string"}},
+		Responses: []resp{{Status: http.StatusOK, MIME: "image/*"}, {Status: http.StatusFound, Description: "Redirect to an external image"}}},
+
+	// 워크스페이스
+	"POST /workspaces": {Summary: "Create a workspace", Tag: "workspaces", Body: CreateWorkspaceRequest{},
+		Responses: []resp{created(withMessage(object{"workspace_info": WorkspaceResponse{}}))}},
+	"GET /workspaces": {Summary: "List workspaces the user belongs to", Tag: "workspaces",
+		Responses: []resp{ok(withMessage(object{"workspaces": []WorkspaceResponse{}}))}},
+	"GET /workspaces/:id/members": {Summary: "List workspace members", Tag: "workspaces",
+		Responses: []resp{ok(withMessage(object{"members": []WorkspaceMemberResponse{}}))}},
+	"PUT /workspaces/:id/members/:user_id": {Summary: "Change a member's role", Tag: "workspaces", Body: UpdateMemberRequest{},
+		Responses: []resp{messageOnly}},
+	"DELETE /workspaces/:id/members/:user_id": {Summary: "Remove a member", Tag: "workspaces",
+		Responses: []resp{messageOnly}},
+	"POST /workspaces/:id/invitations": {Summary: "Invite a user by email (the raw token is only returned here)", Tag: "workspaces", Body: InviteMemberRequest{},
+		Responses: []resp{created(withMessage(object{"token": "", "invitation_info": InvitationResponse{}}))}},
+	"GET /workspaces/:id/invitations": {Summary: "List pending invitations", Tag: "workspaces",
+		Responses: []resp{ok(withMessage(object{"invitations": []InvitationResponse{}}))}},
+	"DELETE /workspaces/:id/invitations/:invitation_id": {Summary: "Revoke an invitation", Tag: "workspaces",
+		Responses: []resp{messageOnly}},
+	"POST /invitations/accept": {Summary: "Accept a workspace invitation", Tag: "workspaces", Body: AcceptInvitationRequest{},
+		Responses: []resp{ok(withMessage(object{"workspace_info": WorkspaceResponse{}}))}},
+
+	// 노트
+	"POST /notes": {Summary: "Create a note", Tag: "notes", Body: CreateNoteRequest{},
+		Responses: []resp{{Status: http.StatusCreated, Body: noteBody, ETag: true}}},
+	"GET /notes": {Summary: "List notes", Tag: "notes", Headers: []param{ifNoneMatch},
+		Responses: []resp{{Status: http.StatusOK, Body: withMessage(object{"notes": []NoteResponse{}}), ETag: true}, unchanged}},
+	"GET /notes/search": {Summary: "Full-text search", Tag: "notes",
+		Query:     []param{{Name: "q", Type: "string", Required: true}},
+		Responses: []resp{ok(withMessage(object{"notes": []NoteResponse{}}))}},
+	"GET /notes/shared": {Summary: "Notes shared with the current user", Tag: "sharing",
+		Responses: []resp{ok(withMessage(object{"notes": []NoteResponse{}}))}},
+	"GET /notes/duplicates": {Summary: "Find exact and near-duplicate notes", Tag: "notes",
+		Query:     []param{{Name: "threshold", Type: "number", Description: "Similarity threshold between 0 and 1 (default 0.8)"}},
+		Responses: []resp{ok(withMessage(object{"threshold": 0.0, "groups": []service.DuplicateGroup{}}))}},
+	"POST /notes/merge": {Summary: "Merge notes into a target note and delete the sources", Tag: "notes", Body: MergeNotesRequest{},
+		Responses: []resp{ok(noteBody)}},
+	"POST /notes/batch": {Summary: "Create, update, delete or move many notes in one transaction (tag operations are not supported)", Tag: "notes", Body: BatchRequest{},
+		Description: "op is one of create, update, delete, move. Notes have no tags, so op \"tag\" is rejected with a validation error " +
+			"and nothing in the batch is run. When the server requires If-Match, update, delete and move must carry the note's current version.",
+		Responses: []resp{ok(withMessage(object{"mode": "", "failed": 0, "results": []BatchResultResponse{}}))}},
+	"GET /notes/:id": {Summary: "Get a note (owned or shared)", Tag: "notes", Headers: []param{ifNoneMatch},
+		Query: []param{{Name: "lang", Type: "string", Description: "Return a stored translation instead of the original"}},
+		Responses: []resp{{Status: http.StatusOK, ETag: true, Body: withMessage(object{
+			"note_info":        NoteResponse{},
+			"access_role":      "",
+			"translation_info": object{"language": "", "source_revision": 0, "stale": false},
+		})}, unchanged}},
+	"PUT /notes/:id": {Summary: "Replace a note", Tag: "notes", Body: UpdateNoteRequest{}, Headers: []param{ifMatch},
+		Responses: []resp{{Status: http.StatusOK, Body: noteBody, ETag: true}}},
+	"PATCH /notes/:id": {Summary: "Partially update a note with JSON Merge Patch or JSON Patch", Tag: "notes", Headers: []param{ifMatch},
+		Bodies: map[string]interface{}{
+			MIMEMergePatch: object{"title": "", "content": "", "img": ""},
+			MIMEJSONPatch:  []utils.PatchOperation{},
+		},
+		Responses: []resp{{Status: http.StatusOK, Body: noteBody, ETag: true}}},
+	"DELETE /notes/:id": {Summary: "Delete a note", Tag: "notes", Headers: []param{ifMatch},
+		Responses: []resp{messageOnly}},
+	"GET /notes/:id/revisions": {Summary: "Revision history, newest first", Tag: "notes",
+		Responses: []resp{ok(withMessage(object{"revisions": []NoteRevisionResponse{}}))}},
+	"POST /notes/:id/revisions/:revision/restore": {Summary: "Restore a revision", Tag: "notes", Headers: []param{ifMatch},
+		Description: "Sets the title, content and image back to the given revision number and saves the result as a new revision; the history is kept.",
+		Responses:   []resp{{Status: http.StatusOK, Body: noteBody, ETag: true}}},
+	"POST /notes/:id/analyze": {Summary: "Analyze a note with a prompt template", Tag: "ai",
+		Form: append([]param{
+			{Name: "request", Type: "string", Description: "Required for the default template"},
+			{Name: "template", Type: "string"},
+			{Name: "language", Type: "string"},
+			{Name: "async", Type: "boolean", Description: "Queue a job instead of waiting"},
+		}, generationForm...),
+		Responses: []resp{ok(withMessage(object{"result": ""})), jobQueued}},
+	"POST /notes/:id/assist": {Summary: "Propose an AI edit without changing the note", Tag: "ai", Body: AssistRequest{},
+		Responses: []resp{ok(withMessage(object{"proposal": service.AssistProposal{}}))}},
+	"POST /notes/:id/assist/apply": {Summary: "Apply a proposed edit as a new revision", Tag: "ai", Body: ApplyAssistRequest{},
+		Description: "base_revision is the proposal's base_revision; 409 when the note has a newer revision.", Headers: []param{ifMatch},
+		Responses: []resp{ok(noteBody)}},
+	"POST /notes/:id/translate": {Summary: "Translate a note or refresh its translation", Tag: "ai", Body: TranslateNoteRequest{},
+		Responses: []resp{ok(withMessage(object{"translation_info": TranslationResponse{}})), jobQueued}},
+	"GET /notes/:id/translations": {Summary: "List stored translations", Tag: "notes",
+		Responses: []resp{ok(withMessage(object{"translations": []TranslationResponse{}}))}},
+	"GET /notes/:id/tasks": {Summary: "Tasks found in a note", Tag: "tasks",
+		Responses: []resp{ok(withMessage(object{"tasks": []TaskResponse{}}))}},
+	"POST /notes/:id/tasks/extract": {Summary: "Extract tasks from a note with AI", Tag: "ai",
+		Responses: []resp{ok(withMessage(object{"tasks": []TaskResponse{}}))}},
+	"POST /notes/:id/attachments": {Summary: "Upload an image attachment (413 above MAX_UPLOAD_MB, default 10; at most 40 megapixels)", Tag: "notes",
+		Form: []param{{Name: "file", Type: "file", Required: true}}, Multipart: true,
+		Responses: []resp{created(withMessage(object{"attachment_info": AttachmentResponse{}}))}},
+	"GET /notes/:id/attachments": {Summary: "List attachments", Tag: "notes",
+		Responses: []resp{ok(withMessage(object{"attachments": []AttachmentResponse{}}))}},
+
+	// 공유
+	"POST /notes/:id/shares": {Summary: "Share a note with another user", Tag: "sharing", Body: ShareNoteRequest{},
+		Responses: []resp{ok(withMessage(object{"share_info": NoteShareResponse{}}))}},
+	"GET /notes/:id/shares": {Summary: "List users a note is shared with", Tag: "sharing",
+		Responses: []resp{ok(withMessage(object{"shares": []NoteShareResponse{}}))}},
+	"DELETE /notes/:id/shares/:user_id": {Summary: "Stop sharing a note with a user", Tag: "sharing",
+		Responses: []resp{messageOnly}},
+	"POST /notes/:id/links": {Summary: "Create a public share link (the raw token is only returned here)", Tag: "sharing", Body: CreateShareLinkRequest{},
+		Responses: []resp{created(withMessage(object{"token": "", "url": "", "link_info": ShareLinkResponse{}}))}},
+	"GET /notes/:id/links": {Summary: "List public share links", Tag: "sharing",
+		Responses: []resp{ok(withMessage(object{"links": []ShareLinkResponse{}}))}},
+	"DELETE /notes/:id/links/:link_id": {Summary: "Revoke a public share link", Tag: "sharing",
+		Responses: []resp{messageOnly}},
+
+	// 노트 변경 이벤트
+	"POST /events/token": {Summary: "Create a short-lived token for connecting to the event streams from a browser", Tag: "events",
+		Description: "EventSource and browser WebSockets cannot send an Authorization header, so pass this token as ?" + QueryAccessToken + "= to GET " +
+			APIV1Prefix + "/events or " + APIV1Prefix + "/events/ws. It is valid for one minute, only for those two routes and only for the workspace in " +
+			HeaderWorkspaceID + " (or personal notes); membership is checked again when the stream connects.",
+		Responses: []resp{created(withMessage(object{"stream_token": StreamTokenResponse{}}))}},
+	"GET /events": {Summary: "Stream note change events as Server-Sent Events (events of the workspace in X-Workspace-ID, or of personal notes)", Tag: "events",
+		Description: eventDescription,
+		Query:       eventQuery,
+		Headers:     []param{{Name: HeaderLastEventID, Type: "integer", Description: "Resume after this event ID (sent automatically by EventSource on reconnect)"}},
+		Responses: []resp{{Status: http.StatusOK, MIME: MIMETextEventStream,
+			Description: "One `id:`, `event:` (note.created, note.updated, note.deleted, note.moved) and `data:` (JSON like NoteEventResponse) block per event; `: ping` comments keep the connection open"}, cursorGone}},
+	"GET /events/ws": {Summary: "Stream note change events over WebSocket (one NoteEventResponse JSON text message per event)", Tag: "events",
+		Description: eventDescription,
+		Query:       eventQuery,
+		Responses:   []resp{{Status: http.StatusSwitchingProtocols, Description: "WebSocket connection; {\"type\": \"ping\"} messages keep it open"}, cursorGone}},
+
+	// 웹훅
+	"POST /webhooks": {Summary: "Subscribe a URL to note events of the current scope (the secret is only returned here). " +
+		"Deliveries are POSTed with " + service.WebhookHeaderEvent + ", " + service.WebhookHeaderDelivery + ", " + service.WebhookHeaderTimestamp + " and " +
+		service.WebhookHeaderSignature + ` = "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)); non-2xx responses are retried with exponential backoff. ` +
+		"The URL must not resolve to a loopback, private, link-local or metadata address (unless listed in WEBHOOK_ALLOWED_HOSTS); redirects are not followed. " +
+		"A workspace webhook stops delivering when its creator leaves the workspace or drops below admin. " +
+		"The payload carries the note only while it is in the webhook's scope, so note.moved out of the scope is sent without it",
+		Tag: "webhooks", Body: WebhookRequest{},
+		Responses: []resp{created(withMessage(object{"secret": "", "webhook_info": WebhookResponse{}}))}},
+	"GET /webhooks": {Summary: "List webhooks of the current scope", Tag: "webhooks",
+		Responses: []resp{ok(withMessage(object{"webhooks": []WebhookResponse{}}))}},
+	"GET /webhooks/:id": {Summary: "Get a webhook", Tag: "webhooks",
+		Responses: []resp{ok(withMessage(object{"webhook_info": WebhookResponse{}}))}},
+	"PUT /webhooks/:id": {Summary: "Update a webhook (an empty secret keeps the current one)", Tag: "webhooks", Body: WebhookRequest{},
+		Responses: []resp{ok(withMessage(object{"webhook_info": WebhookResponse{}}))}},
+	"DELETE /webhooks/:id": {Summary: "Delete a webhook and its delivery log", Tag: "webhooks",
+		Responses: []resp{messageOnly}},
+	"POST /webhooks/:id/test": {Summary: "Send a ping delivery now and return the receiver's response (no retries)", Tag: "webhooks",
+		Responses: []resp{ok(withMessage(object{"delivery_info": WebhookDeliveryResponse{}}))}},
+	"GET /webhooks/:id/deliveries": {Summary: "Delivery log, newest first", Tag: "webhooks",
+		Query:     []param{{Name: "limit", Type: "integer", Description: "Default 50, at most 200"}},
+		Responses: []resp{ok(withMessage(object{"deliveries": []WebhookDeliveryResponse{}}))}},
+	"POST /webhooks/:id/deliveries/:delivery_id/replay": {Summary: "Send a logged delivery again as a new delivery", Tag: "webhooks",
+		Responses: []resp{accepted(withMessage(object{"delivery_info": WebhookDeliveryResponse{}, "job_info": JobResponse{}}))}},
+
+	// 오프라인 동기화
+	"GET /sync": {Summary: "Notes changed since a sync cursor, with tombstones for deleted notes", Tag: "sync",
+		Description: "Without a cursor every note of the current scope is returned (full = true). Otherwise each note changed after the cursor " +
+			"is returned once with its current state, or with deleted = true when it was deleted or moved out of the scope. " +
+			"The cursor is opaque; store it after applying the changes and repeat the request while has_more is true. " +
+			"Shared notes are not synced. A cursor older than the event retention (NOTE_EVENT_RETENTION_DAYS) returns 410 gone; pull again without a cursor.",
+		Query: []param{
+			{Name: "cursor", Type: "string", Description: "Cursor from the previous pull (omit for a full sync)"},
+			{Name: "limit", Type: "integer", Description: "Events read per page, at most 500"},
+		},
+		Responses: []resp{ok(withMessage(object{"full": false, "cursor": "", "has_more": false, "changes": []SyncedNoteResponse{}})), cursorGone}},
+	"POST /sync": {Summary: "Apply offline changes; each change gets its own result and failures do not affect the others", Tag: "sync", Body: SyncPushRequest{},
+		Description: "Target a note by id (base_version required) or by a client-generated client_id; resending a client_id never creates a second note. " +
+			"Conflict policy: " +
+			"an upsert whose base_version is not the current version keeps the server note and saves the client content as a new \"(conflicted copy)\" note (resolution conflict_copy); " +
+			"an upsert of a note deleted on the server creates it again (recreated); " +
+			"a delete whose base_version is not the current version keeps the server note (server_wins); " +
+			"a delete of a note that no longer exists succeeds (already_deleted); " +
+			"an upsert with the same title, content and img as the server note is not a conflict (unchanged).",
+		Responses: []resp{ok(withMessage(object{"conflicts": 0, "failed": 0, "results": []SyncResultResponse{}}))}},
+
+	// 할 일, 작업
+	"GET /tasks": {Summary: "List tasks across notes", Tag: "tasks",
+		Query:     []param{{Name: "done", Type: "boolean"}},
+		Responses: []resp{ok(withMessage(object{"tasks": []TaskResponse{}}))}},
+	"PUT /tasks/:id": {Summary: "Mark a task done or not done", Tag: "tasks", Body: UpdateTaskRequest{},
+		Responses: []resp{ok(withMessage(object{"task_info": TaskResponse{}}))}},
+	"POST /tasks/:id/complete": {Summary: "Mark a task done", Tag: "tasks",
+		Responses: []resp{ok(withMessage(object{"task_info": TaskResponse{}}))}},
+	"GET /jobs/:id": {Summary: "Background job status and result", Tag: "jobs",
+		Responses: []resp{ok(withMessage(object{"job_info": JobResponse{}}))}},
+	"POST /jobs/:id/cancel": {Summary: "Cancel a queued or running job", Tag: "jobs",
+		Responses: []resp{ok(withMessage(object{"job_info": JobResponse{}}))}},
+
+	// 프롬프트 템플릿, AI 사용량, 모델
+	"GET /prompts": {Summary: "List prompt templates", Tag: "ai",
+		Responses: []resp{ok(withMessage(object{"templates": []PromptTemplateResponse{}}))}},
+	"POST /prompts": {Summary: "Create a prompt template (admin only; templates are shared by all users)", Tag: "ai",
+		Description: internalTemplateNote, Body: PromptTemplateRequest{},
+		Responses: []resp{created(withMessage(object{"template_info": PromptTemplateResponse{}}))}},
+	"GET /prompts/:id": {Summary: "Get a prompt template", Tag: "ai",
+		Responses: []resp{ok(withMessage(object{"template_info": PromptTemplateResponse{}}))}},
+	"PUT /prompts/:id": {Summary: "Update a prompt template (admin only; templates are shared by all users)", Tag: "ai",
+		Description: internalTemplateNote, Body: PromptTemplateRequest{},
+		Responses: []resp{ok(withMessage(object{"template_info": PromptTemplateResponse{}}))}},
+	"DELETE /prompts/:id": {Summary: "Delete a prompt template (admin only; templates are shared by all users)", Tag: "ai",
+		Description: internalTemplateNote,
+		Responses:   []resp{messageOnly}},
+	"GET /usage": {Summary: "AI token usage and estimated cost of the workspace in X-Workspace-ID, or of the caller's own calls", Tag: "ai",
+		Query: usageQuery, Responses: []resp{usageBody}},
+	"GET /models": {Summary: "Models that can be selected per request and the server defaults", Tag: "ai",
+		Responses: []resp{ok(withMessage(object{
+			"models":   []service.ModelInfo{},
+			"defaults": object{"model": "", "temperature": (*float32)(nil), "max_output_tokens": (*int32)(nil), "system_instruction": false},
+		}))}},
+
+	// 관리자
+	"GET /admin/audit": {Summary: "Query the audit log", Tag: "admin", Query: auditQuery,
+		Description: "Entries are written on a best-effort basis after the change is committed, so a change can exist without an entry when recording fails. " +
+			"ip is the connecting address, or the X-Forwarded-For client when the request came through a trusted proxy (TRUSTED_PROXIES).",
+		Responses: []resp{ok(withMessage(object{"entries": []AuditEntryResponse{}}))}},
+	"GET /admin/audit/export": {Summary: "Export the audit log as JSON Lines", Tag: "admin", Query: auditQuery,
+		Responses: []resp{{Status: http.StatusOK, MIME: "application/x-ndjson", Description: "One AuditEntryResponse per line"}}},
+	"GET /admin/usage": {Summary: "AI token usage and estimated cost of all users and workspaces", Tag: "admin",
+		Query: usageQuery, Responses: []resp{usageBody}},
+}
+
+var auditQuery = []param{
+	{Name: "actor_id", Type: "integer"},
+	{Name: "action", Type: "string"},
+	{Name: "target_type", Type: "string"},
+	{Name: "target_id", Type: "integer"},
+	{Name: "workspace_id", Type: "integer"},
+	{Name: "since", Type: "string", Description: "RFC3339 or YYYY-MM-DD"},
+	{Name: "until", Type: "string", Description: "RFC3339 or YYYY-MM-DD"},
+	{Name: "limit", Type: "integer"},
+	{Name: "offset", Type: "integer"},
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"myapp/repository"
+	"myapp/service"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// shapeServer 구조체 정의 (실제 서비스와 임시 DB로 만든 서버, 요청마다 일치한 라우트 경로를 기록)
+type shapeServer struct {
+	t       *testing.T
+	e       *echo.Echo
+	spec    map[string]interface{}
+	token   string
+	matched string
+}
+
+// newShapeServer 함수 정의 (Gemini가 필요 없는 핸들러만 실제 서비스로 구성)
+func newShapeServer(t *testing.T) *shapeServer {
+	t.Helper()
+	db, err := repository.Open(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+
+	audit := service.NewAuditService(repository.NewAuditRepository(db))
+	users := repository.NewUserRepository(db)
+	notes := repository.NewNoteRepository(db)
+	authService := service.NewAuthService(users, notes, strings.Repeat("k", 32), time.Minute, time.Hour)
+	tokenService := service.NewAPITokenService(repository.NewAPITokenRepository(db))
+	workspaceService := service.NewWorkspaceService(repository.NewWorkspaceRepository(db), users)
+	taskRepo := repository.NewTaskRepository(db)
+	shareRepo := repository.NewNoteShareRepository(db)
+	events := service.NewEventBus(repository.NewNoteEventRepository(db))
+	noteService := service.NewNoteService(db, notes, repository.NewNoteRevisionRepository(db), repository.NewNoteTranslationRepository(db), taskRepo, shareRepo, events)
+	promptService := service.NewPromptService(repository.NewPromptTemplateRepository(db))
+	if err := promptService.SeedDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := service.NewModelCatalog(service.ModelCatalogOptions{Models: []string{"gemini-1.5-flash"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobService := service.NewJobService(repository.NewJobRepository(db))
+	translationService := service.NewTranslationService(noteService, promptService, nil)
+
+	s := &shapeServer{t: t, e: echo.New()}
+	s.e.HTTPErrorHandler = HTTPErrorHandler
+	s.e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			s.matched = c.Path()
+			return next(c)
+		}
+	})
+	spec := RegisterRoutes(s.e,
+		NewNoteHandler(noteService, nil, jobService, promptService, translationService, audit, false, false),
+		&AttachmentHandler{}, NewJobHandler(jobService), NewPromptHandler(promptService, audit),
+		NewUsageHandler(service.NewUsageService(repository.NewAIUsageRepository(db), 0, nil)), NewModelHandler(catalog),
+		&AssistHandler{}, NewTranslationHandler(noteService, translationService, jobService), &DuplicateHandler{},
+		NewTaskHandler(service.NewTaskService(taskRepo, noteService, promptService, nil), audit), NewAuthHandler(authService),
+		NewShareHandler(noteService, service.NewShareService(shareRepo, noteService, users, strings.Repeat("k", 32)), audit),
+		NewAPITokenHandler(tokenService, audit), NewWorkspaceHandler(workspaceService, audit), NewAuditHandler(audit),
+		NewBatchHandler(service.NewBatchService(db, noteService, workspaceService), audit, false), NewEventHandler(events, authService), &WebhookHandler{},
+		NewSyncHandler(service.NewSyncService(db, noteService, repository.NewSyncClientIDRepository(db)), audit),
+		AuthMiddleware(authService, tokenService), StreamAuthMiddleware(authService, tokenService), WorkspaceMiddleware(workspaceService), RequireAdmin(authService), time.Time{})
+
+	// 클라이언트가 받는 것과 같은 JSON 문서로 검사
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &s.spec); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// call 함수 정의 (요청 본문과 JSON 응답을 명세의 해당 operation 스키마로 검사하고 응답 반환)
+func (s *shapeServer) call(method, path string, body interface{}, status int) interface{} {
+	s.t.Helper()
+	var reader *bytes.Reader
+	var sent interface{}
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		json.Unmarshal(raw, &sent)
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, APIV1Prefix+path, reader)
+	if body != nil {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if s.token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+s.token)
+	}
+	rec := httptest.NewRecorder()
+	s.matched = ""
+	s.e.ServeHTTP(rec, req)
+	if rec.Code != status {
+		s.t.Fatalf("%s %s = %d, want %d: %s", method, path, rec.Code, status, rec.Body.String())
+	}
+
+	op, _ := s.spec["paths"].(map[string]interface{})[openAPIPathOf(s.matched)].(map[string]interface{})[strings.ToLower(method)].(map[string]interface{})
+	if op == nil {
+		s.t.Fatalf("%s %s matched %q, which is not in the spec", method, path, s.matched)
+	}
+	name := method + " " + s.matched
+
+	// 거부되어야 하는 요청은 일부러 명세와 다르게 보냄
+	if body != nil && status < 400 {
+		schema := s.mediaSchema(op["requestBody"], name+" request")
+		s.checkShape(name+" request", schema, sent)
+	}
+
+	responses, _ := op["responses"].(map[string]interface{})
+	response, ok := responses[strconv.Itoa(status)]
+	if !ok {
+		if status < 400 {
+			s.t.Fatalf("%s: status %d is not documented", name, status)
+		}
+		response = responses["default"]
+	}
+	if rec.Body.Len() == 0 || !strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	var got interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		s.t.Fatalf("%s: response is not JSON: %v", name, err)
+	}
+	s.checkShape(fmt.Sprintf("%s %d response", name, status), s.mediaSchema(response, fmt.Sprintf("%s %d response", name, status)), got)
+	return got
+}
+
+// mediaSchema 함수 정의 (requestBody 또는 response의 application/json 스키마)
+func (s *shapeServer) mediaSchema(v interface{}, name string) map[string]interface{} {
+	s.t.Helper()
+	content, _ := v.(map[string]interface{})["content"].(map[string]interface{})
+	media, _ := content[echo.MIMEApplicationJSON].(map[string]interface{})
+	schema, _ := media["schema"].(map[string]interface{})
+	if schema == nil {
+		s.t.Fatalf("%s: the spec has no JSON schema for a JSON body", name)
+	}
+	return schema
+}
+
+// checkShape 함수 정의 (문서에 없는 키, 빠진 필수 키, 타입 불일치를 모두 보고)
+func (s *shapeServer) checkShape(name string, schema map[string]interface{}, value interface{}) {
+	s.t.Helper()
+	var problems []string
+	s.collectProblems("$", schema, value, &problems)
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		s.t.Errorf("%s does not match the spec:\n  %s", name, strings.Join(problems, "\n  "))
+	}
+}
+
+func (s *shapeServer) collectProblems(at string, schema map[string]interface{}, value interface{}, problems *[]string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		components := s.spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+		resolved, ok := components[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]interface{})
+		if !ok {
+			*problems = append(*problems, at+": unresolved "+ref)
+			return
+		}
+		s.collectProblems(at, resolved, value, problems)
+		return
+	}
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); !nullable && len(schema) > 0 {
+			*problems = append(*problems, at+": null is not allowed")
+		}
+		return
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			s.collectProblems(at, sub.(map[string]interface{}), value, problems)
+		}
+		return
+	}
+
+	mismatch := func(want string) {
+		*problems = append(*problems, fmt.Sprintf("%s: %T where the spec says %s", at, value, want))
+	}
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch("object")
+			return
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		additional, _ := schema["additionalProperties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			if _, ok := obj[key.(string)]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s: required key %q is missing", at, key))
+			}
+		}
+		for key, v := range obj {
+			switch property, ok := properties[key].(map[string]interface{}); {
+			case ok:
+				s.collectProblems(at+"."+key, property, v, problems)
+			case additional != nil:
+				s.collectProblems(at+"."+key, additional, v, problems)
+			default:
+				*problems = append(*problems, fmt.Sprintf("%s: key %q is not documented", at, key))
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			mismatch("array")
+			return
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		for i, item := range items {
+			s.collectProblems(fmt.Sprintf("%s[%d]", at, i), itemSchema, item, problems)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			mismatch("string")
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			mismatch("integer")
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			mismatch("number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			mismatch("boolean")
+		}
+	}
+}
+
+// field 함수 정의 (응답 객체의 항목)
+func field(v interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		v = v.(map[string]interface{})[key]
+	}
+	return v
+}
+
+func TestHandlerResponsesMatchTheSpec(t *testing.T) {
+	s := newShapeServer(t)
+
+	s.call(http.MethodPost, "/auth/signup", object{"email": "shape@example.com", "password": "password123"}, http.StatusCreated)
+	login := s.call(http.MethodPost, "/auth/login", object{"email": "shape@example.com", "password": "password123"}, http.StatusOK)
+	s.token = field(login, "tokens", "access_token").(string)
+	s.call(http.MethodGet, "/auth/me", nil, http.StatusOK)
+	s.call(http.MethodPost, "/auth/tokens", object{"name": "ci", "scopes": []string{service.ScopeNotesRead}}, http.StatusCreated)
+	s.call(http.MethodGet, "/auth/tokens", nil, http.StatusOK)
+	s.call(http.MethodPost, "/workspaces", object{"name": "team"}, http.StatusCreated)
+	s.call(http.MethodGet, "/workspaces", nil, http.StatusOK)
+
+	created := s.call(http.MethodPost, "/notes", object{"title": "groceries", "content": "- [ ] milk\n- [x] eggs"}, http.StatusCreated)
+	id := strconv.Itoa(int(field(created, "note_info", "id").(float64)))
+	s.call(http.MethodGet, "/notes", nil, http.StatusOK)
+	s.call(http.MethodGet, "/notes/"+id, nil, http.StatusOK)
+	s.call(http.MethodPut, "/notes/"+id, object{"title": "shopping", "content": "- [ ] milk", "img": ""}, http.StatusOK)
+	s.call(http.MethodGet, "/notes/"+id+"/revisions", nil, http.StatusOK)
+	s.call(http.MethodPost, "/notes/"+id+"/revisions/1/restore", nil, http.StatusOK)
+	s.call(http.MethodGet, "/notes/"+id+"/tasks", nil, http.StatusOK)
+	s.call(http.MethodGet, "/notes/"+id+"/translations", nil, http.StatusOK)
+	s.call(http.MethodPost, "/notes/"+id+"/links", object{"expires_in_days": 1}, http.StatusCreated)
+	s.call(http.MethodGet, "/notes/"+id+"/links", nil, http.StatusOK)
+	s.call(http.MethodGet, "/tasks", nil, http.StatusOK)
+	s.call(http.MethodPost, "/notes/batch", object{"mode": "best_effort", "operations": []object{
+		{"op": "create", "title": "a", "content": "first"},
+		{"op": "delete", "id": 9999},
+	}}, http.StatusOK)
+	s.call(http.MethodGet, "/sync", nil, http.StatusOK)
+	s.call(http.MethodGet, "/prompts", nil, http.StatusOK)
+	s.call(http.MethodGet, "/models", nil, http.StatusOK)
+	s.call(http.MethodGet, "/usage", nil, http.StatusOK)
+	s.call(http.MethodPost, "/events/token", nil, http.StatusCreated)
+	s.call(http.MethodDelete, "/notes/"+id, nil, http.StatusOK)
+
+	// 오류 응답은 default 스키마
+	s.call(http.MethodGet, "/notes/"+id, nil, http.StatusNotFound)
+	s.call(http.MethodPost, "/notes", object{"title": "no content"}, http.StatusBadRequest)
+}
+
+func TestShapeCheckReportsMismatches(t *testing.T) {
+	s := &shapeServer{spec: map[string]interface{}{"components": map[string]interface{}{"schemas": map[string]interface{}{
+		"Note": map[string]interface{}{"type": "object", "required": []interface{}{"id"}, "properties": map[string]interface{}{
+			"id":    map[string]interface{}{"type": "integer"},
+			"title": map[string]interface{}{"type": "string", "nullable": true},
+		}},
+	}}}}
+	schema := map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Note"}}
+
+	var problems []string
+	s.collectProblems("$", schema, []interface{}{
+		map[string]interface{}{"id": 1.0, "title": nil},
+		map[string]interface{}{"id": 1.5, "tags": []interface{}{}},
+		map[string]interface{}{"title": 3.0},
+	}, &problems)
+	sort.Strings(problems)
+	want := []string{
+		`$[1].id: float64 where the spec says integer`,
+		`$[1]: key "tags" is not documented`,
+		`$[2].title: float64 where the spec says string`,
+		`$[2]: required key "id" is missing`,
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("problems:\n%s\nwant:\n%s", strings.Join(problems, "\n"), strings.Join(want, "\n"))
+	}
+}
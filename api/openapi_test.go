@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// registerStubRoutes 함수 정의 (핸들러는 호출하지 않으므로 빈 구조체로 라우트만 등록)
+func registerStubRoutes(e *echo.Echo) *OpenAPISpec {
+	noop := func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	return RegisterRoutes(e, &NoteHandler{}, &AttachmentHandler{}, &JobHandler{}, &PromptHandler{}, &UsageHandler{}, &ModelHandler{}, &AssistHandler{}, &TranslationHandler{}, &DuplicateHandler{}, &TaskHandler{}, &AuthHandler{}, &ShareHandler{}, &APITokenHandler{}, &WorkspaceHandler{}, &AuditHandler{}, &BatchHandler{}, &EventHandler{}, &WebhookHandler{}, &SyncHandler{}, noop, noop, noop, noop, time.Time{})
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	e := echo.New()
+	spec := registerStubRoutes(e)
+	if err := CheckOpenAPI(e.Routes(), spec); err != nil {
+		t.Fatalf("routes and OpenAPI spec differ: %v", err)
+	}
+}
+
+func TestOpenAPIReportsUndocumentedRoute(t *testing.T) {
+	e := echo.New()
+	spec := registerStubRoutes(e)
+	e.GET(APIV1Prefix+"/undocumented", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	err := CheckOpenAPI(e.Routes(), spec)
+	if err == nil || !strings.Contains(err.Error(), "/undocumented") {
+		t.Fatalf("undocumented route not reported: %v", err)
+	}
+}
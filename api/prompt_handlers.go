@@ -0,0 +1,160 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PromptHandler 구조체 정의
+type PromptHandler struct {
+	PromptService *service.PromptService
+	Audit         *service.AuditService
+}
+
+// NewPromptHandler 함수 정의
+func NewPromptHandler(promptService *service.PromptService, audit *service.AuditService) *PromptHandler {
+	return &PromptHandler{PromptService: promptService, Audit: audit}
+}
+
+// PromptTemplateResponse 구조체 정의
+type PromptTemplateResponse struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Template    string  `json:"template"`
+	Internal    bool    `json:"internal"`
+	CreatedTime string  `json:"created_time"`
+	UpdatedTime *string `json:"updated_time"`
+}
+
+// PromptTemplateRequest 구조체 정의
+type PromptTemplateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=500"`
+	Template    string `json:"template" validate:"required"`
+}
+
+func promptTemplateToResponse(tmpl *model.PromptTemplate) PromptTemplateResponse {
+	return PromptTemplateResponse{
+		ID:          tmpl.ID,
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Template:    tmpl.Template,
+		Internal:    service.IsInternalTemplate(tmpl.Name),
+		CreatedTime: formatTime(tmpl.CreatedTime),
+		UpdatedTime: formatOptionalTime(tmpl.UpdatedTime),
+	}
+}
+
+// CreatePromptHandler 함수 정의
+func (h *PromptHandler) CreatePromptHandler(c echo.Context) error {
+	var req PromptTemplateRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	tmpl, err := h.PromptService.CreateTemplate(req.Name, req.Description, req.Template)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, promptAudit(service.AuditPromptCreate, tmpl.ID, nil, tmpl))
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":       "Prompt template created successfully",
+		"template_info": promptTemplateToResponse(tmpl),
+	})
+}
+
+// GetAllPromptsHandler 함수 정의
+func (h *PromptHandler) GetAllPromptsHandler(c echo.Context) error {
+	templates, err := h.PromptService.GetAllTemplates()
+	if err != nil {
+		return err
+	}
+
+	responses := make([]PromptTemplateResponse, len(templates))
+	for i, tmpl := range templates {
+		responses[i] = promptTemplateToResponse(tmpl)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Prompt templates retrieved successfully",
+		"templates": responses,
+	})
+}
+
+// GetPromptByIDHandler 함수 정의
+func (h *PromptHandler) GetPromptByIDHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	tmpl, err := h.PromptService.GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "Prompt template retrieved successfully",
+		"template_info": promptTemplateToResponse(tmpl),
+	})
+}
+
+// UpdatePromptHandler 함수 정의
+func (h *PromptHandler) UpdatePromptHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req PromptTemplateRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	before, err := h.PromptService.GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := h.PromptService.UpdateTemplate(id, req.Name, req.Description, req.Template)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, promptAudit(service.AuditPromptUpdate, id, before, tmpl))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "Prompt template updated successfully",
+		"template_info": promptTemplateToResponse(tmpl),
+	})
+}
+
+// DeletePromptHandler 함수 정의
+func (h *PromptHandler) DeletePromptHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	before, err := h.PromptService.GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := h.PromptService.DeleteTemplate(id); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, promptAudit(service.AuditPromptDelete, id, before, nil))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Prompt template deleted successfully",
+	})
+}
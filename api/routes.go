@@ -1,14 +1,212 @@
 package api
 
 import (
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/labstack/echo/v4"
 )
 
-func RegisterRoutes(e *echo.Echo, noteHandler *NoteHandler) {
-	e.POST("/notes", noteHandler.CreateNoteHandler)
-	e.GET("/notes/:id", noteHandler.GetNoteByIDHandler)
-	e.GET("/notes/all", noteHandler.GetAllNotesHandler)
-	e.PUT("/notes/:id", noteHandler.UpdateNoteHandler)
-	e.DELETE("/notes/:id", noteHandler.DeleteNoteHandler)
-	e.POST("/api/notes/:id/analyze", noteHandler.AnalyzeNoteHandler)
+// APIV1Prefix 현재 API 경로 (v2가 생기면 /api/v2 그룹을 따로 등록하고 v1은 유지)
+const APIV1Prefix = "/api/v1"
+
+// 사용 중단 안내 헤더
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderLink        = "Link"
+)
+
+// 이전 경로를 사용 중단으로 표시한 날짜 (Deprecation 헤더)
+var legacyDeprecatedAt = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+// route 구조체 정의 (Path는 버전 그룹 아래 경로, Legacy는 같은 기능의 이전 경로)
+type route struct {
+	Method     string
+	Path       string
+	Legacy     string
+	Handler    echo.HandlerFunc
+	Middleware []echo.MiddlewareFunc
+}
+
+// RegisterRoutes 함수 정의 (라우트를 등록하고 같은 목록으로 만든 OpenAPI 명세 반환)
+func RegisterRoutes(e *echo.Echo, noteHandler *NoteHandler, attachmentHandler *AttachmentHandler, jobHandler *JobHandler, promptHandler *PromptHandler, usageHandler *UsageHandler, modelHandler *ModelHandler, assistHandler *AssistHandler, translationHandler *TranslationHandler, duplicateHandler *DuplicateHandler, taskHandler *TaskHandler, authHandler *AuthHandler, shareHandler *ShareHandler, apiTokenHandler *APITokenHandler, workspaceHandler *WorkspaceHandler, auditHandler *AuditHandler, batchHandler *BatchHandler, eventHandler *EventHandler, webhookHandler *WebhookHandler, syncHandler *SyncHandler, authMiddleware, streamAuthMiddleware, workspaceMiddleware, adminMiddleware echo.MiddlewareFunc, legacySunset time.Time) *OpenAPISpec {
+	// 인증 없이 사용 가능한 경로
+	public := []route{
+		{http.MethodPost, "/auth/signup", "/auth/signup", authHandler.SignupHandler, nil},
+		{http.MethodPost, "/auth/login", "/auth/login", authHandler.LoginHandler, nil},
+		{http.MethodPost, "/auth/refresh", "/auth/refresh", authHandler.RefreshHandler, nil},
+		{http.MethodPost, "/auth/logout", "/auth/logout", authHandler.LogoutHandler, nil},
+		{http.MethodGet, "/public/notes/:token", "/public/notes/:token", shareHandler.GetPublicNoteHandler, nil},
+		{http.MethodPost, "/public/notes/:token", "/public/notes/:token", shareHandler.GetPublicNoteHandler, nil},
+		{http.MethodGet, "/public/notes/:token/image", "/public/notes/:token/image", shareHandler.GetPublicNoteImageHandler, nil},
+	}
+
+	// 개인 API 토큰은 권한(scope)별로, 워크스페이스 guest는 읽기만 가능
+	read := []echo.MiddlewareFunc{RequireScope(service.ScopeNotesRead)}
+	write := []echo.MiddlewareFunc{RequireScope(service.ScopeNotesWrite), RequireWorkspaceRole(model.WorkspaceRoleMember)}
+	analyze := []echo.MiddlewareFunc{RequireScope(service.ScopeAIAnalyze), RequireWorkspaceRole(model.WorkspaceRoleMember)}
+	session := []echo.MiddlewareFunc{RequireSession}
+	admin := []echo.MiddlewareFunc{RequireSession, adminMiddleware}
+	// 웹훅은 노트 내용을 외부로 보내므로 로그인 세션과 워크스페이스 admin 이상 필요
+	manage := []echo.MiddlewareFunc{RequireSession, RequireWorkspaceRole(model.WorkspaceRoleAdmin)}
+
+	// 이하 경로는 로그인 필요 (X-Workspace-ID 헤더로 워크스페이스 지정)
+	authenticated := []route{
+		{http.MethodGet, "/auth/me", "/auth/me", authHandler.GetMeHandler, nil},
+		{http.MethodPost, "/auth/tokens", "/auth/tokens", apiTokenHandler.CreateAPITokenHandler, session},
+		{http.MethodGet, "/auth/tokens", "/auth/tokens", apiTokenHandler.GetAPITokensHandler, session},
+		{http.MethodDelete, "/auth/tokens/:id", "/auth/tokens/:id", apiTokenHandler.DeleteAPITokenHandler, session},
+
+		// 워크스페이스 관리
+		{http.MethodPost, "/workspaces", "/workspaces", workspaceHandler.CreateWorkspaceHandler, session},
+		{http.MethodGet, "/workspaces", "/workspaces", workspaceHandler.GetWorkspacesHandler, read},
+		{http.MethodGet, "/workspaces/:id/members", "/workspaces/:id/members", workspaceHandler.GetMembersHandler, read},
+		{http.MethodPut, "/workspaces/:id/members/:user_id", "/workspaces/:id/members/:user_id", workspaceHandler.UpdateMemberHandler, session},
+		{http.MethodDelete, "/workspaces/:id/members/:user_id", "/workspaces/:id/members/:user_id", workspaceHandler.RemoveMemberHandler, session},
+		{http.MethodPost, "/workspaces/:id/invitations", "/workspaces/:id/invitations", workspaceHandler.InviteMemberHandler, session},
+		{http.MethodGet, "/workspaces/:id/invitations", "/workspaces/:id/invitations", workspaceHandler.GetInvitationsHandler, session},
+		{http.MethodDelete, "/workspaces/:id/invitations/:invitation_id", "/workspaces/:id/invitations/:invitation_id", workspaceHandler.RevokeInvitationHandler, session},
+		{http.MethodPost, "/invitations/accept", "/invitations/accept", workspaceHandler.AcceptInvitationHandler, session},
+
+		// 노트
+		{http.MethodPost, "/notes", "/notes", noteHandler.CreateNoteHandler, write},
+		{http.MethodGet, "/notes", "/notes/all", noteHandler.GetAllNotesHandler, read},
+		{http.MethodGet, "/notes/search", "/notes/search", noteHandler.SearchNotesHandler, read},
+		{http.MethodGet, "/notes/shared", "/notes/shared", shareHandler.GetSharedNotesHandler, read},
+		{http.MethodGet, "/notes/duplicates", "/notes/duplicates", duplicateHandler.GetDuplicatesHandler, read},
+		{http.MethodPost, "/notes/merge", "/notes/merge", duplicateHandler.MergeNotesHandler, write},
+		{http.MethodPost, "/notes/batch", "", batchHandler.BatchNotesHandler, write},
+		{http.MethodGet, "/notes/:id", "/notes/:id", noteHandler.GetNoteByIDHandler, read},
+		{http.MethodPut, "/notes/:id", "/notes/:id", noteHandler.UpdateNoteHandler, write},
+		{http.MethodPatch, "/notes/:id", "/notes/:id", noteHandler.PatchNoteHandler, write},
+		{http.MethodDelete, "/notes/:id", "/notes/:id", noteHandler.DeleteNoteHandler, write},
+		{http.MethodGet, "/notes/:id/revisions", "/notes/:id/revisions", noteHandler.GetNoteRevisionsHandler, read},
+		{http.MethodPost, "/notes/:id/revisions/:revision/restore", "/notes/:id/revisions/:revision/restore", noteHandler.RestoreNoteRevisionHandler, write},
+		{http.MethodPost, "/notes/:id/analyze", "/api/notes/:id/analyze", noteHandler.AnalyzeNoteHandler, analyze},
+		{http.MethodPost, "/notes/:id/assist", "/api/notes/:id/assist", assistHandler.AssistNoteHandler, analyze},
+		{http.MethodPost, "/notes/:id/assist/apply", "/api/notes/:id/assist/apply", assistHandler.ApplyAssistHandler, write},
+		{http.MethodPost, "/notes/:id/translate", "/api/notes/:id/translate", translationHandler.TranslateNoteHandler, analyze},
+		{http.MethodGet, "/notes/:id/translations", "/notes/:id/translations", translationHandler.GetTranslationsHandler, read},
+		{http.MethodGet, "/notes/:id/tasks", "/notes/:id/tasks", taskHandler.GetNoteTasksHandler, read},
+		{http.MethodPost, "/notes/:id/tasks/extract", "/api/notes/:id/tasks/extract", taskHandler.ExtractTasksHandler, analyze},
+		{http.MethodPost, "/notes/:id/attachments", "/notes/:id/attachments", attachmentHandler.UploadAttachmentHandler, write},
+		{http.MethodGet, "/notes/:id/attachments", "/notes/:id/attachments", attachmentHandler.GetAttachmentsHandler, read},
+		{http.MethodPost, "/notes/:id/shares", "/notes/:id/shares", shareHandler.ShareNoteHandler, write},
+		{http.MethodGet, "/notes/:id/shares", "/notes/:id/shares", shareHandler.GetSharesHandler, read},
+		{http.MethodDelete, "/notes/:id/shares/:user_id", "/notes/:id/shares/:user_id", shareHandler.RemoveShareHandler, write},
+		{http.MethodPost, "/notes/:id/links", "/notes/:id/links", shareHandler.CreateShareLinkHandler, write},
+		{http.MethodGet, "/notes/:id/links", "/notes/:id/links", shareHandler.GetShareLinksHandler, read},
+		{http.MethodDelete, "/notes/:id/links/:link_id", "/notes/:id/links/:link_id", shareHandler.RevokeShareLinkHandler, write},
+
+		// 노트 변경 이벤트 스트림에 연결할 토큰 (브라우저용)
+		{http.MethodPost, "/events/token", "", eventHandler.CreateStreamTokenHandler, session},
+
+		// 오프라인 동기화
+		{http.MethodGet, "/sync", "", syncHandler.PullChangesHandler, read},
+		{http.MethodPost, "/sync", "", syncHandler.PushChangesHandler, write},
+
+		// 웹훅
+		{http.MethodPost, "/webhooks", "", webhookHandler.CreateWebhookHandler, manage},
+		{http.MethodGet, "/webhooks", "", webhookHandler.GetWebhooksHandler, manage},
+		{http.MethodGet, "/webhooks/:id", "", webhookHandler.GetWebhookHandler, manage},
+		{http.MethodPut, "/webhooks/:id", "", webhookHandler.UpdateWebhookHandler, manage},
+		{http.MethodDelete, "/webhooks/:id", "", webhookHandler.DeleteWebhookHandler, manage},
+		{http.MethodPost, "/webhooks/:id/test", "", webhookHandler.TestWebhookHandler, manage},
+		{http.MethodGet, "/webhooks/:id/deliveries", "", webhookHandler.GetDeliveriesHandler, manage},
+		{http.MethodPost, "/webhooks/:id/deliveries/:delivery_id/replay", "", webhookHandler.ReplayDeliveryHandler, manage},
+
+		// 할 일, 작업 (작업은 AI 요청으로 만들어지므로 취소에는 analyze 권한 필요)
+		{http.MethodGet, "/tasks", "/tasks", taskHandler.GetAllTasksHandler, read},
+		{http.MethodPut, "/tasks/:id", "/tasks/:id", taskHandler.UpdateTaskHandler, write},
+		{http.MethodPost, "/tasks/:id/complete", "/tasks/:id/complete", taskHandler.CompleteTaskHandler, write},
+		{http.MethodGet, "/jobs/:id", "/jobs/:id", jobHandler.GetJobHandler, read},
+		{http.MethodPost, "/jobs/:id/cancel", "/jobs/:id/cancel", jobHandler.CancelJobHandler, analyze},
+
+		// 프롬프트 템플릿, AI 사용량, 모델 (템플릿은 모든 사용자가 함께 쓰므로 변경은 관리자만)
+		{http.MethodGet, "/prompts", "/api/prompts", promptHandler.GetAllPromptsHandler, nil},
+		{http.MethodPost, "/prompts", "/api/prompts", promptHandler.CreatePromptHandler, admin},
+		{http.MethodGet, "/prompts/:id", "/api/prompts/:id", promptHandler.GetPromptByIDHandler, nil},
+		{http.MethodPut, "/prompts/:id", "/api/prompts/:id", promptHandler.UpdatePromptHandler, admin},
+		{http.MethodDelete, "/prompts/:id", "/api/prompts/:id", promptHandler.DeletePromptHandler, admin},
+		{http.MethodGet, "/usage", "/api/usage", usageHandler.GetUsageHandler, analyze},
+		{http.MethodGet, "/models", "/api/models", modelHandler.GetModelsHandler, nil},
+
+		// 관리자 전용 감사 기록, 전체 AI 사용량
+		{http.MethodGet, "/admin/audit", "/admin/audit", auditHandler.GetAuditLogHandler, admin},
+		{http.MethodGet, "/admin/audit/export", "/admin/audit/export", auditHandler.ExportAuditLogHandler, admin},
+		{http.MethodGet, "/admin/usage", "/admin/usage", usageHandler.GetAllUsageHandler, admin},
+	}
+
+	// 노트 변경 이벤트 (SSE, WebSocket), 헤더를 붙일 수 없는 브라우저를 위해 access_token 쿼리의 스트림 토큰도 허용
+	streams := []route{
+		{http.MethodGet, "/events", "", eventHandler.StreamEventsHandler, read},
+		{http.MethodGet, "/events/ws", "", eventHandler.StreamEventsWebSocketHandler, read},
+	}
+
+	// 인증 미들웨어는 그룹이 아니라 라우트마다 붙임 (그룹 미들웨어는 없는 경로에도 실행되어 404 대신 401이 됨)
+	auth := []echo.MiddlewareFunc{authMiddleware, workspaceMiddleware}
+	v1 := e.Group(APIV1Prefix)
+	registerVersion(v1, public)
+	registerVersion(v1, authenticated, auth...)
+	registerVersion(v1, streams, streamAuthMiddleware, workspaceMiddleware)
+
+	// 버전 없는 이전 경로는 v1과 같은 핸들러로 연결하고 사용 중단 헤더 추가
+	registerLegacy(e, public, APIV1Prefix, legacySunset)
+	registerLegacy(e, authenticated, APIV1Prefix, legacySunset, auth...)
+
+	// API 문서 (/openapi.json, /docs)
+	spec := buildOpenAPI(public, append(authenticated, streams...))
+	registerDocs(e, spec)
+	return spec
+}
+
+// registerVersion 함수 정의 (common은 라우트별 미들웨어 앞에 실행)
+func registerVersion(g *echo.Group, routes []route, common ...echo.MiddlewareFunc) {
+	for _, r := range routes {
+		middleware := append(append([]echo.MiddlewareFunc{}, common...), r.Middleware...)
+		g.Add(r.Method, r.Path, r.Handler, middleware...)
+	}
+}
+
+// registerLegacy 함수 정의 (Legacy 경로가 있는 라우트만 등록, common은 사용 중단 헤더와 라우트별 미들웨어 앞에 실행)
+func registerLegacy(e *echo.Echo, routes []route, prefix string, sunset time.Time, common ...echo.MiddlewareFunc) {
+	for _, r := range routes {
+		if r.Legacy == "" {
+			continue
+		}
+		middleware := append(append([]echo.MiddlewareFunc{}, common...), deprecated(prefix+r.Path, sunset))
+		middleware = append(middleware, r.Middleware...)
+		e.Add(r.Method, r.Legacy, r.Handler, middleware...)
+	}
+}
+
+// deprecated 함수 정의 (Deprecation, Sunset 헤더와 새 경로를 알려주는 Link 헤더 추가)
+func deprecated(successor string, sunset time.Time) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, fmt.Sprintf("@%d", legacyDeprecatedAt.Unix()))
+			if !sunset.IsZero() {
+				header.Set(HeaderSunset, sunset.UTC().Format(http.TimeFormat))
+			}
+			header.Set(HeaderLink, fmt.Sprintf(`<%s>; rel="successor-version"`, successorPath(c, successor)))
+			return next(c)
+		}
+	}
+}
+
+// successorPath 함수 정의 (경로 템플릿의 :param을 요청 값으로 채움)
+func successorPath(c echo.Context, template string) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = c.Param(segment[1:])
+		}
+	}
+	return strings.Join(segments, "/")
 }
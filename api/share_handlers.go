@@ -0,0 +1,372 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderSharePassword 비밀번호가 걸린 공개 링크의 비밀번호 헤더
+const HeaderSharePassword = "X-Share-Password"
+
+// 공개 링크에서 제공하는 이미지 경로 (업로드 폴더 밖의 파일은 제공하지 않음)
+const publicImageDir = "uploads"
+
+// ShareHandler 구조체 정의
+type ShareHandler struct {
+	NoteService  *service.NoteService
+	ShareService *service.ShareService
+	Audit        *service.AuditService
+}
+
+// NewShareHandler 함수 정의
+func NewShareHandler(noteService *service.NoteService, shareService *service.ShareService, audit *service.AuditService) *ShareHandler {
+	return &ShareHandler{NoteService: noteService, ShareService: shareService, Audit: audit}
+}
+
+// ShareNoteRequest 구조체 정의
+type ShareNoteRequest struct {
+	Email string `json:"email" validate:"required,max=254"`
+	Role  string `json:"role" validate:"required"`
+}
+
+// CreateShareLinkRequest 구조체 정의 (password가 비어 있으면 비밀번호 없음, expires_in_days가 0이면 만료 없음)
+type CreateShareLinkRequest struct {
+	Password      string `json:"password"`
+	ExpiresInDays int    `json:"expires_in_days"`
+}
+
+// NoteShareResponse 구조체 정의
+type NoteShareResponse struct {
+	UserID      int    `json:"user_id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	CreatedTime string `json:"created_time"`
+}
+
+// ShareLinkResponse 구조체 정의 (링크 토큰 원문은 생성 응답에만 포함)
+type ShareLinkResponse struct {
+	ID          int     `json:"id"`
+	Prefix      string  `json:"prefix"`
+	HasPassword bool    `json:"has_password"`
+	ExpiresTime *string `json:"expires_time"`
+	RevokedTime *string `json:"revoked_time"`
+	CreatedTime string  `json:"created_time"`
+}
+
+func shareToResponse(share *model.NoteShare) NoteShareResponse {
+	return NoteShareResponse{
+		UserID:      share.UserID,
+		Email:       share.Email,
+		Role:        share.Role,
+		CreatedTime: formatTime(share.CreatedTime),
+	}
+}
+
+func shareLinkToResponse(link *model.ShareLink) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:          link.ID,
+		Prefix:      link.Prefix,
+		HasPassword: link.PasswordHash != "",
+		ExpiresTime: formatOptionalTime(link.ExpiresTime),
+		RevokedTime: formatOptionalTime(link.RevokedTime),
+		CreatedTime: formatTime(link.CreatedTime),
+	}
+}
+
+// GetSharedNotesHandler 함수 정의 (다른 사용자에게 공유받은 노트 목록)
+func (h *ShareHandler) GetSharedNotesHandler(c echo.Context) error {
+	notes, err := h.NoteService.GetSharedNotes(currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Shared notes retrieved successfully",
+		"notes":   notesToResponse(notes),
+	})
+}
+
+// ShareNoteHandler 함수 정의 (이미 공유한 사용자면 역할 변경)
+func (h *ShareHandler) ShareNoteHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req ShareNoteRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	share, err := h.ShareService.ShareNote(currentScope(c), id, req.Email, req.Role)
+	if err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditShareCreate, id, nil, nil)
+	entry.Details = fmt.Sprintf("user_id=%d role=%s", share.UserID, share.Role)
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":    "Note shared successfully",
+		"share_info": shareToResponse(share),
+	})
+}
+
+// GetSharesHandler 함수 정의
+func (h *ShareHandler) GetSharesHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	shares, err := h.ShareService.GetShares(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]NoteShareResponse, len(shares))
+	for i, share := range shares {
+		responses[i] = shareToResponse(share)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Shares retrieved successfully",
+		"shares":  responses,
+	})
+}
+
+// RemoveShareHandler 함수 정의
+func (h *ShareHandler) RemoveShareHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return model.Validation("Invalid user ID format")
+	}
+
+	if err := h.ShareService.RemoveShare(currentScope(c), id, userID); err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditShareDelete, id, nil, nil)
+	entry.Details = fmt.Sprintf("user_id=%d", userID)
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Share removed successfully",
+	})
+}
+
+// CreateShareLinkHandler 함수 정의
+func (h *ShareHandler) CreateShareLinkHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req CreateShareLinkRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	expiresIn := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	link, raw, err := h.ShareService.CreateLink(currentScope(c), id, req.Password, expiresIn)
+	if err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditShareLinkCreate, id, nil, nil)
+	entry.Details = fmt.Sprintf("link_id=%d password=%t", link.ID, link.PasswordHash != "")
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":   "Share link created successfully",
+		"token":     raw,
+		"url":       APIV1Prefix + "/public/notes/" + raw,
+		"link_info": shareLinkToResponse(link),
+	})
+}
+
+// GetShareLinksHandler 함수 정의
+func (h *ShareHandler) GetShareLinksHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	links, err := h.ShareService.GetLinks(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = shareLinkToResponse(link)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Share links retrieved successfully",
+		"links":   responses,
+	})
+}
+
+// RevokeShareLinkHandler 함수 정의
+func (h *ShareHandler) RevokeShareLinkHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	linkID, err := strconv.Atoi(c.Param("link_id"))
+	if err != nil {
+		return model.Validation("Invalid link ID format")
+	}
+
+	if err := h.ShareService.RevokeLink(currentScope(c), id, linkID); err != nil {
+		return err
+	}
+
+	entry := noteAudit(service.AuditShareLinkRevoke, id, nil, nil)
+	entry.Details = fmt.Sprintf("link_id=%d", linkID)
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Share link revoked successfully",
+	})
+}
+
+// openPublicNote 함수 정의 (비밀번호는 헤더 또는 POST 폼으로만 전달, URL에 남지 않도록 쿼리는 받지 않음)
+func (h *ShareHandler) openPublicNote(c echo.Context) (*model.Note, error) {
+	password := c.Request().Header.Get(HeaderSharePassword)
+	if password == "" && c.Request().Method == http.MethodPost {
+		password = c.FormValue("password")
+	}
+	return publicLinkResult(h.ShareService.OpenLink(c.Param("token"), password))
+}
+
+// publicLinkResult 함수 정의
+func publicLinkResult(note *model.Note, err error) (*model.Note, error) {
+	if errors.Is(err, service.ErrInvalidToken) {
+		return nil, model.NotFound("Link not found or expired").Wrap(err)
+	}
+	return note, err
+}
+
+// wantsHTML 함수 정의
+func wantsHTML(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMETextHTML)
+}
+
+var publicPasswordTemplate = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Password required</title>
+</head>
+<body>
+<form method="post">
+<p>{{if .Failed}}Wrong password. {{end}}This note is protected by a password.</p>
+<input type="password" name="password" autofocus>
+<button type="submit">Open</button>
+</form>
+</body>
+</html>
+`))
+
+var publicNoteTemplate = template.Must(template.New("note").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .ImageURL}}<img src="{{.ImageURL}}" alt="{{.Title}}" style="max-width: 100%">{{end}}
+<pre style="white-space: pre-wrap">{{.Content}}</pre>
+</body>
+</html>
+`))
+
+// GetPublicNoteHandler 함수 정의 (로그인 없이 공개 링크로 노트 조회, 브라우저 요청이면 HTML로 렌더링, POST는 비밀번호 폼 제출)
+func (h *ShareHandler) GetPublicNoteHandler(c echo.Context) error {
+	note, err := h.openPublicNote(c)
+	if note == nil {
+		if errors.Is(err, service.ErrSharePasswordRequired) && wantsHTML(c) {
+			c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+			c.Response().WriteHeader(http.StatusUnauthorized)
+			return publicPasswordTemplate.Execute(c.Response(), map[string]bool{"Failed": c.Request().Method == http.MethodPost})
+		}
+		return err
+	}
+
+	// 이미지는 비밀번호 대신 짧게 유효한 서명 URL로 제공
+	imageURL := ""
+	if note.Img != "" {
+		token := c.Param("token")
+		expires, signature := h.ShareService.SignImage(token, time.Now())
+		imageURL = fmt.Sprintf("%s/public/notes/%s/image?expires=%d&sig=%s", APIV1Prefix, token, expires, signature)
+	}
+
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	if wantsHTML(c) {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return publicNoteTemplate.Execute(c.Response(), map[string]string{
+			"Title":    note.Title,
+			"Content":  note.Content,
+			"ImageURL": imageURL,
+		})
+	}
+
+	response := noteToResponse(note)
+	response.Img = imageURL
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Note retrieved successfully",
+		"note_info": response,
+	})
+}
+
+// GetPublicNoteImageHandler 함수 정의 (노트 응답의 서명 URL 또는 비밀번호 헤더로 접근, 외부 URL 이미지는 리다이렉트, 업로드 이미지는 파일로 응답)
+func (h *ShareHandler) GetPublicNoteImageHandler(c echo.Context) error {
+	var (
+		note *model.Note
+		err  error
+	)
+	if signature := c.QueryParam("sig"); signature != "" {
+		expires, parseErr := strconv.ParseInt(c.QueryParam("expires"), 10, 64)
+		if parseErr != nil {
+			return model.Validation("Invalid expires")
+		}
+		note, err = publicLinkResult(h.ShareService.OpenLinkSigned(c.Param("token"), expires, signature))
+	} else {
+		note, err = h.openPublicNote(c)
+	}
+	if note == nil {
+		return err
+	}
+
+	if note.Img == "" {
+		return model.NotFound("Note has no image")
+	}
+	if strings.HasPrefix(note.Img, "http://") || strings.HasPrefix(note.Img, "https://") {
+		return c.Redirect(http.StatusFound, note.Img)
+	}
+
+	path := filepath.Clean(note.Img)
+	if rel, err := filepath.Rel(publicImageDir, path); err != nil || strings.HasPrefix(rel, "..") {
+		return model.NotFound("Image is not available")
+	}
+	return c.File(path)
+}
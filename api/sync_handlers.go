@@ -0,0 +1,231 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxSyncClientIDLength 클라이언트 ID 최대 길이
+const maxSyncClientIDLength = 200
+
+// SyncHandler 구조체 정의
+type SyncHandler struct {
+	SyncService *service.SyncService
+	Audit       *service.AuditService
+}
+
+// NewSyncHandler 함수 정의
+func NewSyncHandler(syncService *service.SyncService, audit *service.AuditService) *SyncHandler {
+	return &SyncHandler{SyncService: syncService, Audit: audit}
+}
+
+// SyncChangeRequest 구조체 정의 (op: upsert, delete, 대상은 id 또는 client_id, base_version은 클라이언트가 마지막으로 받은 버전)
+type SyncChangeRequest struct {
+	Op          string `json:"op"`
+	ID          int    `json:"id"`
+	ClientID    string `json:"client_id"`
+	BaseVersion int    `json:"base_version"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Img         string `json:"img"`
+}
+
+// SyncPushRequest 구조체 정의
+type SyncPushRequest struct {
+	Changes []SyncChangeRequest `json:"changes"`
+}
+
+// validateFields 함수 정의 (형식이 잘못된 변경이 있으면 아무것도 적용하지 않음)
+func (r *SyncPushRequest) validateFields() []model.FieldError {
+	var fields []model.FieldError
+	if len(r.Changes) == 0 {
+		fields = append(fields, model.FieldError{Field: "changes", Message: "is required"})
+	}
+	if len(r.Changes) > service.MaxSyncChanges {
+		fields = append(fields, model.FieldError{Field: "changes", Message: fmt.Sprintf("must have at most %d items", service.MaxSyncChanges)})
+	}
+
+	for i, change := range r.Changes {
+		prefix := fmt.Sprintf("changes[%d].", i)
+		switch change.Op {
+		case service.SyncOpUpsert:
+			err := validateStruct(&UpdateNoteRequest{Title: &change.Title, Content: &change.Content, Img: &change.Img})
+			var appErr *model.Error
+			if errors.As(err, &appErr) {
+				details, _ := appErr.Details.([]model.FieldError)
+				for _, field := range details {
+					fields = append(fields, model.FieldError{Field: prefix + field.Field, Message: field.Message})
+				}
+			}
+		case service.SyncOpDelete:
+		default:
+			fields = append(fields, model.FieldError{Field: prefix + "op", Message: "must be one of upsert, delete"})
+			continue
+		}
+		if change.ID == 0 && change.ClientID == "" {
+			fields = append(fields, model.FieldError{Field: prefix + "id", Message: "id or client_id is required"})
+		}
+		if change.ID < 0 {
+			fields = append(fields, model.FieldError{Field: prefix + "id", Message: "must be positive"})
+		}
+		if len(change.ClientID) > maxSyncClientIDLength {
+			fields = append(fields, model.FieldError{Field: prefix + "client_id", Message: fmt.Sprintf("must be at most %d characters", maxSyncClientIDLength)})
+		}
+		// 서버 ID로 지정한 변경은 충돌을 판단할 기준 버전이 필요
+		if change.ID > 0 && change.BaseVersion <= 0 {
+			fields = append(fields, model.FieldError{Field: prefix + "base_version", Message: "is required when id is set"})
+		}
+		if change.BaseVersion < 0 {
+			fields = append(fields, model.FieldError{Field: prefix + "base_version", Message: "must not be negative"})
+		}
+	}
+	return fields
+}
+
+// SyncedNoteResponse 구조체 정의 (deleted가 true이면 note_info 없이 클라이언트에서 지울 노트)
+type SyncedNoteResponse struct {
+	ID       int           `json:"id"`
+	ClientID string        `json:"client_id,omitempty"`
+	Deleted  bool          `json:"deleted"`
+	Note     *NoteResponse `json:"note_info,omitempty"`
+}
+
+// SyncResultResponse 구조체 정의 (note_info는 적용 후 서버 상태, conflict_copy_info는 충돌한 클라이언트 내용으로 만든 노트)
+type SyncResultResponse struct {
+	Index        int           `json:"index"`
+	ID           int           `json:"id"`
+	ClientID     string        `json:"client_id,omitempty"`
+	Status       string        `json:"status"`
+	Resolution   string        `json:"resolution,omitempty"`
+	Note         *NoteResponse `json:"note_info,omitempty"`
+	ConflictCopy *NoteResponse `json:"conflict_copy_info,omitempty"`
+	Error        *ErrorBody    `json:"error,omitempty"`
+}
+
+func syncedNoteToResponse(synced service.SyncedNote) SyncedNoteResponse {
+	response := SyncedNoteResponse{ID: synced.NoteID, ClientID: synced.ClientID, Deleted: synced.Note == nil}
+	if synced.Note != nil {
+		note := noteToResponse(synced.Note)
+		response.Note = &note
+	}
+	return response
+}
+
+// 동기화 결과별 감사 기록 종류
+var syncAuditActions = map[string]string{
+	service.BatchOpCreate: service.AuditNoteCreate,
+	service.BatchOpUpdate: service.AuditNoteUpdate,
+	service.SyncOpDelete:  service.AuditNoteDelete,
+}
+
+// PullChangesHandler 함수 정의 (cursor가 없으면 전체 노트, 있으면 그 이후 바뀐 노트와 삭제된 노트, has_more이면 받은 cursor로 다시 요청)
+func (h *SyncHandler) PullChangesHandler(c echo.Context) error {
+	scope := currentScope(c)
+
+	limit := service.DefaultSyncPageSize
+	if value := c.QueryParam("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > service.DefaultSyncPageSize {
+			return model.Validation(fmt.Sprintf("limit must be between 1 and %d", service.DefaultSyncPageSize))
+		}
+	}
+
+	var (
+		synced  []service.SyncedNote
+		cursor  int64
+		hasMore bool
+		err     error
+	)
+	if value := c.QueryParam("cursor"); value == "" {
+		synced, cursor, err = h.SyncService.Snapshot(scope)
+	} else {
+		cursor, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || cursor < 0 {
+			return model.Validation("Invalid cursor")
+		}
+		synced, cursor, hasMore, err = h.SyncService.Changes(scope, cursor, limit)
+	}
+	if err != nil {
+		return err
+	}
+
+	responses := make([]SyncedNoteResponse, len(synced))
+	for i, note := range synced {
+		responses[i] = syncedNoteToResponse(note)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Changes retrieved successfully",
+		"full":     c.QueryParam("cursor") == "",
+		"cursor":   strconv.FormatInt(cursor, 10),
+		"has_more": hasMore,
+		"changes":  responses,
+	})
+}
+
+// PushChangesHandler 함수 정의 (변경마다 결과를 돌려주며 충돌이나 실패가 있어도 200)
+func (h *SyncHandler) PushChangesHandler(c echo.Context) error {
+	var req SyncPushRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	changes := make([]service.SyncChange, len(req.Changes))
+	for i, change := range req.Changes {
+		changes[i] = service.SyncChange{
+			Op:          change.Op,
+			ID:          change.ID,
+			ClientID:    change.ClientID,
+			BaseVersion: change.BaseVersion,
+			Title:       change.Title,
+			Content:     change.Content,
+			Img:         change.Img,
+		}
+	}
+
+	results, err := h.SyncService.Push(currentScope(c), changes)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]SyncResultResponse, len(results))
+	conflicts, failed := 0, 0
+	for i, result := range results {
+		responses[i] = SyncResultResponse{Index: i, ID: result.NoteID, ClientID: result.ClientID, Status: result.Status, Resolution: result.Resolution}
+		if result.Err != nil {
+			_, body := errorResponse(result.Err)
+			responses[i].Error = &body
+			failed++
+			continue
+		}
+		if result.Note != nil {
+			note := noteToResponse(result.Note)
+			responses[i].Note = &note
+		}
+		if result.ConflictCopy != nil {
+			copied := noteToResponse(result.ConflictCopy)
+			responses[i].ConflictCopy = &copied
+			recordAudit(c, h.Audit, noteAudit(service.AuditNoteCreate, copied.ID, nil, result.ConflictCopy))
+		}
+		if result.Status == service.SyncStatusConflict {
+			conflicts++
+		}
+		if result.Action != "" {
+			recordAudit(c, h.Audit, noteAudit(syncAuditActions[result.Action], result.NoteID, result.Before, result.Note))
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Changes processed successfully",
+		"conflicts": conflicts,
+		"failed":    failed,
+		"results":   responses,
+	})
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TaskHandler 구조체 정의
+type TaskHandler struct {
+	TaskService *service.TaskService
+	Audit       *service.AuditService
+}
+
+// NewTaskHandler 함수 정의
+func NewTaskHandler(taskService *service.TaskService, audit *service.AuditService) *TaskHandler {
+	return &TaskHandler{TaskService: taskService, Audit: audit}
+}
+
+// TaskResponse 구조체 정의
+type TaskResponse struct {
+	ID          int     `json:"id"`
+	NoteID      int     `json:"note_id"`
+	Text        string  `json:"text"`
+	Done        bool    `json:"done"`
+	DueDate     *string `json:"due_date"`
+	Source      string  `json:"source"`
+	SpanStart   int     `json:"span_start"`
+	SpanEnd     int     `json:"span_end"`
+	CreatedTime string  `json:"created_time"`
+	UpdatedTime *string `json:"updated_time"`
+}
+
+// UpdateTaskRequest 구조체 정의
+type UpdateTaskRequest struct {
+	Done *bool `json:"done" validate:"present"`
+}
+
+func taskToResponse(task *model.Task) TaskResponse {
+	var dueDate *string
+	if task.DueDate != nil {
+		s := task.DueDate.Format("2006-01-02")
+		dueDate = &s
+	}
+	return TaskResponse{
+		ID:          task.ID,
+		NoteID:      task.NoteID,
+		Text:        task.Text,
+		Done:        task.Done,
+		DueDate:     dueDate,
+		Source:      task.Source,
+		SpanStart:   task.SpanStart,
+		SpanEnd:     task.SpanEnd,
+		CreatedTime: formatTime(task.CreatedTime),
+		UpdatedTime: formatOptionalTime(task.UpdatedTime),
+	}
+}
+
+func tasksToResponse(tasks []*model.Task) []TaskResponse {
+	responses := make([]TaskResponse, len(tasks))
+	for i, task := range tasks {
+		responses[i] = taskToResponse(task)
+	}
+	return responses
+}
+
+// GetAllTasksHandler 함수 정의 (모든 노트의 할 일, done=true|false로 필터)
+func (h *TaskHandler) GetAllTasksHandler(c echo.Context) error {
+	var done *bool
+	if v := c.QueryParam("done"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return model.Validation("Invalid done filter")
+		}
+		done = &b
+	}
+
+	tasks, err := h.TaskService.GetAllTasks(currentScope(c), done)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Tasks retrieved successfully",
+		"tasks":   tasksToResponse(tasks),
+	})
+}
+
+// GetNoteTasksHandler 함수 정의
+func (h *TaskHandler) GetNoteTasksHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if _, err := h.TaskService.Notes.GetNoteByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	tasks, err := h.TaskService.GetTasksByNoteID(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Tasks retrieved successfully",
+		"tasks":   tasksToResponse(tasks),
+	})
+}
+
+// UpdateTaskHandler 함수 정의 (완료 여부 변경, 체크박스 할 일은 노트 내용에도 반영)
+func (h *TaskHandler) UpdateTaskHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req UpdateTaskRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	return h.setDone(c, id, *req.Done)
+}
+
+// CompleteTaskHandler 함수 정의
+func (h *TaskHandler) CompleteTaskHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	return h.setDone(c, id, true)
+}
+
+func (h *TaskHandler) setDone(c echo.Context, id int, done bool) error {
+	if _, err := h.TaskService.GetTaskByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	task, err := h.TaskService.SetTaskDone(currentScope(c), id, done)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, model.AuditEntry{
+		Action:     service.AuditTaskUpdate,
+		TargetType: service.AuditTargetTask,
+		TargetID:   task.ID,
+		Details:    fmt.Sprintf("note_id=%d done=%t", task.NoteID, task.Done),
+	})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Task updated successfully",
+		"task_info": taskToResponse(task),
+	})
+}
+
+// ExtractTasksHandler 함수 정의 (AI로 할 일 추출)
+func (h *TaskHandler) ExtractTasksHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if _, err := h.TaskService.Notes.GetNoteByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	tasks, err := h.TaskService.ExtractTasksWithAI(c.Request().Context(), currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Tasks extracted successfully",
+		"tasks":   tasksToResponse(tasks),
+	})
+}
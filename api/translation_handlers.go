@@ -0,0 +1,126 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TranslationHandler 구조체 정의
+type TranslationHandler struct {
+	NoteService        *service.NoteService
+	TranslationService *service.TranslationService
+	JobService         *service.JobService
+}
+
+// NewTranslationHandler 함수 정의
+func NewTranslationHandler(noteService *service.NoteService, translationService *service.TranslationService, jobService *service.JobService) *TranslationHandler {
+	return &TranslationHandler{
+		NoteService:        noteService,
+		TranslationService: translationService,
+		JobService:         jobService,
+	}
+}
+
+// TranslationResponse 구조체 정의
+type TranslationResponse struct {
+	NoteID         int     `json:"note_id"`
+	Language       string  `json:"language"`
+	Title          string  `json:"title"`
+	Content        string  `json:"content"`
+	SourceRevision int     `json:"source_revision"`
+	Stale          bool    `json:"stale"`
+	CreatedTime    string  `json:"created_time"`
+	UpdatedTime    *string `json:"updated_time"`
+}
+
+func translationToResponse(translation *model.NoteTranslation) TranslationResponse {
+	return TranslationResponse{
+		NoteID:         translation.NoteID,
+		Language:       translation.Language,
+		Title:          translation.Title,
+		Content:        translation.Content,
+		SourceRevision: translation.SourceRevision,
+		Stale:          translation.Stale,
+		CreatedTime:    formatTime(translation.CreatedTime),
+		UpdatedTime:    formatOptionalTime(translation.UpdatedTime),
+	}
+}
+
+// TranslateNoteRequest 구조체 정의
+type TranslateNoteRequest struct {
+	Language string `json:"language" validate:"required"`
+	Async    bool   `json:"async"`
+}
+
+// TranslateNoteHandler 함수 정의 (번역본 생성 또는 갱신)
+func (h *TranslationHandler) TranslateNoteHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req TranslateNoteRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	language, err := service.NormalizeLanguage(req.Language)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.NoteService.GetNoteByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	if req.Async {
+		job, err := h.JobService.Enqueue(currentScope(c), service.JobTypeTranslate, service.TranslateJobPayload{NoteID: id, Language: language})
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusAccepted, map[string]interface{}{
+			"message":  "Note translation queued",
+			"job_info": jobToResponse(job),
+		})
+	}
+
+	translation, err := h.TranslationService.TranslateNote(c.Request().Context(), currentScope(c), id, language)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":          "Note translated successfully",
+		"translation_info": translationToResponse(translation),
+	})
+}
+
+// GetTranslationsHandler 함수 정의 (노트의 번역본 목록)
+func (h *TranslationHandler) GetTranslationsHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if _, err := h.NoteService.GetNoteByID(currentScope(c), id); err != nil {
+		return err
+	}
+
+	translations, err := h.TranslationService.GetTranslations(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]TranslationResponse, len(translations))
+	for i, translation := range translations {
+		responses[i] = translationToResponse(translation)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":      "Translations retrieved successfully",
+		"translations": responses,
+	})
+}
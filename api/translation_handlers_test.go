@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"myapp/service"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestGetNoteReturnsTheTranslatedVariant(t *testing.T) {
+	db, err := repository.Open(filepath.Join(t.TempDir(), "notes.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	notes := service.NewNoteService(db, repository.NewNoteRepository(db), repository.NewNoteRevisionRepository(db), repository.NewNoteTranslationRepository(db),
+		repository.NewTaskRepository(db), repository.NewNoteShareRepository(db), service.NewEventBus(repository.NewNoteEventRepository(db)))
+	h := &NoteHandler{NoteService: notes, TranslationService: service.NewTranslationService(notes, nil, nil)}
+
+	scope := model.Scope{UserID: 1}
+	note, err := notes.CreateNote(scope, "장보기", "우유", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = notes.Translations.Upsert(&model.NoteTranslation{NoteID: note.ID, Language: "en", Title: "Groceries", Content: "milk", SourceRevision: 1, CreatedTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := notes.UpdateNote(scope, note.ID, "장보기", "우유와 빵", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(query string) (*httptest.ResponseRecorder, error) {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/notes/1?"+query, nil), rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(note.ID))
+		c.Set(userIDKey, scope.UserID)
+		return rec, h.GetNoteByIDHandler(c)
+	}
+
+	rec, err := get("lang=EN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Note        NoteResponse `json:"note_info"`
+		Translation struct {
+			Language       string `json:"language"`
+			SourceRevision int    `json:"source_revision"`
+			Stale          bool   `json:"stale"`
+		} `json:"translation_info"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Note.Title != "Groceries" || body.Note.Content != "milk" || body.Note.ID != note.ID {
+		t.Fatalf("note_info = %+v, want the translated title and content", body.Note)
+	}
+	if body.Translation.Language != "en" || body.Translation.SourceRevision != 1 || !body.Translation.Stale {
+		t.Fatalf("translation_info = %+v, want a stale English variant of revision 1", body.Translation)
+	}
+	// 번역본 응답에는 원본 버전의 ETag를 붙이지 않음
+	if etag := rec.Header().Get(HeaderETag); etag != "" {
+		t.Fatalf("ETag %s on a translated response", etag)
+	}
+
+	if rec, err := get(""); err != nil || rec.Header().Get(HeaderETag) == "" {
+		t.Fatalf("original note: %v, ETag %q", err, rec.Header().Get(HeaderETag))
+	}
+	if _, err := get("lang=ja"); !errors.Is(err, model.NotFound("translation not found")) {
+		t.Fatalf("missing translation: %v, want not found", err)
+	}
+	if _, err := get("lang=japanese"); !errors.Is(err, model.Validation(`invalid language code "japanese"`)) {
+		t.Fatalf("invalid language: %v, want a validation error", err)
+	}
+}
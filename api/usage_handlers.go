@@ -0,0 +1,79 @@
+package api
+
+import (
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UsageHandler 구조체 정의
+type UsageHandler struct {
+	UsageService *service.UsageService
+}
+
+// NewUsageHandler 함수 정의
+func NewUsageHandler(usageService *service.UsageService) *UsageHandler {
+	return &UsageHandler{UsageService: usageService}
+}
+
+// GetUsageHandler 함수 정의 (X-Workspace-ID가 있으면 워크스페이스에서 한 호출, 없으면 자신이 한 호출)
+func (h *UsageHandler) GetUsageHandler(c echo.Context) error {
+	scope := currentScope(c)
+	return h.usage(c, &scope)
+}
+
+// GetAllUsageHandler 함수 정의 (관리자용, 모든 사용자와 워크스페이스)
+func (h *UsageHandler) GetAllUsageHandler(c echo.Context) error {
+	return h.usage(c, nil)
+}
+
+// usage 함수 정의 (group_by=day|user|note|model, from/to=YYYY-MM-DD, 기본 최근 30일)
+func (h *UsageHandler) usage(c echo.Context, scope *model.Scope) error {
+	groupBy := c.QueryParam("group_by")
+	if groupBy == "" {
+		groupBy = "day"
+	}
+
+	now := time.Now().UTC()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	from := to.AddDate(0, 0, -30)
+
+	if v := c.QueryParam("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return model.Validation("Invalid from date, expected YYYY-MM-DD")
+		}
+		from = t
+	}
+	if v := c.QueryParam("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return model.Validation("Invalid to date, expected YYYY-MM-DD")
+		}
+		// to 날짜 당일까지 포함
+		to = t.AddDate(0, 0, 1)
+	}
+
+	summaries, unpriced, err := h.UsageService.Summarize(scope, groupBy, from, to)
+	if err != nil {
+		return err
+	}
+	totalCost := 0.0
+	for _, summary := range summaries {
+		totalCost += summary.EstimatedCostUSD
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":            "Usage retrieved successfully",
+		"group_by":           groupBy,
+		"from":               from.Format("2006-01-02"),
+		"to":                 to.AddDate(0, 0, -1).Format("2006-01-02"),
+		"daily_token_quota":  h.UsageService.DailyTokenQuota,
+		"estimated_cost_usd": totalCost,
+		"unpriced_models":    unpriced,
+		"usage":              summaries,
+	})
+}
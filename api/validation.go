@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"myapp/model"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 클라이언트 시각 허용 범위 (서버와의 시계 차이는 5분까지 허용)
+var (
+	minClientTime   = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	clientClockSkew = 5 * time.Minute
+)
+
+// fieldValidator 인터페이스 정의 (태그로 표현할 수 없는 항목 간 검증)
+type fieldValidator interface {
+	validateFields() []model.FieldError
+}
+
+// bindStrict 함수 정의 (JSON 본문을 읽고 모르는 필드는 거부한 뒤 validate 태그 검증)
+// 폼 본문은 받지 않음 (Content-Type이 JSON이 아니면 415, 없으면 JSON으로 읽음)
+func bindStrict(c echo.Context, v interface{}) error {
+	if contentType := c.Request().Header.Get(echo.HeaderContentType); contentType != "" {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType != echo.MIMEApplicationJSON && !strings.HasSuffix(mediaType, "+json") {
+			return model.NewError(model.ErrCodeUnsupportedMedia, "Content-Type must be "+echo.MIMEApplicationJSON)
+		}
+	}
+	return decodeStrict(c.Request().Body, v)
+}
+
+// decodeStrict 함수 정의 (본문은 JSON 값 하나만 허용, 빈 본문은 빈 객체로 취급)
+func decodeStrict(r io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return decodeError(err)
+	}
+	if err == nil {
+		if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+			return model.Validation("request body must contain a single JSON value")
+		}
+	}
+	return validateStruct(v)
+}
+
+// decodeError 함수 정의 (JSON 해석 오류를 항목별 오류로 변환)
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return model.InvalidFields([]model.FieldError{{Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()}})
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return model.InvalidFields([]model.FieldError{{Field: strings.Trim(field, `"`), Message: "unknown field"}})
+	}
+	return model.Validation("Invalid request format").Wrap(err)
+}
+
+// validateStruct 함수 정의 (validate 태그: required, present, max=N, img, timestamp)
+// 포인터 항목은 키가 없으면 nil이므로 present(키 필수, 빈 값 허용)나 required로 키가 있는지 확인
+func validateStruct(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+
+	var fields []model.FieldError
+	for i := 0; i < rt.NumField(); i++ {
+		tag := rt.Field(i).Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		name := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = rt.Field(i).Name
+		}
+		field := rv.Field(i)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				if hasRule(tag, "required") || hasRule(tag, "present") {
+					fields = append(fields, model.FieldError{Field: name, Message: "is required"})
+				}
+				continue
+			}
+			field = field.Elem()
+		}
+		value, ok := field.Interface().(string)
+		if !ok {
+			continue
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if message := checkRule(rule, value); message != "" {
+				fields = append(fields, model.FieldError{Field: name, Message: message})
+				break
+			}
+		}
+	}
+
+	if fv, ok := v.(fieldValidator); ok && len(fields) == 0 {
+		fields = fv.validateFields()
+	}
+	if len(fields) > 0 {
+		return model.InvalidFields(fields)
+	}
+	return nil
+}
+
+// hasRule 함수 정의
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRule 함수 정의 (통과하면 빈 문자열, 빈 값은 required만 검사)
+func checkRule(rule, value string) string {
+	name, arg, _ := strings.Cut(rule, "=")
+	if name == "present" {
+		return ""
+	}
+	if name == "required" {
+		if strings.TrimSpace(value) == "" {
+			return "is required"
+		}
+		return ""
+	}
+	if value == "" {
+		return ""
+	}
+
+	switch name {
+	case "max":
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			panic(fmt.Sprintf("invalid validate rule %q", rule))
+		}
+		if utf8.RuneCountInString(value) > limit {
+			return fmt.Sprintf("must be at most %d characters", limit)
+		}
+	case "img":
+		if !isImageReference(value) {
+			return "must be an http(s) URL or an uploads/ attachment path"
+		}
+	case "timestamp":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "must be an RFC3339 timestamp"
+		}
+	default:
+		panic(fmt.Sprintf("unknown validate rule %q", rule))
+	}
+	return ""
+}
+
+// isImageReference 함수 정의 (외부 이미지 URL 또는 업로드 폴더 안의 첨부 경로)
+func isImageReference(value string) bool {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		u, err := url.Parse(value)
+		return err == nil && u.Host != ""
+	}
+	cleaned := path.Clean(strings.TrimPrefix(value, "./"))
+	return strings.HasPrefix(cleaned, publicImageDir+"/") && !strings.Contains(value, "..")
+}
+
+// checkClientTime 함수 정의 (가져오기 시각이 1970년 이후이고 미래가 아닌지 확인)
+func checkClientTime(field string, t time.Time) []model.FieldError {
+	if t.Before(minClientTime) || t.After(time.Now().Add(clientClockSkew)) {
+		return []model.FieldError{{Field: field, Message: "must not be in the future or before 1970"}}
+	}
+	return nil
+}
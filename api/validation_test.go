@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"myapp/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// fieldErrors 함수 정의 (항목별 오류를 항목 이름 → 메시지로 변환, 항목별 오류가 아니면 실패)
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var modelErr *model.Error
+	if !errors.As(err, &modelErr) || modelErr.Code != model.ErrCodeValidation {
+		t.Fatalf("error = %v, want a validation error", err)
+	}
+	fields, ok := modelErr.Details.([]model.FieldError)
+	if !ok {
+		t.Fatalf("validation error without field details: %v", err)
+	}
+	messages := make(map[string]string)
+	for _, field := range fields {
+		messages[field.Field] = field.Message
+	}
+	return messages
+}
+
+func TestDecodeStrictRejectsMalformedBodies(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"unknown field", `{"content": "a", "tags": ["x"]}`, "tags"},
+		{"wrong type", `{"content": 5}`, "content"},
+		{"missing required", `{"title": "only a title"}`, "content"},
+		{"over max", `{"content": "a", "title": "` + strings.Repeat("가", 201) + `"}`, "title"},
+		{"bad image", `{"content": "a", "img": "../../etc/passwd"}`, "img"},
+		{"bad timestamp", `{"content": "a", "createdAt": "yesterday"}`, "createdAt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req CreateNoteRequest
+			fields := fieldErrors(t, decodeStrict(strings.NewReader(tt.body), &req))
+			if _, ok := fields[tt.field]; !ok || len(fields) != 1 {
+				t.Fatalf("field errors = %v, want only %s", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestDecodeStrictRejectsTrailingContent(t *testing.T) {
+	for _, body := range []string{`{"content": "a"} {"content": "b"}`, `{"content": "a"}}`, `{"content": "a"} x`} {
+		var req CreateNoteRequest
+		err := decodeStrict(strings.NewReader(body), &req)
+		var modelErr *model.Error
+		if !errors.As(err, &modelErr) || modelErr.Code != model.ErrCodeValidation {
+			t.Fatalf("body %q: error = %v, want a validation error", body, err)
+		}
+	}
+
+	var req CreateNoteRequest
+	if err := decodeStrict(strings.NewReader("{\"content\": \"a\"}\n\t "), &req); err != nil {
+		t.Fatalf("trailing whitespace rejected: %v", err)
+	}
+}
+
+func TestPresentRequiresTheKeyButAllowsEmptyValues(t *testing.T) {
+	var missing UpdateTaskRequest
+	if fields := fieldErrors(t, decodeStrict(strings.NewReader(`{}`), &missing)); fields["done"] != "is required" {
+		t.Fatalf("field errors = %v, want done is required", fields)
+	}
+
+	var apply ApplyAssistRequest
+	if err := decodeStrict(strings.NewReader(`{"content": "new", "base_revision": 0}`), &apply); err != nil {
+		t.Fatalf("base_revision 0 rejected: %v", err)
+	}
+	if apply.BaseRevision == nil || *apply.BaseRevision != 0 {
+		t.Fatalf("base_revision = %v, want 0", apply.BaseRevision)
+	}
+}
+
+func TestBindStrictRejectsFormBodies(t *testing.T) {
+	e := echo.New()
+	tests := []struct {
+		contentType string
+		status      int
+	}{
+		{echo.MIMEApplicationForm, http.StatusUnsupportedMediaType},
+		{echo.MIMEMultipartForm + "; boundary=x", http.StatusUnsupportedMediaType},
+		{echo.MIMEApplicationJSONCharsetUTF8, 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"content": "a"}`))
+		if tt.contentType != "" {
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+		}
+		var body CreateNoteRequest
+		err := bindStrict(e.NewContext(req, httptest.NewRecorder()), &body)
+		if tt.status == 0 {
+			if err != nil {
+				t.Fatalf("Content-Type %q: %v", tt.contentType, err)
+			}
+			continue
+		}
+		var modelErr *model.Error
+		if !errors.As(err, &modelErr) || errorStatuses[modelErr.Code] != tt.status {
+			t.Fatalf("Content-Type %q: error = %v, want status %d", tt.contentType, err, tt.status)
+		}
+	}
+}
+
+func TestUpdateNoteRequestRequiresEveryField(t *testing.T) {
+	var req UpdateNoteRequest
+	fields := fieldErrors(t, decodeStrict(strings.NewReader(`{"content": "only content"}`), &req))
+	if fields["title"] != "is required" || fields["img"] != "is required" {
+		t.Fatalf("field errors = %v, want title and img required", fields)
+	}
+
+	req = UpdateNoteRequest{}
+	if err := decodeStrict(strings.NewReader(`{"title": "", "content": "c", "img": ""}`), &req); err != nil {
+		t.Fatalf("empty title and img rejected: %v", err)
+	}
+	if req.Title == nil || *req.Title != "" || req.Img == nil || *req.Img != "" {
+		t.Fatalf("decoded %+v, want empty title and img", req)
+	}
+}
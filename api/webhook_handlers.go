@@ -0,0 +1,295 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 전송 기록 조회 기본, 최대 개수
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// WebhookHandler 구조체 정의
+type WebhookHandler struct {
+	WebhookService *service.WebhookService
+	Audit          *service.AuditService
+}
+
+// NewWebhookHandler 함수 정의
+func NewWebhookHandler(webhookService *service.WebhookService, audit *service.AuditService) *WebhookHandler {
+	return &WebhookHandler{WebhookService: webhookService, Audit: audit}
+}
+
+// WebhookRequest 구조체 정의 (event_types가 비어 있으면 모든 이벤트, secret이 비어 있으면 생성할 때는 서버가 만들고 수정할 때는 유지, active가 없으면 생성할 때 true이고 수정할 때 유지)
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,max=2048"`
+	Secret     string   `json:"secret" validate:"max=256"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookResponse 구조체 정의 (비밀 키는 생성 응답에만 포함)
+type WebhookResponse struct {
+	ID          int      `json:"id"`
+	WorkspaceID int      `json:"workspace_id"`
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Active      bool     `json:"active"`
+	CreatedTime string   `json:"created_time"`
+	UpdatedTime *string  `json:"updated_time"`
+}
+
+// WebhookDeliveryResponse 구조체 정의 (payload는 보낸 본문 그대로, replay_of는 다시 보낸 원래 기록 ID)
+type WebhookDeliveryResponse struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	JobID          int             `json:"job_id"`
+	ReplayOf       int             `json:"replay_of"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedTime    string          `json:"created_time"`
+	UpdatedTime    *string         `json:"updated_time"`
+}
+
+func webhookToResponse(webhook *model.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:          webhook.ID,
+		WorkspaceID: webhook.WorkspaceID,
+		URL:         webhook.URL,
+		EventTypes:  webhook.EventTypes,
+		Active:      webhook.Active,
+		CreatedTime: formatTime(webhook.CreatedTime),
+		UpdatedTime: formatOptionalTime(webhook.UpdatedTime),
+	}
+}
+
+func deliveryToResponse(delivery *model.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		JobID:          delivery.JobID,
+		ReplayOf:       delivery.ReplayOf,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedTime:    formatTime(delivery.CreatedTime),
+		UpdatedTime:    formatOptionalTime(delivery.UpdatedTime),
+	}
+}
+
+// webhookAudit 함수 정의
+func webhookAudit(action string, webhook *model.Webhook) model.AuditEntry {
+	return model.AuditEntry{
+		Action:     action,
+		TargetType: service.AuditTargetWebhook,
+		TargetID:   webhook.ID,
+		Details:    fmt.Sprintf("url=%s events=%s active=%t", webhook.URL, strings.Join(webhook.EventTypes, ","), webhook.Active),
+	}
+}
+
+// CreateWebhookHandler 함수 정의 (X-Workspace-ID가 있으면 워크스페이스 노트, 없으면 개인 노트의 이벤트 구독)
+func (h *WebhookHandler) CreateWebhookHandler(c echo.Context) error {
+	var req WebhookRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	active := req.Active == nil || *req.Active
+
+	webhook, secret, err := h.WebhookService.CreateWebhook(c.Request().Context(), currentScope(c), req.URL, req.Secret, req.EventTypes, active)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, webhookAudit(service.AuditWebhookCreate, webhook))
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":      "Webhook created successfully",
+		"secret":       secret,
+		"webhook_info": webhookToResponse(webhook),
+	})
+}
+
+// GetWebhooksHandler 함수 정의
+func (h *WebhookHandler) GetWebhooksHandler(c echo.Context) error {
+	webhooks, err := h.WebhookService.GetWebhooks(currentScope(c))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = webhookToResponse(webhook)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Webhooks retrieved successfully",
+		"webhooks": responses,
+	})
+}
+
+// GetWebhookHandler 함수 정의
+func (h *WebhookHandler) GetWebhookHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	webhook, err := h.WebhookService.GetWebhook(currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":      "Webhook retrieved successfully",
+		"webhook_info": webhookToResponse(webhook),
+	})
+}
+
+// UpdateWebhookHandler 함수 정의
+func (h *WebhookHandler) UpdateWebhookHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req WebhookRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	webhook, err := h.WebhookService.UpdateWebhook(c.Request().Context(), currentScope(c), id, req.URL, req.Secret, req.EventTypes, req.Active)
+	if err != nil {
+		return err
+	}
+
+	entry := webhookAudit(service.AuditWebhookUpdate, webhook)
+	if req.Secret != "" {
+		entry.Details += " secret_rotated=true"
+	}
+	recordAudit(c, h.Audit, entry)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":      "Webhook updated successfully",
+		"webhook_info": webhookToResponse(webhook),
+	})
+}
+
+// DeleteWebhookHandler 함수 정의 (전송 기록도 함께 삭제)
+func (h *WebhookHandler) DeleteWebhookHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	if err := h.WebhookService.DeleteWebhook(currentScope(c), id); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, model.AuditEntry{
+		Action:     service.AuditWebhookDelete,
+		TargetType: service.AuditTargetWebhook,
+		TargetID:   id,
+	})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// TestWebhookHandler 함수 정의 (ping을 바로 보내고 수신 측 응답을 돌려줌, 수신 측이 실패해도 200이며 결과는 delivery_info.status)
+func (h *WebhookHandler) TestWebhookHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	delivery, err := h.WebhookService.TestWebhook(c.Request().Context(), currentScope(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "Test delivery sent",
+		"delivery_info": deliveryToResponse(delivery),
+	})
+}
+
+// GetDeliveriesHandler 함수 정의 (최근 전송 기록부터, limit 기본 50, 최대 200)
+func (h *WebhookHandler) GetDeliveriesHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	limit := defaultDeliveryLimit
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxDeliveryLimit {
+			return model.Validation(fmt.Sprintf("limit must be between 1 and %d", maxDeliveryLimit))
+		}
+	}
+
+	deliveries, err := h.WebhookService.GetDeliveries(currentScope(c), id, limit)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = deliveryToResponse(delivery)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":    "Deliveries retrieved successfully",
+		"deliveries": responses,
+	})
+}
+
+// ReplayDeliveryHandler 함수 정의 (같은 본문을 새 전송 기록으로 다시 보냄, 실패하면 일반 전송처럼 재시도)
+func (h *WebhookHandler) ReplayDeliveryHandler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		return model.Validation("Invalid delivery ID format")
+	}
+
+	delivery, job, err := h.WebhookService.ReplayDelivery(currentScope(c), id, deliveryID)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, model.AuditEntry{
+		Action:     service.AuditWebhookReplay,
+		TargetType: service.AuditTargetWebhook,
+		TargetID:   id,
+		Details:    fmt.Sprintf("delivery_id=%d replay_of=%d", delivery.ID, deliveryID),
+	})
+
+	return c.JSON(http.StatusAccepted, map[string]interface{}{
+		"message":       "Delivery queued for replay",
+		"delivery_info": deliveryToResponse(delivery),
+		"job_info":      jobToResponse(job),
+	})
+}
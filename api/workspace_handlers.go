@@ -0,0 +1,300 @@
+package api
+
+import (
+	"fmt"
+	"myapp/model"
+	"myapp/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WorkspaceHandler 구조체 정의
+type WorkspaceHandler struct {
+	WorkspaceService *service.WorkspaceService
+	Audit            *service.AuditService
+}
+
+// NewWorkspaceHandler 함수 정의
+func NewWorkspaceHandler(workspaceService *service.WorkspaceService, audit *service.AuditService) *WorkspaceHandler {
+	return &WorkspaceHandler{WorkspaceService: workspaceService, Audit: audit}
+}
+
+// CreateWorkspaceRequest 구조체 정의
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// UpdateMemberRequest 구조체 정의
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// InviteMemberRequest 구조체 정의
+type InviteMemberRequest struct {
+	Email string `json:"email" validate:"required,max=254"`
+	Role  string `json:"role"`
+}
+
+// AcceptInvitationRequest 구조체 정의
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// WorkspaceResponse 구조체 정의
+type WorkspaceResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	CreatedTime string `json:"created_time"`
+}
+
+// WorkspaceMemberResponse 구조체 정의
+type WorkspaceMemberResponse struct {
+	UserID      int    `json:"user_id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	CreatedTime string `json:"created_time"`
+}
+
+// InvitationResponse 구조체 정의 (초대 토큰 원문은 생성 응답에만 포함)
+type InvitationResponse struct {
+	ID          int    `json:"id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	Prefix      string `json:"prefix"`
+	InvitedBy   int    `json:"invited_by"`
+	ExpiresTime string `json:"expires_time"`
+	CreatedTime string `json:"created_time"`
+}
+
+func workspaceToResponse(workspace *model.Workspace) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:          workspace.ID,
+		Name:        workspace.Name,
+		Role:        workspace.Role,
+		CreatedTime: formatTime(workspace.CreatedTime),
+	}
+}
+
+func invitationToResponse(inv *model.WorkspaceInvitation) InvitationResponse {
+	return InvitationResponse{
+		ID:          inv.ID,
+		Email:       inv.Email,
+		Role:        inv.Role,
+		Prefix:      inv.Prefix,
+		InvitedBy:   inv.InvitedBy,
+		ExpiresTime: formatTime(inv.ExpiresTime),
+		CreatedTime: formatTime(inv.CreatedTime),
+	}
+}
+
+// CreateWorkspaceHandler 함수 정의
+func (h *WorkspaceHandler) CreateWorkspaceHandler(c echo.Context) error {
+	var req CreateWorkspaceRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	workspace, err := h.WorkspaceService.CreateWorkspace(currentUserID(c), req.Name)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditWorkspaceCreate, workspace.ID, "name="+workspace.Name))
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":        "Workspace created successfully",
+		"workspace_info": workspaceToResponse(workspace),
+	})
+}
+
+// GetWorkspacesHandler 함수 정의 (내가 속한 워크스페이스)
+func (h *WorkspaceHandler) GetWorkspacesHandler(c echo.Context) error {
+	workspaces, err := h.WorkspaceService.GetWorkspaces(currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]WorkspaceResponse, len(workspaces))
+	for i, workspace := range workspaces {
+		responses[i] = workspaceToResponse(workspace)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":    "Workspaces retrieved successfully",
+		"workspaces": responses,
+	})
+}
+
+// GetMembersHandler 함수 정의
+func (h *WorkspaceHandler) GetMembersHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	members, err := h.WorkspaceService.GetMembers(currentUserID(c), workspaceID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]WorkspaceMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = WorkspaceMemberResponse{
+			UserID:      member.UserID,
+			Email:       member.Email,
+			Role:        member.Role,
+			CreatedTime: formatTime(member.CreatedTime),
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Members retrieved successfully",
+		"members": responses,
+	})
+}
+
+// UpdateMemberHandler 함수 정의 (멤버 역할 변경)
+func (h *WorkspaceHandler) UpdateMemberHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return model.Validation("Invalid user ID format")
+	}
+
+	var req UpdateMemberRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.WorkspaceService.ChangeMemberRole(currentUserID(c), workspaceID, userID, req.Role); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditMemberUpdate, workspaceID, fmt.Sprintf("user_id=%d role=%s", userID, req.Role)))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Member updated successfully",
+	})
+}
+
+// RemoveMemberHandler 함수 정의 (본인이면 워크스페이스 탈퇴)
+func (h *WorkspaceHandler) RemoveMemberHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return model.Validation("Invalid user ID format")
+	}
+
+	if err := h.WorkspaceService.RemoveMember(currentUserID(c), workspaceID, userID); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditMemberRemove, workspaceID, fmt.Sprintf("user_id=%d", userID)))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Member removed successfully",
+	})
+}
+
+// InviteMemberHandler 함수 정의
+func (h *WorkspaceHandler) InviteMemberHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	var req InviteMemberRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+	if req.Role == "" {
+		req.Role = model.WorkspaceRoleMember
+	}
+
+	inv, raw, err := h.WorkspaceService.Invite(currentUserID(c), workspaceID, req.Email, req.Role)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditInvitationCreate, workspaceID, fmt.Sprintf("invitation_id=%d email=%s role=%s", inv.ID, inv.Email, inv.Role)))
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":         "Invitation created successfully",
+		"token":           raw,
+		"invitation_info": invitationToResponse(inv),
+	})
+}
+
+// GetInvitationsHandler 함수 정의 (수락 전 초대 목록)
+func (h *WorkspaceHandler) GetInvitationsHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+
+	invitations, err := h.WorkspaceService.GetInvitations(currentUserID(c), workspaceID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]InvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		responses[i] = invitationToResponse(inv)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Invitations retrieved successfully",
+		"invitations": responses,
+	})
+}
+
+// RevokeInvitationHandler 함수 정의
+func (h *WorkspaceHandler) RevokeInvitationHandler(c echo.Context) error {
+	workspaceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return model.Validation("Invalid ID format")
+	}
+	invitationID, err := strconv.Atoi(c.Param("invitation_id"))
+	if err != nil {
+		return model.Validation("Invalid invitation ID format")
+	}
+
+	if err := h.WorkspaceService.RevokeInvitation(currentUserID(c), workspaceID, invitationID); err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditInvitationRevoke, workspaceID, fmt.Sprintf("invitation_id=%d", invitationID)))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Invitation revoked successfully",
+	})
+}
+
+// AcceptInvitationHandler 함수 정의
+func (h *WorkspaceHandler) AcceptInvitationHandler(c echo.Context) error {
+	var req AcceptInvitationRequest
+	if err := bindStrict(c, &req); err != nil {
+		return err
+	}
+
+	workspace, err := h.WorkspaceService.AcceptInvitation(currentUserID(c), req.Token)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(c, h.Audit, workspaceAudit(service.AuditInvitationAccept, workspace.ID, ""))
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":        "Invitation accepted successfully",
+		"workspace_info": workspaceToResponse(workspace),
+	})
+}
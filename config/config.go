@@ -1,24 +1,249 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
+	"myapp/model"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	DatabasePath string
+	JobWorkers   int
+
+	// 인증 (JWT 서명 키, 토큰 유효 기간), 허용 CORS 출처 (비어 있으면 다른 출처 허용 안 함)
+	JWTSecret        string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	CORSAllowOrigins []string
+	// X-Forwarded-For를 믿을 리버스 프록시 주소 (비어 있으면 접속한 주소를 그대로 사용)
+	TrustedProxies []*net.IPNet
+
+	// 노트 가져오기 시 클라이언트가 보낸 작성, 수정 시각 사용 허용
+	AllowClientTimestamps bool
+	// 노트 수정, 삭제 시 If-Match 헤더 필수 여부
+	RequireIfMatch bool
+
+	// 버전 없는 이전 API 경로 제공 종료 예정일 (Sunset 헤더)
+	LegacyAPISunset time.Time
+
+	// 첨부 이미지 업로드 요청 본문 최대 크기
+	MaxUploadBytes int64
+
+	// 웹훅 전송 한 번의 제한 시간, 내부 주소여도 보낼 수 있는 호스트 (로컬 수신기 개발용)
+	WebhookTimeout      time.Duration
+	WebhookAllowedHosts []string
+
+	// 노트 이벤트 보관 기간 (0이면 삭제하지 않음, 이보다 오래된 커서로는 이어받을 수 없음)
+	NoteEventRetention time.Duration
+
+	// 일일 AI 토큰 한도 (0이면 무제한), 비용 추정에 쓰는 모델별 가격
+	DailyTokenQuota int
+	AIPricing       map[string]model.ModelPrice
+
+	// AI 호출 타임아웃, 재시도, 서킷 브레이커
+	AITimeout          time.Duration
+	AIMaxRetries       int
+	AIBreakerThreshold int
+	AIBreakerCooldown  time.Duration
+
+	// 모델 선택과 생성 파라미터
+	AIModels            []string
+	AIDefaultModel      string
+	AITemperature       *float32
+	AIMaxOutputTokens   int
+	AISystemInstruction string
+	AISafetyThreshold   string
 }
 
 func LoadConfig() *Config {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file")
+	// .env가 없으면 프로세스 환경 변수만 사용 (.env.example 참고)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("Error loading .env file: %v", err)
 	}
 
 	config := &Config{
 		DatabasePath: os.Getenv("DATABASE_PATH"),
+		JobWorkers:   getEnvInt("JOB_WORKERS", 2),
+
+		JWTSecret:        os.Getenv("JWT_SECRET"),
+		AccessTokenTTL:   time.Duration(getEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL:  time.Duration(getEnvInt("REFRESH_TOKEN_TTL_HOURS", 720)) * time.Hour,
+		CORSAllowOrigins: getEnvList("CORS_ALLOW_ORIGINS", nil),
+		TrustedProxies:   getEnvCIDRs("TRUSTED_PROXIES"),
+
+		AllowClientTimestamps: os.Getenv("ALLOW_CLIENT_TIMESTAMPS") == "true",
+		RequireIfMatch:        os.Getenv("REQUIRE_IF_MATCH") == "true",
+
+		LegacyAPISunset: getEnvDate("LEGACY_API_SUNSET", time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)),
+
+		MaxUploadBytes: int64(getEnvInt("MAX_UPLOAD_MB", 10)) << 20,
+
+		WebhookTimeout:      time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		WebhookAllowedHosts: getEnvList("WEBHOOK_ALLOWED_HOSTS", nil),
+
+		NoteEventRetention: time.Duration(getEnvInt("NOTE_EVENT_RETENTION_DAYS", 30)) * 24 * time.Hour,
+
+		DailyTokenQuota: getEnvInt("AI_DAILY_TOKEN_QUOTA", 0),
+		AIPricing:       getEnvPricing("AI_PRICING", defaultAIPricing),
+
+		AITimeout:          time.Duration(getEnvInt("AI_TIMEOUT_SECONDS", 30)) * time.Second,
+		AIMaxRetries:       getEnvInt("AI_MAX_RETRIES", 2),
+		AIBreakerThreshold: getEnvInt("AI_BREAKER_THRESHOLD", 5),
+		AIBreakerCooldown:  time.Duration(getEnvInt("AI_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+
+		AIModels:            getEnvList("AI_MODELS", []string{"gemini-1.5-flash", "gemini-1.5-pro"}),
+		AIDefaultModel:      getEnv("AI_DEFAULT_MODEL", "gemini-1.5-flash"),
+		AITemperature:       getEnvFloat32("AI_TEMPERATURE"),
+		AIMaxOutputTokens:   getEnvInt("AI_MAX_OUTPUT_TOKENS", 0),
+		AISystemInstruction: os.Getenv("AI_SYSTEM_INSTRUCTION"),
+		AISafetyThreshold:   os.Getenv("AI_SAFETY_THRESHOLD"),
+	}
+	if err := checkJWTSecret(config.JWTSecret); err != nil {
+		log.Fatalf("invalid JWT_SECRET: %v", err)
 	}
 	return config
 }
+
+// minJWTSecretLength HS256 서명 키 최소 길이 (바이트)
+const minJWTSecretLength = 32
+
+// placeholderJWTSecret .env.example에 들어 있는 예시 값
+const placeholderJWTSecret = "USE A LONG RANDOM SECRET"
+
+// checkJWTSecret 함수 정의 (예시 값이나 짧은 키로는 누구나 토큰을 만들 수 있으므로 시작하지 않음)
+func checkJWTSecret(secret string) error {
+	if secret == "" {
+		return fmt.Errorf("JWT_SECRET must be set")
+	}
+	if secret == placeholderJWTSecret {
+		return fmt.Errorf("JWT_SECRET is the example value; generate one with `openssl rand -hex 32`")
+	}
+	if len(secret) < minJWTSecretLength {
+		return fmt.Errorf("JWT_SECRET must be at least %d bytes", minJWTSecretLength)
+	}
+	return nil
+}
+
+// getEnvInt 함수 정의 (값이 없거나 잘못되면 기본값 사용)
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("invalid %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getEnv 함수 정의
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvDate 함수 정의 (YYYY-MM-DD 형식, 값이 없거나 잘못되면 기본값 사용)
+func getEnvDate(key string, fallback time.Time) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		log.Printf("invalid %s=%q, using %s", key, value, fallback.Format("2006-01-02"))
+		return fallback
+	}
+	return t
+}
+
+// getEnvList 함수 정의 (쉼표로 구분된 목록)
+func getEnvList(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvCIDRs 함수 정의 (쉼표로 구분된 IP 또는 CIDR 목록, 잘못된 항목은 무시)
+func getEnvCIDRs(key string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, item := range getEnvList(key, nil) {
+		if ip := net.ParseIP(item); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			log.Printf("invalid %s item %q, ignoring", key, item)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// defaultAIPricing 기본 모델의 토큰 100만 개당 공개 가격 (USD, 입력 128k 토큰 이하 기준), 바뀌면 AI_PRICING으로 덮어씀
+var defaultAIPricing = map[string]model.ModelPrice{
+	"gemini-1.5-flash": {InputPerMillion: 0.075, OutputPerMillion: 0.30},
+	"gemini-1.5-pro":   {InputPerMillion: 1.25, OutputPerMillion: 5.00},
+}
+
+// getEnvPricing 함수 정의 ("모델=입력가격:출력가격" 목록, 예: gemini-1.5-flash=0.075:0.30, 잘못된 항목은 무시)
+func getEnvPricing(key string, fallback map[string]model.ModelPrice) map[string]model.ModelPrice {
+	items := getEnvList(key, nil)
+	if items == nil {
+		return fallback
+	}
+	pricing := make(map[string]model.ModelPrice)
+	for _, item := range items {
+		name, prices, ok := strings.Cut(item, "=")
+		input, output, ok2 := strings.Cut(prices, ":")
+		in, err1 := strconv.ParseFloat(strings.TrimSpace(input), 64)
+		out, err2 := strconv.ParseFloat(strings.TrimSpace(output), 64)
+		if !ok || !ok2 || err1 != nil || err2 != nil || in < 0 || out < 0 {
+			log.Printf("invalid %s item %q, ignoring", key, item)
+			continue
+		}
+		pricing[strings.TrimSpace(name)] = model.ModelPrice{InputPerMillion: in, OutputPerMillion: out}
+	}
+	return pricing
+}
+
+// getEnvFloat32 함수 정의 (값이 없으면 nil)
+func getEnvFloat32(key string) *float32 {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		log.Printf("invalid %s=%q, ignoring", key, value)
+		return nil
+	}
+	f32 := float32(f)
+	return &f32
+}
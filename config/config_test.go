@@ -0,0 +1,35 @@
+package config
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestCheckJWTSecret(t *testing.T) {
+	for _, secret := range []string{"", placeholderJWTSecret, "short-secret"} {
+		if err := checkJWTSecret(secret); err == nil {
+			t.Errorf("checkJWTSecret(%q) accepted an unsafe secret", secret)
+		}
+	}
+	if err := checkJWTSecret(strings.Repeat("k", minJWTSecretLength)); err != nil {
+		t.Errorf("32-byte secret rejected: %v", err)
+	}
+}
+
+func TestGetEnvCIDRs(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16, not-an-ip, ::1")
+	networks := getEnvCIDRs("TRUSTED_PROXIES")
+	if len(networks) != 3 {
+		t.Fatalf("got %d networks, want 3", len(networks))
+	}
+	if !networks[0].Contains(net.ParseIP("10.0.0.1")) || networks[0].Contains(net.ParseIP("10.0.0.2")) {
+		t.Errorf("single IP should match only itself: %v", networks[0])
+	}
+	if !networks[1].Contains(net.ParseIP("192.168.3.4")) {
+		t.Errorf("CIDR should match its range: %v", networks[1])
+	}
+	if !networks[2].Contains(net.ParseIP("::1")) {
+		t.Errorf("IPv6 address should match itself: %v", networks[2])
+	}
+}
@@ -40,7 +40,12 @@ func main() {
 	}
 	defer client.Close()
 
-	model := client.GenerativeModel("gemini-1.5-flash")
+	// 서버와 같은 기본 모델 설정 사용
+	modelName := os.Getenv("AI_DEFAULT_MODEL")
+	if modelName == "" {
+		modelName = "gemini-1.5-flash"
+	}
+	model := client.GenerativeModel(modelName)
 
 	// Initialize the chat
 	// cs := model.StartChat()
@@ -1,12 +1,14 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
 	"log"
 	"myapp/api"
 	"myapp/config"
 	"myapp/repository"
 	"myapp/service"
+	"os"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -16,41 +18,154 @@ import (
 func main() {
 	// Echo 웹 프레임워크 인스턴스 생성
 	e := echo.New()
-
-	// CORS 미들웨어 설정
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
-		AllowHeaders: []string{echo.HeaderContentType, echo.HeaderAuthorization},
-	}))
+	// 오류 응답 형식 통일 ({"error": {code, message, details, request_id}})
+	e.HTTPErrorHandler = api.HTTPErrorHandler
 
 	// 환경 변수 로드
 	cfg := config.LoadConfig()
 
+	// 감사 기록에 남길 클라이언트 IP (X-Forwarded-For는 지정한 프록시에서 온 요청만 믿음, 클라이언트가 IP를 꾸밀 수 없도록)
+	if len(cfg.TrustedProxies) > 0 {
+		options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+		for _, network := range cfg.TrustedProxies {
+			options = append(options, echo.TrustIPRange(network))
+		}
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(options...)
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+
+	// 요청마다 X-Request-ID 부여 (감사 기록에 남김)
+	e.Use(middleware.RequestID())
+
+	// CORS 미들웨어 설정 (CORS_ALLOW_ORIGINS가 없으면 다른 출처의 요청 허용 안 함)
+	if len(cfg.CORSAllowOrigins) > 0 {
+		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:  cfg.CORSAllowOrigins,
+			AllowMethods:  []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE},
+			AllowHeaders:  []string{echo.HeaderContentType, echo.HeaderAuthorization, api.HeaderSharePassword, api.HeaderWorkspaceID, api.HeaderIfMatch, api.HeaderIfNoneMatch, api.HeaderLastEventID},
+			ExposeHeaders: []string{api.HeaderETag, api.HeaderDeprecation, api.HeaderSunset, api.HeaderLink},
+		}))
+	}
+
 	// SQLite 데이터베이스 연결
-	db, err := sql.Open("sqlite3", cfg.DatabasePath)
+	db, err := repository.Open(cfg.DatabasePath)
 	if err != nil {
 		log.Fatalf("could not connect to database: %v", err)
 	}
 	defer db.Close()
 
 	// 데이터베이스 스키마 초기화
-	err = initializeSchema(db)
+	err = repository.InitializeSchema(db)
 	if err != nil {
 		log.Fatalf("could not initialize database schema: %v", err)
 	}
 
+	// 감사 기록
+	auditService := service.NewAuditService(repository.NewAuditRepository(db))
+	auditHandler := api.NewAuditHandler(auditService)
+
+	// 사용자 인증
+	userRepo := repository.NewUserRepository(db)
+	authService := service.NewAuthService(userRepo, repository.NewNoteRepository(db), cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	// 운영자 명령이 있으면 실행하고 종료 (서버는 시작하지 않음)
+	if len(os.Args) > 1 {
+		if err := runCommand(authService, os.Args[1:]); err != nil {
+			log.Fatalf("%s: %v", os.Args[1], err)
+		}
+		return
+	}
+	// 계정 도입 전에 만든 노트는 소유자가 없어 조회되지 않으므로 운영자가 넘길 계정을 정하도록 안내
+	if orphans, err := authService.CountOrphanedNotes(); err != nil {
+		log.Fatalf("could not count notes without an owner: %v", err)
+	} else if orphans > 0 {
+		log.Printf("%d notes have no owner and are not visible to anyone; assign them with: myapp claim-orphan-notes <email>", orphans)
+	}
+	authHandler := api.NewAuthHandler(authService)
+	apiTokenService := service.NewAPITokenService(repository.NewAPITokenRepository(db))
+	apiTokenHandler := api.NewAPITokenHandler(apiTokenService, auditService)
+	workspaceService := service.NewWorkspaceService(repository.NewWorkspaceRepository(db), userRepo)
+	workspaceHandler := api.NewWorkspaceHandler(workspaceService, auditService)
+
 	// 레포지토리, 서비스, 핸들러 생성
 	repo := repository.NewNoteRepository(db)
-	noteService := service.NewNoteService(repo)
-	geminiService, err := service.NewGeminiService()
+	taskRepo := repository.NewTaskRepository(db)
+	shareRepo := repository.NewNoteShareRepository(db)
+	eventBus := service.NewEventBus(repository.NewNoteEventRepository(db))
+	noteService := service.NewNoteService(db, repo, repository.NewNoteRevisionRepository(db), repository.NewNoteTranslationRepository(db), taskRepo, shareRepo, eventBus)
+	shareHandler := api.NewShareHandler(noteService, service.NewShareService(shareRepo, noteService, userRepo, cfg.JWTSecret), auditService)
+	usageService := service.NewUsageService(repository.NewAIUsageRepository(db), cfg.DailyTokenQuota, cfg.AIPricing)
+	usageHandler := api.NewUsageHandler(usageService)
+	catalog, err := service.NewModelCatalog(service.ModelCatalogOptions{
+		Models:            cfg.AIModels,
+		DefaultModel:      cfg.AIDefaultModel,
+		Temperature:       cfg.AITemperature,
+		MaxOutputTokens:   cfg.AIMaxOutputTokens,
+		SystemInstruction: cfg.AISystemInstruction,
+		SafetyThreshold:   cfg.AISafetyThreshold,
+	})
+	if err != nil {
+		log.Fatalf("invalid AI model configuration: %v", err)
+	}
+	modelHandler := api.NewModelHandler(catalog)
+	geminiService, err := service.NewGeminiService(usageService, catalog, service.GeminiOptions{
+		Timeout:          cfg.AITimeout,
+		MaxRetries:       cfg.AIMaxRetries,
+		BreakerThreshold: cfg.AIBreakerThreshold,
+		BreakerCooldown:  cfg.AIBreakerCooldown,
+	})
 	if err != nil {
 		log.Fatalf("could not initialize Gemini service: %v", err)
 	}
-	noteHandler := api.NewNoteHandler(noteService, geminiService)
+
+	// 백그라운드 작업 큐
+	jobService := service.NewJobService(repository.NewJobRepository(db))
+
+	// 프롬프트 템플릿 (기본 템플릿 생성)
+	promptService := service.NewPromptService(repository.NewPromptTemplateRepository(db))
+	if err := promptService.SeedDefaults(); err != nil {
+		log.Fatalf("could not seed prompt templates: %v", err)
+	}
+	promptHandler := api.NewPromptHandler(promptService, auditService)
+
+	translationService := service.NewTranslationService(noteService, promptService, geminiService)
+	translationHandler := api.NewTranslationHandler(noteService, translationService, jobService)
+	taskHandler := api.NewTaskHandler(service.NewTaskService(taskRepo, noteService, promptService, geminiService), auditService)
+	noteHandler := api.NewNoteHandler(noteService, geminiService, jobService, promptService, translationService, auditService, cfg.AllowClientTimestamps, cfg.RequireIfMatch)
+	assistHandler := api.NewAssistHandler(noteService, service.NewAssistService(noteService, promptService, geminiService), auditService, cfg.RequireIfMatch)
+	jobHandler := api.NewJobHandler(jobService)
+
+	// 첨부 이미지 업로드 및 텍스트 추출
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	extractor := service.NewGeminiTextExtractor(geminiService)
+	attachmentService := service.NewAttachmentService(attachmentRepo, repo, extractor, jobService)
+	attachmentHandler := api.NewAttachmentHandler(attachmentService, cfg.MaxUploadBytes)
+	duplicateHandler := api.NewDuplicateHandler(service.NewDuplicateService(db, noteService, attachmentRepo), auditService)
+	batchHandler := api.NewBatchHandler(service.NewBatchService(db, noteService, workspaceService), auditService, cfg.RequireIfMatch)
+	eventHandler := api.NewEventHandler(eventBus, authService)
+
+	// 웹훅 (노트 이벤트를 작업 큐로 전송)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(db), eventBus, noteService, jobService, repository.NewWorkspaceRepository(db), cfg.WebhookTimeout, cfg.WebhookAllowedHosts)
+	webhookHandler := api.NewWebhookHandler(webhookService, auditService)
+	syncHandler := api.NewSyncHandler(service.NewSyncService(db, noteService, repository.NewSyncClientIDRepository(db)), auditService)
+
+	// 작업 워커 시작
+	service.RegisterJobHandlers(jobService, noteService, geminiService, promptService, attachmentService, translationService, webhookService)
+	if err := jobService.Start(context.Background(), cfg.JobWorkers); err != nil {
+		log.Fatalf("could not start job workers: %v", err)
+	}
+	if err := webhookService.Start(context.Background()); err != nil {
+		log.Fatalf("could not start webhook dispatcher: %v", err)
+	}
+	// 보관 기간이 지난 노트 이벤트 삭제
+	service.NewEventRetention(db, webhookService.Repo, cfg.NoteEventRetention).Start(context.Background())
 
 	// 라우팅 설정
-	api.RegisterRoutes(e, noteHandler)
+	spec := api.RegisterRoutes(e, noteHandler, attachmentHandler, jobHandler, promptHandler, usageHandler, modelHandler, assistHandler, translationHandler, duplicateHandler, taskHandler, authHandler, shareHandler, apiTokenHandler, workspaceHandler, auditHandler, batchHandler, eventHandler, webhookHandler, syncHandler, api.AuthMiddleware(authService, apiTokenService), api.StreamAuthMiddleware(authService, apiTokenService), api.WorkspaceMiddleware(workspaceService), api.RequireAdmin(authService), cfg.LegacyAPISunset)
+	// 명세에 없는 라우트가 있거나 명세에만 있는 라우트가 있으면 시작하지 않음
+	if err := api.CheckOpenAPI(e.Routes(), spec); err != nil {
+		log.Fatalf("OpenAPI spec does not match the registered routes: %v", err)
+	}
 
 	// 이미지 핸들러
 	e.Static("/uploads", "myapp/uploads/.cache")
@@ -59,24 +174,31 @@ func main() {
 	e.Logger.Fatal(e.Start(":8080"))
 }
 
-// 데이터베이스 스키마 초기화 함수
-func initializeSchema(db *sql.DB) error {
-	// 노트 테이블 생성 쿼리
-	createTableQuery := `
-    CREATE TABLE IF NOT EXISTS notes (
-        id INTEGER PRIMARY KEY AUTOINCREMENT,
-        title TEXT,
-        content TEXT NOT NULL,
-        img TEXT,
-        created_time DATETIME NOT NULL,
-        updated_time DATETIME
-    );
-    `
-
-	// 테이블 생성 쿼리 실행
-	_, err := db.Exec(createTableQuery)
-	if err != nil {
-		return err
+// runCommand 함수 정의 (운영자 명령: 관리자 지정, 해제, 소유자 없는 노트 이전)
+func runCommand(authService *service.AuthService, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: myapp grant-admin|revoke-admin|claim-orphan-notes <email>")
+	}
+	email := args[1]
+	switch args[0] {
+	case "grant-admin":
+		if err := authService.SetAdmin(email, true); err != nil {
+			return err
+		}
+		log.Printf("%s is now an admin", email)
+	case "revoke-admin":
+		if err := authService.SetAdmin(email, false); err != nil {
+			return err
+		}
+		log.Printf("%s is no longer an admin", email)
+	case "claim-orphan-notes":
+		claimed, err := authService.ClaimOrphanedNotes(email)
+		if err != nil {
+			return err
+		}
+		log.Printf("assigned %d notes without an owner to %s", claimed, email)
+	default:
+		return fmt.Errorf("unknown command %q (usage: myapp grant-admin|revoke-admin|claim-orphan-notes <email>)", args[0])
 	}
 	return nil
 }
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// AIUsage 구조체 정의 (AI 호출 1회에 대한 사용량 기록)
+type AIUsage struct {
+	ID              int       `json:"id"`
+	Operation       string    `json:"operation"`
+	Model           string    `json:"model"`
+	UserID          int       `json:"user_id"`
+	WorkspaceID     int       `json:"workspace_id"`
+	NoteID          *int      `json:"note_id"`
+	PromptTokens    int       `json:"prompt_tokens"`
+	CandidateTokens int       `json:"candidate_tokens"`
+	TotalTokens     int       `json:"total_tokens"`
+	LatencyMs       int64     `json:"latency_ms"`
+	Success         bool      `json:"success"`
+	Error           string    `json:"error"`
+	CreatedTime     time.Time `json:"created_time"`
+}
+
+// AIUsageSummary 구조체 정의 (기간/사용자/노트/모델별 집계, 비용은 가격이 설정된 모델만 포함한 추정치)
+type AIUsageSummary struct {
+	Key              string  `json:"key"`
+	Model            string  `json:"-"`
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CandidateTokens  int     `json:"candidate_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	AvgLatencyMs     int64   `json:"avg_latency_ms"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// ModelPrice 구조체 정의 (토큰 100만 개당 USD 가격)
+type ModelPrice struct {
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+}
+
+// Cost 함수 정의
+func (p ModelPrice) Cost(promptTokens, candidateTokens int) float64 {
+	return (float64(promptTokens)*p.InputPerMillion + float64(candidateTokens)*p.OutputPerMillion) / 1_000_000
+}
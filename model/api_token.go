@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// APIToken 구조체 정의 (스크립트, 외부 연동용 개인 토큰, 원문 대신 해시만 저장)
+type APIToken struct {
+	ID           int        `json:"id"`
+	UserID       int        `json:"user_id"`
+	Name         string     `json:"name"`
+	Prefix       string     `json:"prefix"`
+	TokenHash    string     `json:"-"`
+	Scopes       []string   `json:"scopes"`
+	ExpiresTime  *time.Time `json:"expires_time"`
+	LastUsedTime *time.Time `json:"last_used_time"`
+	CreatedTime  time.Time  `json:"created_time"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Attachment 구조체 정의 (노트에 업로드된 이미지)
+type Attachment struct {
+	ID            int       `json:"id"`
+	NoteID        int       `json:"note_id"`
+	Filename      string    `json:"filename"`
+	Path          string    `json:"path"`
+	MimeType      string    `json:"mime_type"`
+	ExtractedText string    `json:"extracted_text"`
+	ThumbnailPath string    `json:"thumbnail_path"`
+	CreatedTime   time.Time `json:"created_time"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// AuditEntry 구조체 정의 (추가만 가능한 감사 기록 항목)
+type AuditEntry struct {
+	ID          int       `json:"id"`
+	ActorID     int       `json:"actor_id"`
+	Action      string    `json:"action"`
+	TargetType  string    `json:"target_type"`
+	TargetID    int       `json:"target_id"`
+	WorkspaceID int       `json:"workspace_id"`
+	BeforeHash  string    `json:"before_hash"`
+	AfterHash   string    `json:"after_hash"`
+	Details     string    `json:"details"`
+	IP          string    `json:"ip"`
+	RequestID   string    `json:"request_id"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// AuditFilter 구조체 정의 (0 또는 빈 값인 조건은 무시, Limit이 0이면 전체)
+type AuditFilter struct {
+	ActorID     int
+	Action      string
+	TargetType  string
+	TargetID    int
+	WorkspaceID int
+	Since       *time.Time
+	Until       *time.Time
+	Limit       int
+	Offset      int
+}
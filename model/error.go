@@ -0,0 +1,88 @@
+package model
+
+// ErrorCode 클라이언트가 분기할 수 있는 오류 코드
+type ErrorCode string
+
+// 오류 코드 (HTTP 상태 코드는 api 패키지에서 결정)
+const (
+	ErrCodeValidation          ErrorCode = "validation_failed"
+	ErrCodeUnauthorized        ErrorCode = "unauthorized"
+	ErrCodeForbidden           ErrorCode = "forbidden"
+	ErrCodeNotFound            ErrorCode = "not_found"
+	ErrCodeConflict            ErrorCode = "conflict"
+	ErrCodePreconditionFailed  ErrorCode = "precondition_failed"
+	ErrCodePreconditionNeeded  ErrorCode = "precondition_required"
+	ErrCodeGone                ErrorCode = "gone"
+	ErrCodeUnsupportedMedia    ErrorCode = "unsupported_media_type"
+	ErrCodePayloadTooLarge     ErrorCode = "payload_too_large"
+	ErrCodeRateLimited         ErrorCode = "rate_limited"
+	ErrCodeUpstream            ErrorCode = "upstream_error"
+	ErrCodeUpstreamUnavailable ErrorCode = "upstream_unavailable"
+	ErrCodeUpstreamTimeout     ErrorCode = "upstream_timeout"
+	ErrCodeInternal            ErrorCode = "internal_error"
+)
+
+// Error 구조체 정의 (Message는 클라이언트에게 그대로 보여줄 수 있는 문장, Err는 로그용 원본 오류)
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func (e *Error) Error() string { return e.Message }
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is 함수 정의 (Wrap, WithDetails로 만든 복사본도 원래 오류와 같은 것으로 취급)
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// NewError 함수 정의
+func NewError(code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap 함수 정의 (같은 코드와 메시지로 원본 오류를 감싼 복사본)
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithDetails 함수 정의 (상세 정보를 붙인 복사본)
+func (e *Error) WithDetails(details interface{}) *Error {
+	withDetails := *e
+	withDetails.Details = details
+	return &withDetails
+}
+
+// NotFound 함수 정의
+func NotFound(message string) *Error { return NewError(ErrCodeNotFound, message) }
+
+// Validation 함수 정의
+func Validation(message string) *Error { return NewError(ErrCodeValidation, message) }
+
+// Conflict 함수 정의
+func Conflict(message string) *Error { return NewError(ErrCodeConflict, message) }
+
+// Forbidden 함수 정의
+func Forbidden(message string) *Error { return NewError(ErrCodeForbidden, message) }
+
+// Unauthorized 함수 정의
+func Unauthorized(message string) *Error { return NewError(ErrCodeUnauthorized, message) }
+
+// Upstream 함수 정의
+func Upstream(message string) *Error { return NewError(ErrCodeUpstream, message) }
+
+// FieldError 구조체 정의 (입력 검증 실패 항목, 검증 오류의 Details로 전달)
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// InvalidFields 함수 정의 (항목별 검증 오류)
+func InvalidFields(fields []FieldError) *Error {
+	return Validation("request validation failed").WithDetails(fields)
+}
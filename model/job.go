@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// 작업 상태 정의
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job 구조체 정의 (백그라운드 작업 큐 항목)
+type Job struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	WorkspaceID int        `json:"workspace_id"`
+	Type        string     `json:"type"`
+	Payload     string     `json:"payload"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	RunAt       time.Time  `json:"run_at"`
+	LastError   string     `json:"last_error"`
+	Result      string     `json:"result"`
+	CreatedTime time.Time  `json:"created_time"`
+	UpdatedTime *time.Time `json:"updated_time"`
+}
+
+// Scope 함수 정의 (작업을 요청한 사용자와 워크스페이스 범위)
+func (j *Job) Scope() Scope {
+	return Scope{UserID: j.UserID, WorkspaceID: j.WorkspaceID}
+}
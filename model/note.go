@@ -5,9 +5,13 @@ import "time"
 // Note 구조체 정의
 type Note struct {
 	ID          int        `json:"id"`
+	OwnerID     int        `json:"owner_id"`
+	WorkspaceID int        `json:"workspace_id"`
 	Img         string     `json:"img"`
 	Title       string     `json:"title"`
 	Content     string     `json:"content"`
 	CreatedTime time.Time  `json:"created_time"`
 	UpdatedTime *time.Time `json:"updated_time"`
+	// 수정할 때마다 1씩 증가 (ETag, If-Match에 사용)
+	Version int `json:"version"`
 }
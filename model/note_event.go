@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// 노트 변경 이벤트 종류
+const (
+	NoteEventCreated = "note.created"
+	NoteEventUpdated = "note.updated"
+	NoteEventDeleted = "note.deleted"
+	NoteEventMoved   = "note.moved"
+)
+
+// NoteEventTypes 구독할 수 있는 이벤트 종류
+var NoteEventTypes = []string{NoteEventCreated, NoteEventUpdated, NoteEventDeleted, NoteEventMoved}
+
+// NoteEvent 구조체 정의 (노트 변경 기록, ID는 순서대로 증가하며 다시 연결할 때 이어받는 기준)
+type NoteEvent struct {
+	ID          int64  `json:"id"`
+	Type        string `json:"type"`
+	NoteID      int    `json:"note_id"`
+	OwnerID     int    `json:"owner_id"`
+	WorkspaceID int    `json:"workspace_id"`
+	// 이동 이벤트의 이전 위치 (다른 이벤트는 0)
+	FromOwnerID     int       `json:"from_owner_id,omitempty"`
+	FromWorkspaceID int       `json:"from_workspace_id,omitempty"`
+	Version         int       `json:"version"`
+	CreatedTime     time.Time `json:"created_time"`
+}
+
+// NoteEventFilter 구조체 정의 (빈 값인 조건은 무시, AfterID보다 큰 ID의 이벤트만)
+type NoteEventFilter struct {
+	AfterID int64
+	Types   []string
+	NoteID  int
+	Limit   int
+}
+
+// inScope 함수 정의 (개인 노트는 소유자 기준, 워크스페이스 노트는 워크스페이스 기준)
+func inScope(scope Scope, ownerID, workspaceID int) bool {
+	if scope.WorkspaceID != 0 {
+		return workspaceID == scope.WorkspaceID
+	}
+	return workspaceID == 0 && ownerID == scope.UserID
+}
+
+// VisibleTo 함수 정의 (scope 안의 노트이거나 scope 밖으로 옮겨진 노트의 이벤트인지)
+func (e *NoteEvent) VisibleTo(scope Scope) bool {
+	if inScope(scope, e.OwnerID, e.WorkspaceID) {
+		return true
+	}
+	return e.Type == NoteEventMoved && inScope(scope, e.FromOwnerID, e.FromWorkspaceID)
+}
+
+// Matches 함수 정의 (AfterID와 Limit을 제외한 조건 확인)
+func (f NoteEventFilter) Matches(e *NoteEvent) bool {
+	if f.NoteID != 0 && e.NoteID != f.NoteID {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// NoteRevision 구조체 정의 (노트 저장 시점별 스냅샷)
+type NoteRevision struct {
+	ID          int       `json:"id"`
+	NoteID      int       `json:"note_id"`
+	Revision    int       `json:"revision"`
+	Img         string    `json:"img"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	CreatedTime time.Time `json:"created_time"`
+}
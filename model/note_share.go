@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// 노트 접근 역할
+const (
+	ShareRoleOwner  = "owner"
+	ShareRoleEditor = "editor"
+	ShareRoleViewer = "viewer"
+)
+
+// NoteShare 구조체 정의 (다른 사용자에게 공유한 노트)
+type NoteShare struct {
+	ID          int       `json:"id"`
+	NoteID      int       `json:"note_id"`
+	UserID      int       `json:"user_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// ShareLink 구조체 정의 (로그인 없이 읽을 수 있는 공개 링크, 토큰과 비밀번호는 해시만 저장)
+type ShareLink struct {
+	ID           int        `json:"id"`
+	NoteID       int        `json:"note_id"`
+	UserID       int        `json:"user_id"`
+	Prefix       string     `json:"prefix"`
+	TokenHash    string     `json:"-"`
+	PasswordHash string     `json:"-"`
+	ExpiresTime  *time.Time `json:"expires_time"`
+	RevokedTime  *time.Time `json:"revoked_time"`
+	CreatedTime  time.Time  `json:"created_time"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// NoteTranslation 구조체 정의 (노트의 언어별 번역본)
+type NoteTranslation struct {
+	ID             int        `json:"id"`
+	NoteID         int        `json:"note_id"`
+	Language       string     `json:"language"`
+	Title          string     `json:"title"`
+	Content        string     `json:"content"`
+	SourceRevision int        `json:"source_revision"`
+	Stale          bool       `json:"stale"`
+	CreatedTime    time.Time  `json:"created_time"`
+	UpdatedTime    *time.Time `json:"updated_time"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// PromptTemplate 구조체 정의 (노트 분석용 프롬프트 템플릿)
+type PromptTemplate struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Template    string     `json:"template"`
+	CreatedTime time.Time  `json:"created_time"`
+	UpdatedTime *time.Time `json:"updated_time"`
+}
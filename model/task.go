@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// 할 일 출처
+const (
+	TaskSourceCheckbox = "checkbox"
+	TaskSourceAI       = "ai"
+)
+
+// Task 구조체 정의 (노트에서 추출한 할 일)
+type Task struct {
+	ID          int        `json:"id"`
+	NoteID      int        `json:"note_id"`
+	Text        string     `json:"text"`
+	Done        bool       `json:"done"`
+	DueDate     *time.Time `json:"due_date"`
+	Source      string     `json:"source"`
+	SpanStart   int        `json:"span_start"`
+	SpanEnd     int        `json:"span_end"`
+	CreatedTime time.Time  `json:"created_time"`
+	UpdatedTime *time.Time `json:"updated_time"`
+}
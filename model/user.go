@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// User 구조체 정의
+type User struct {
+	ID           int       `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	IsAdmin      bool      `json:"is_admin"`
+	CreatedTime  time.Time `json:"created_time"`
+}
+
+// RefreshToken 구조체 정의 (토큰 원문 대신 해시만 저장)
+type RefreshToken struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	TokenHash   string     `json:"-"`
+	ExpiresTime time.Time  `json:"expires_time"`
+	RevokedTime *time.Time `json:"revoked_time"`
+	CreatedTime time.Time  `json:"created_time"`
+}
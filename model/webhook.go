@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// 웹훅 전송 상태 정의
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookEventPing 테스트 전송 이벤트 종류
+const WebhookEventPing = "ping"
+
+// Webhook 구조체 정의 (WorkspaceID가 0이면 UserID의 개인 노트 이벤트, 아니면 워크스페이스 노트 이벤트 구독)
+type Webhook struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	WorkspaceID int        `json:"workspace_id"`
+	URL         string     `json:"url"`
+	Secret      string     `json:"-"`
+	EventTypes  []string   `json:"event_types"`
+	Active      bool       `json:"active"`
+	CreatedTime time.Time  `json:"created_time"`
+	UpdatedTime *time.Time `json:"updated_time"`
+}
+
+// Scope 함수 정의 (웹훅이 구독하는 노트 범위)
+func (w *Webhook) Scope() Scope {
+	return Scope{UserID: w.UserID, WorkspaceID: w.WorkspaceID}
+}
+
+// Subscribes 함수 정의 (이벤트가 웹훅의 범위와 이벤트 종류에 맞는지)
+func (w *Webhook) Subscribes(event *NoteEvent) bool {
+	return event.VisibleTo(w.Scope()) && NoteEventFilter{Types: w.EventTypes}.Matches(event)
+}
+
+// WebhookDelivery 구조체 정의 (웹훅 전송 기록, EventID는 ping이면 0, ReplayOf는 다시 보낸 원래 기록 ID)
+type WebhookDelivery struct {
+	ID             int        `json:"id"`
+	WebhookID      int        `json:"webhook_id"`
+	EventID        int64      `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	LastError      string     `json:"last_error"`
+	JobID          int        `json:"job_id"`
+	ReplayOf       int        `json:"replay_of"`
+	CreatedTime    time.Time  `json:"created_time"`
+	UpdatedTime    *time.Time `json:"updated_time"`
+}
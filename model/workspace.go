@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// 워크스페이스 역할 (뒤로 갈수록 권한이 적음)
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleGuest  = "guest"
+)
+
+var workspaceRoleRanks = map[string]int{
+	WorkspaceRoleGuest:  1,
+	WorkspaceRoleMember: 2,
+	WorkspaceRoleAdmin:  3,
+	WorkspaceRoleOwner:  4,
+}
+
+// WorkspaceRoleAtLeast 함수 정의 (role이 min 이상의 권한인지 확인, 알 수 없는 역할은 false)
+func WorkspaceRoleAtLeast(role, min string) bool {
+	rank, ok := workspaceRoleRanks[role]
+	return ok && rank >= workspaceRoleRanks[min]
+}
+
+// IsWorkspaceRole 함수 정의
+func IsWorkspaceRole(role string) bool {
+	_, ok := workspaceRoleRanks[role]
+	return ok
+}
+
+// Scope 구조체 정의 (노트 조회 범위, WorkspaceID가 0이면 UserID의 개인 노트)
+type Scope struct {
+	UserID      int
+	WorkspaceID int
+}
+
+// Workspace 구조체 정의 (Role은 조회한 사용자의 역할)
+type Workspace struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// WorkspaceMember 구조체 정의
+type WorkspaceMember struct {
+	WorkspaceID int       `json:"workspace_id"`
+	UserID      int       `json:"user_id"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// WorkspaceInvitation 구조체 정의 (초대 토큰은 해시만 저장)
+type WorkspaceInvitation struct {
+	ID           int        `json:"id"`
+	WorkspaceID  int        `json:"workspace_id"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	Prefix       string     `json:"prefix"`
+	TokenHash    string     `json:"-"`
+	InvitedBy    int        `json:"invited_by"`
+	ExpiresTime  time.Time  `json:"expires_time"`
+	AcceptedTime *time.Time `json:"accepted_time"`
+	CreatedTime  time.Time  `json:"created_time"`
+}
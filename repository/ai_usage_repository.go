@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"myapp/model"
+	"time"
+)
+
+// AIUsageRepository 구조체 정의
+type AIUsageRepository struct {
+	DB *sql.DB
+}
+
+// NewAIUsageRepository 함수 정의
+func NewAIUsageRepository(db *sql.DB) *AIUsageRepository {
+	return &AIUsageRepository{DB: db}
+}
+
+// 집계 기준별 GROUP BY 식
+var usageGroupColumns = map[string]string{
+	"day":   "substr(created_time, 1, 10)",
+	"user":  "CAST(user_id AS TEXT)",
+	"note":  "COALESCE(CAST(note_id AS TEXT), '')",
+	"model": "model",
+}
+
+// Create 함수 정의
+func (r *AIUsageRepository) Create(usage *model.AIUsage) (int, error) {
+	result, err := r.DB.Exec(`INSERT INTO ai_usage (operation, model, user_id, workspace_id, note_id, prompt_tokens, candidate_tokens, total_tokens, latency_ms, success, error, created_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		usage.Operation, usage.Model, usage.UserID, usage.WorkspaceID, usage.NoteID, usage.PromptTokens, usage.CandidateTokens, usage.TotalTokens, usage.LatencyMs, usage.Success, usage.Error, usage.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// SumTokensSince 함수 정의 (since 이후 사용한 토큰 합계, userID가 0이면 전체)
+func (r *AIUsageRepository) SumTokensSince(userID int, since time.Time) (int, error) {
+	query := "SELECT COALESCE(SUM(total_tokens), 0) FROM ai_usage WHERE created_time >= ?"
+	args := []interface{}{since}
+	if userID != 0 {
+		query += " AND user_id = ?"
+		args = append(args, userID)
+	}
+	var total int
+	err := r.DB.QueryRow(query, args...).Scan(&total)
+	return total, err
+}
+
+// Summarize 함수 정의 (groupBy: day, user, note, model, 같은 기준 안에서도 모델별로 나눠 반환, scope가 nil이면 전체)
+// 워크스페이스 scope는 그 워크스페이스에서 한 호출, 개인 scope는 사용자가 한 모든 호출
+func (r *AIUsageRepository) Summarize(scope *model.Scope, groupBy string, from, to time.Time) ([]*model.AIUsageSummary, error) {
+	column, ok := usageGroupColumns[groupBy]
+	if !ok {
+		return nil, model.Validation(fmt.Sprintf("invalid group_by %q", groupBy))
+	}
+
+	conditions := "created_time >= ? AND created_time < ?"
+	args := []interface{}{from, to}
+	switch {
+	case scope == nil:
+	case scope.WorkspaceID != 0:
+		conditions += " AND workspace_id = ?"
+		args = append(args, scope.WorkspaceID)
+	default:
+		conditions += " AND user_id = ?"
+		args = append(args, scope.UserID)
+	}
+
+	rows, err := r.DB.Query(fmt.Sprintf(`
+        SELECT %s AS key, model, COUNT(*), SUM(prompt_tokens), SUM(candidate_tokens), SUM(total_tokens), CAST(AVG(latency_ms) AS INTEGER)
+        FROM ai_usage
+        WHERE %s
+        GROUP BY key, model
+        ORDER BY key, model`, column, conditions), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*model.AIUsageSummary
+	for rows.Next() {
+		summary := &model.AIUsageSummary{}
+		err := rows.Scan(&summary.Key, &summary.Model, &summary.Calls, &summary.PromptTokens, &summary.CandidateTokens, &summary.TotalTokens, &summary.AvgLatencyMs)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"strings"
+	"time"
+)
+
+// APITokenRepository 구조체 정의
+type APITokenRepository struct {
+	DB *sql.DB
+}
+
+// NewAPITokenRepository 함수 정의
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{DB: db}
+}
+
+const apiTokenColumns = "id, user_id, name, prefix, token_hash, scopes, expires_time, last_used_time, created_time"
+
+// 권한 목록은 쉼표로 구분해 저장
+func scanAPIToken(row interface{ Scan(...interface{}) error }) (*model.APIToken, error) {
+	token := &model.APIToken{}
+	var scopes string
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.Prefix, &token.TokenHash, &scopes, &token.ExpiresTime, &token.LastUsedTime, &token.CreatedTime)
+	if err != nil {
+		return nil, err
+	}
+	token.Scopes = []string{}
+	if scopes != "" {
+		token.Scopes = strings.Split(scopes, ",")
+	}
+	return token, nil
+}
+
+// Create 함수 정의
+func (r *APITokenRepository) Create(token *model.APIToken) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO api_tokens (user_id, name, prefix, token_hash, scopes, expires_time, created_time) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		token.UserID, token.Name, token.Prefix, token.TokenHash, strings.Join(token.Scopes, ","), token.ExpiresTime, token.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByHash 함수 정의
+func (r *APITokenRepository) GetByHash(tokenHash string) (*model.APIToken, error) {
+	row := r.DB.QueryRow("SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = ?", tokenHash)
+	return scanAPIToken(row)
+}
+
+// GetByUserID 함수 정의 (최근 생성 순)
+func (r *APITokenRepository) GetByUserID(userID int) ([]*model.APIToken, error) {
+	rows, err := r.DB.Query("SELECT "+apiTokenColumns+" FROM api_tokens WHERE user_id = ? ORDER BY id DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []*model.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// Delete 함수 정의 (다른 사용자의 토큰이면 false)
+func (r *APITokenRepository) Delete(userID, id int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM api_tokens WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// TouchLastUsed 함수 정의
+func (r *APITokenRepository) TouchLastUsed(id int, now time.Time) error {
+	_, err := r.DB.Exec("UPDATE api_tokens SET last_used_time = ? WHERE id = ?", now, id)
+	return err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+)
+
+// AttachmentRepository 구조체 정의
+type AttachmentRepository struct {
+	DB DBTX
+}
+
+// NewAttachmentRepository 함수 정의
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{DB: db}
+}
+
+// Create 함수 정의
+func (r *AttachmentRepository) Create(attachment *model.Attachment) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO attachments (note_id, filename, path, mime_type, extracted_text, created_time) VALUES (?, ?, ?, ?, ?, ?)",
+		attachment.NoteID, attachment.Filename, attachment.Path, attachment.MimeType, attachment.ExtractedText, attachment.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의
+func (r *AttachmentRepository) GetByID(id int) (*model.Attachment, error) {
+	row := r.DB.QueryRow("SELECT id, note_id, filename, path, mime_type, extracted_text, thumbnail_path, created_time FROM attachments WHERE id = ?", id)
+	attachment := &model.Attachment{}
+	err := row.Scan(&attachment.ID, &attachment.NoteID, &attachment.Filename, &attachment.Path, &attachment.MimeType, &attachment.ExtractedText, &attachment.ThumbnailPath, &attachment.CreatedTime)
+	if err != nil {
+		return nil, notFound(err, "attachment")
+	}
+	return attachment, nil
+}
+
+// GetByNoteID 함수 정의
+func (r *AttachmentRepository) GetByNoteID(noteID int) ([]*model.Attachment, error) {
+	rows, err := r.DB.Query("SELECT id, note_id, filename, path, mime_type, extracted_text, thumbnail_path, created_time FROM attachments WHERE note_id = ? ORDER BY id", noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*model.Attachment
+	for rows.Next() {
+		attachment := &model.Attachment{}
+		err := rows.Scan(&attachment.ID, &attachment.NoteID, &attachment.Filename, &attachment.Path, &attachment.MimeType, &attachment.ExtractedText, &attachment.ThumbnailPath, &attachment.CreatedTime)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// UpdateExtractedText 함수 정의
+func (r *AttachmentRepository) UpdateExtractedText(id int, text string) error {
+	_, err := r.DB.Exec("UPDATE attachments SET extracted_text = ? WHERE id = ?", text, id)
+	return err
+}
+
+// UpdateThumbnailPath 함수 정의
+func (r *AttachmentRepository) UpdateThumbnailPath(id int, path string) error {
+	_, err := r.DB.Exec("UPDATE attachments SET thumbnail_path = ? WHERE id = ?", path, id)
+	return err
+}
+
+// Delete 함수 정의
+func (r *AttachmentRepository) Delete(id int) error {
+	_, err := r.DB.Exec("DELETE FROM attachments WHERE id = ?", id)
+	return err
+}
+
+// MoveToNote 함수 정의 (노트 병합 시 첨부를 다른 노트로 이동)
+func (r *AttachmentRepository) MoveToNote(fromNoteID, toNoteID int) error {
+	_, err := r.DB.Exec("UPDATE attachments SET note_id = ? WHERE note_id = ?", toNoteID, fromNoteID)
+	return err
+}
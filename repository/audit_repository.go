@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"myapp/model"
+	"strings"
+)
+
+// AuditRepository 구조체 정의 (감사 기록은 추가와 조회만 제공)
+type AuditRepository struct {
+	DB *sql.DB
+}
+
+// NewAuditRepository 함수 정의
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{DB: db}
+}
+
+// Create 함수 정의
+func (r *AuditRepository) Create(entry *model.AuditEntry) (int, error) {
+	result, err := r.DB.Exec(`
+        INSERT INTO audit_log (actor_id, action, target_type, target_id, workspace_id, before_hash, after_hash, details, ip, request_id, created_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ActorID, entry.Action, entry.TargetType, entry.TargetID, entry.WorkspaceID, entry.BeforeHash, entry.AfterHash, entry.Details, entry.IP, entry.RequestID, entry.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// Query 함수 정의 (최근 기록부터)
+func (r *AuditRepository) Query(filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.ActorID != 0 {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.TargetID != 0 {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.WorkspaceID != 0 {
+		conditions = append(conditions, "workspace_id = ?")
+		args = append(args, filter.WorkspaceID)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_time >= ?")
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_time < ?")
+		args = append(args, *filter.Until)
+	}
+
+	query := "SELECT id, actor_id, action, target_type, target_id, workspace_id, before_hash, after_hash, details, ip, request_id, created_time FROM audit_log"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", filter.Limit, filter.Offset)
+	}
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.AuditEntry
+	for rows.Next() {
+		entry := &model.AuditEntry{}
+		err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &entry.TargetType, &entry.TargetID, &entry.WorkspaceID,
+			&entry.BeforeHash, &entry.AfterHash, &entry.Details, &entry.IP, &entry.RequestID, &entry.CreatedTime)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// DBTX *sql.DB와 *sql.Tx 공통 메서드 (트랜잭션 안에서도 같은 저장소 사용)
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Open 함수 정의 (SQLite 파일 열기, 트랜잭션은 시작할 때 쓰기 잠금을 잡으므로 읽은 뒤 쓰는 트랜잭션끼리 교착되지 않고 차례를 기다림)
+func Open(path string) (*sql.DB, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return sql.Open("sqlite3", path+separator+"_txlock=immediate")
+}
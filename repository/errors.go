@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"myapp/model"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// notFound 함수 정의 (조회 결과가 없으면 NotFound 오류로 변환, errors.Is(err, sql.ErrNoRows)는 계속 동작)
+func notFound(err error, resource string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.NotFound(resource + " not found").Wrap(err)
+	}
+	return err
+}
+
+// conflict 함수 정의 (UNIQUE 제약 위반이면 Conflict 오류로 변환)
+func conflict(err error, message string) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return model.Conflict(message).Wrap(err)
+	}
+	return err
+}
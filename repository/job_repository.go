@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"time"
+)
+
+// JobRepository 구조체 정의
+type JobRepository struct {
+	DB *sql.DB
+}
+
+// NewJobRepository 함수 정의
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{DB: db}
+}
+
+const jobColumns = "id, user_id, workspace_id, type, payload, status, attempts, max_attempts, run_at, last_error, result, created_time, updated_time"
+
+func scanJob(row interface{ Scan(...interface{}) error }) (*model.Job, error) {
+	job := &model.Job{}
+	err := row.Scan(&job.ID, &job.UserID, &job.WorkspaceID, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.RunAt, &job.LastError, &job.Result, &job.CreatedTime, &job.UpdatedTime)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Create 함수 정의
+func (r *JobRepository) Create(job *model.Job) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO jobs (user_id, workspace_id, type, payload, status, attempts, max_attempts, run_at, last_error, result, created_time) VALUES (?, ?, ?, ?, ?, 0, ?, ?, '', '', ?)",
+		job.UserID, job.WorkspaceID, job.Type, job.Payload, job.Status, job.MaxAttempts, job.RunAt, job.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의
+func (r *JobRepository) GetByID(id int) (*model.Job, error) {
+	row := r.DB.QueryRow("SELECT "+jobColumns+" FROM jobs WHERE id = ?", id)
+	job, err := scanJob(row)
+	return job, notFound(err, "job")
+}
+
+// ClaimNext 함수 정의 (실행 가능한 작업 하나를 running 상태로 가져옴, 없으면 nil)
+func (r *JobRepository) ClaimNext(now time.Time) (*model.Job, error) {
+	row := r.DB.QueryRow(`
+        UPDATE jobs SET status = ?, attempts = attempts + 1, updated_time = ?
+        WHERE id = (
+            SELECT id FROM jobs WHERE status = ? AND run_at <= ? ORDER BY run_at, id LIMIT 1
+        )
+        RETURNING `+jobColumns, model.JobStatusRunning, now, model.JobStatusPending, now)
+	job, err := scanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return job, err
+}
+
+// Complete 함수 정의
+func (r *JobRepository) Complete(id int, result string, now time.Time) error {
+	_, err := r.DB.Exec("UPDATE jobs SET status = ?, result = ?, last_error = '', updated_time = ? WHERE id = ? AND status = ?",
+		model.JobStatusSucceeded, result, now, id, model.JobStatusRunning)
+	return err
+}
+
+// Retry 함수 정의 (runAt 이후에 다시 실행되도록 pending으로 되돌림)
+func (r *JobRepository) Retry(id int, lastError string, runAt, now time.Time) error {
+	_, err := r.DB.Exec("UPDATE jobs SET status = ?, last_error = ?, run_at = ?, updated_time = ? WHERE id = ? AND status = ?",
+		model.JobStatusPending, lastError, runAt, now, id, model.JobStatusRunning)
+	return err
+}
+
+// Fail 함수 정의 (재시도 불가 작업을 dead 상태로 이동)
+func (r *JobRepository) Fail(id int, lastError string, now time.Time) error {
+	_, err := r.DB.Exec("UPDATE jobs SET status = ?, last_error = ?, updated_time = ? WHERE id = ? AND status = ?",
+		model.JobStatusDead, lastError, now, id, model.JobStatusRunning)
+	return err
+}
+
+// Cancel 함수 정의 (아직 끝나지 않은 작업만 취소, 변경 여부 반환)
+func (r *JobRepository) Cancel(id int, now time.Time) (bool, error) {
+	result, err := r.DB.Exec("UPDATE jobs SET status = ?, updated_time = ? WHERE id = ? AND status IN (?, ?)",
+		model.JobStatusCancelled, now, id, model.JobStatusPending, model.JobStatusRunning)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// RequeueRunning 함수 정의 (서버 재시작 시 중단된 작업을 다시 대기열로)
+func (r *JobRepository) RequeueRunning(now time.Time) error {
+	_, err := r.DB.Exec("UPDATE jobs SET status = ?, run_at = ?, updated_time = ? WHERE status = ?",
+		model.JobStatusPending, now, now, model.JobStatusRunning)
+	return err
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"strings"
+	"time"
+)
+
+// NoteEventRepository 구조체 정의 (이벤트는 추가와 조회, 보관 기간이 지난 이벤트 삭제만 제공)
+type NoteEventRepository struct {
+	DB DBTX
+}
+
+// NewNoteEventRepository 함수 정의
+func NewNoteEventRepository(db *sql.DB) *NoteEventRepository {
+	return &NoteEventRepository{DB: db}
+}
+
+// Create 함수 정의
+func (r *NoteEventRepository) Create(event *model.NoteEvent) (int64, error) {
+	result, err := r.DB.Exec(`
+        INSERT INTO note_events (type, note_id, owner_id, workspace_id, from_owner_id, from_workspace_id, version, created_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.Type, event.NoteID, event.OwnerID, event.WorkspaceID, event.FromOwnerID, event.FromWorkspaceID, event.Version, event.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// eventScopeCondition 함수 정의 (scope 안의 이벤트와 scope 밖으로 옮겨진 노트의 이동 이벤트)
+func eventScopeCondition(scope model.Scope) (string, []interface{}) {
+	if scope.WorkspaceID != 0 {
+		return "(workspace_id = ? OR (type = ? AND from_workspace_id = ?))",
+			[]interface{}{scope.WorkspaceID, model.NoteEventMoved, scope.WorkspaceID}
+	}
+	return "((workspace_id = 0 AND owner_id = ?) OR (type = ? AND from_workspace_id = 0 AND from_owner_id = ?))",
+		[]interface{}{scope.UserID, model.NoteEventMoved, scope.UserID}
+}
+
+// GetAfter 함수 정의 (scope에서 보이는 이벤트를 오래된 것부터)
+func (r *NoteEventRepository) GetAfter(scope model.Scope, filter model.NoteEventFilter) ([]*model.NoteEvent, error) {
+	cond, args := eventScopeCondition(scope)
+	conditions := []string{"id > ?", cond}
+	args = append([]interface{}{filter.AfterID}, args...)
+	if filter.NoteID != 0 {
+		conditions = append(conditions, "note_id = ?")
+		args = append(args, filter.NoteID)
+	}
+	if len(filter.Types) > 0 {
+		conditions = append(conditions, "type IN (?"+strings.Repeat(", ?", len(filter.Types)-1)+")")
+		for _, t := range filter.Types {
+			args = append(args, t)
+		}
+	}
+
+	query := "SELECT " + noteEventColumns + " FROM note_events WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+	return r.queryEvents(query, args...)
+}
+
+// GetAllAfter 함수 정의 (범위와 관계없이 afterID 이후의 이벤트, 웹훅 전달에 사용)
+func (r *NoteEventRepository) GetAllAfter(afterID int64, limit int) ([]*model.NoteEvent, error) {
+	return r.queryEvents("SELECT "+noteEventColumns+" FROM note_events WHERE id > ? ORDER BY id LIMIT ?", afterID, limit)
+}
+
+// LatestID 함수 정의 (마지막 이벤트 ID, 이벤트가 없으면 0)
+func (r *NoteEventRepository) LatestID() (int64, error) {
+	var id int64
+	err := r.DB.QueryRow("SELECT COALESCE(MAX(id), 0) FROM note_events").Scan(&id)
+	return id, err
+}
+
+// DeleteBefore 함수 정의 (cutoff 전에 만들어졌고 ID가 maxID 이하인 이벤트 삭제, 삭제한 마지막 ID 반환, 없으면 0)
+func (r *NoteEventRepository) DeleteBefore(cutoff time.Time, maxID int64) (int64, int64, error) {
+	var lastID int64
+	err := r.DB.QueryRow("SELECT COALESCE(MAX(id), 0) FROM note_events WHERE created_time < ? AND id <= ?", cutoff.UTC(), maxID).Scan(&lastID)
+	if err != nil || lastID == 0 {
+		return 0, 0, err
+	}
+	result, err := r.DB.Exec("DELETE FROM note_events WHERE id <= ?", lastID)
+	if err != nil {
+		return 0, 0, err
+	}
+	deleted, err := result.RowsAffected()
+	return lastID, deleted, err
+}
+
+// PrunedThrough 함수 정의 (삭제한 마지막 이벤트 ID, 삭제한 적이 없으면 0)
+func (r *NoteEventRepository) PrunedThrough() (int64, error) {
+	var id int64
+	err := r.DB.QueryRow("SELECT pruned_through FROM note_event_retention WHERE id = 1").Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+// SetPrunedThrough 함수 정의
+func (r *NoteEventRepository) SetPrunedThrough(eventID int64, now time.Time) error {
+	_, err := r.DB.Exec(`
+        INSERT INTO note_event_retention (id, pruned_through, updated_time) VALUES (1, ?, ?)
+        ON CONFLICT (id) DO UPDATE SET pruned_through = MAX(pruned_through, excluded.pruned_through), updated_time = excluded.updated_time`, eventID, now)
+	return err
+}
+
+const noteEventColumns = "id, type, note_id, owner_id, workspace_id, from_owner_id, from_workspace_id, version, created_time"
+
+func (r *NoteEventRepository) queryEvents(query string, args ...interface{}) ([]*model.NoteEvent, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*model.NoteEvent
+	for rows.Next() {
+		event := &model.NoteEvent{}
+		err := rows.Scan(&event.ID, &event.Type, &event.NoteID, &event.OwnerID, &event.WorkspaceID,
+			&event.FromOwnerID, &event.FromWorkspaceID, &event.Version, &event.CreatedTime)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
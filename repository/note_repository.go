@@ -3,11 +3,12 @@ package repository
 import (
 	"database/sql"
 	"myapp/model"
+	"time"
 )
 
 // NoteRepository 구조체 정의
 type NoteRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 // NewNoteRepository 함수 정의
@@ -15,9 +16,41 @@ func NewNoteRepository(db *sql.DB) *NoteRepository {
 	return &NoteRepository{DB: db}
 }
 
+// scopeCondition 함수 정의 (개인 노트는 소유자 기준, 워크스페이스 노트는 워크스페이스 기준 조건)
+func scopeCondition(alias string, scope model.Scope) (string, []interface{}) {
+	if scope.WorkspaceID != 0 {
+		return alias + "workspace_id = ?", []interface{}{scope.WorkspaceID}
+	}
+	return alias + "owner_id = ? AND " + alias + "workspace_id IS NULL", []interface{}{scope.UserID}
+}
+
+// CountOrphans 함수 정의 (소유자가 없는 노트 수)
+func (r *NoteRepository) CountOrphans() (int, error) {
+	var count int
+	err := r.DB.QueryRow("SELECT COUNT(*) FROM notes WHERE owner_id IS NULL AND workspace_id IS NULL").Scan(&count)
+	return count, err
+}
+
+// ClaimOrphans 함수 정의 (사용자 계정 도입 전에 만들어져 소유자가 없는 노트를 ownerID의 개인 노트로 지정)
+func (r *NoteRepository) ClaimOrphans(ownerID int) (int64, error) {
+	result, err := r.DB.Exec("UPDATE notes SET owner_id = ? WHERE owner_id IS NULL AND workspace_id IS NULL", ownerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// nullableWorkspaceID 함수 정의 (개인 노트는 NULL로 저장)
+func nullableWorkspaceID(workspaceID int) interface{} {
+	if workspaceID == 0 {
+		return nil
+	}
+	return workspaceID
+}
+
 // Create 함수 정의
 func (r *NoteRepository) Create(note *model.Note) (int, error) {
-	result, err := r.DB.Exec("INSERT INTO notes (img, title, content, created_time, updated_time) VALUES (?, ?, ?, ?, ?)", note.Img, note.Title, note.Content, note.CreatedTime, note.UpdatedTime)
+	result, err := r.DB.Exec("INSERT INTO notes (owner_id, workspace_id, img, title, content, created_time, updated_time) VALUES (?, ?, ?, ?, ?, ?, ?)", note.OwnerID, nullableWorkspaceID(note.WorkspaceID), note.Img, note.Title, note.Content, note.CreatedTime, note.UpdatedTime)
 	if err != nil {
 		return 0, err
 	}
@@ -28,46 +61,129 @@ func (r *NoteRepository) Create(note *model.Note) (int, error) {
 	return int(id), nil
 }
 
-// GetByID 함수 정의
-func (r *NoteRepository) GetByID(id int) (*model.Note, error) {
-	row := r.DB.QueryRow("SELECT id, img, title, content, created_time, updated_time FROM notes WHERE id = ?", id)
+const noteColumns = "id, owner_id, COALESCE(workspace_id, 0), img, title, content, created_time, updated_time, version"
+
+func scanNote(row interface{ Scan(...interface{}) error }) (*model.Note, error) {
 	note := &model.Note{}
-	err := row.Scan(&note.ID, &note.Img, &note.Title, &note.Content, &note.CreatedTime, &note.UpdatedTime)
+	err := row.Scan(&note.ID, &note.OwnerID, &note.WorkspaceID, &note.Img, &note.Title, &note.Content, &note.CreatedTime, &note.UpdatedTime, &note.Version)
 	if err != nil {
 		return nil, err
 	}
 	return note, nil
 }
 
-// GetAll 함수 정의
-func (r *NoteRepository) GetAll() ([]*model.Note, error) {
-	rows, err := r.DB.Query("SELECT id, img, title, content, created_time, updated_time FROM notes")
-	if err != nil {
-		return nil, err
-	}
+func scanNotes(rows *sql.Rows) ([]*model.Note, error) {
 	defer rows.Close()
-
 	var notes []*model.Note
 	for rows.Next() {
-		note := &model.Note{}
-		err := rows.Scan(&note.ID, &note.Img, &note.Title, &note.Content, &note.CreatedTime, &note.UpdatedTime)
+		note, err := scanNote(rows)
 		if err != nil {
 			return nil, err
 		}
 		notes = append(notes, note)
 	}
-	return notes, nil
+	return notes, rows.Err()
+}
+
+// GetByID 함수 정의 (scope 안의 노트만 조회)
+func (r *NoteRepository) GetByID(scope model.Scope, id int) (*model.Note, error) {
+	cond, args := scopeCondition("", scope)
+	row := r.DB.QueryRow("SELECT "+noteColumns+" FROM notes WHERE id = ? AND "+cond, append([]interface{}{id}, args...)...)
+	note, err := scanNote(row)
+	return note, notFound(err, "note")
 }
 
-// Update 함수 정의
-func (r *NoteRepository) Update(note *model.Note) error {
-	_, err := r.DB.Exec("UPDATE notes SET img = ?, title = ?, content = ?, updated_time = ? WHERE id = ?",
-		note.Img, note.Title, note.Content, note.UpdatedTime, note.ID)
-	return err
+// GetAll 함수 정의 (scope 안의 노트 전체)
+func (r *NoteRepository) GetAll(scope model.Scope) ([]*model.Note, error) {
+	cond, args := scopeCondition("", scope)
+	rows, err := r.DB.Query("SELECT "+noteColumns+" FROM notes WHERE "+cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotes(rows)
 }
 
-// Delete 함수 정의
-func (r *NoteRepository) Delete(id int) error {
-	_, err := r.DB.Exec("DELETE FROM notes WHERE id = ?", id)
-	return err
+// Update 함수 정의 (note.Version이 0이 아니면 그 버전일 때만 수정, 수정되지 않으면 false)
+func (r *NoteRepository) Update(scope model.Scope, note *model.Note) (bool, error) {
+	cond, args := scopeCondition("", scope)
+	result, err := r.DB.Exec("UPDATE notes SET img = ?, title = ?, content = ?, updated_time = ?, version = version + 1 WHERE id = ? AND (? = 0 OR version = ?) AND "+cond,
+		append([]interface{}{note.Img, note.Title, note.Content, note.UpdatedTime, note.ID, note.Version, note.Version}, args...)...)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// Move 함수 정의 (소유자와 워크스페이스 변경, version이 0이 아니면 그 버전일 때만, 바뀌지 않으면 false)
+func (r *NoteRepository) Move(scope model.Scope, id, version, ownerID, workspaceID int, now time.Time) (bool, error) {
+	cond, args := scopeCondition("", scope)
+	result, err := r.DB.Exec("UPDATE notes SET owner_id = ?, workspace_id = ?, updated_time = ?, version = version + 1 WHERE id = ? AND (? = 0 OR version = ?) AND "+cond,
+		append([]interface{}{ownerID, nullableWorkspaceID(workspaceID), now, id, version, version}, args...)...)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// Delete 함수 정의 (version이 0이 아니면 그 버전일 때만 삭제, 삭제되지 않으면 false)
+func (r *NoteRepository) Delete(scope model.Scope, id, version int) (bool, error) {
+	cond, args := scopeCondition("", scope)
+	result, err := r.DB.Exec("DELETE FROM notes WHERE id = ? AND (? = 0 OR version = ?) AND "+cond, append([]interface{}{id, version, version}, args...)...)
+	if err != nil {
+		return false, err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return false, err
+	}
+	// 노트에 딸린 첨부 정보도 함께 삭제
+	_, err = r.DB.Exec("DELETE FROM attachments WHERE note_id = ?", id)
+	return true, err
+}
+
+// Search 함수 정의 (제목, 내용, 첨부 이미지에서 추출한 텍스트 검색)
+func (r *NoteRepository) Search(scope model.Scope, query string) ([]*model.Note, error) {
+	pattern := "%" + query + "%"
+	cond, args := scopeCondition("n.", scope)
+	rows, err := r.DB.Query(`
+        SELECT DISTINCT n.id, n.owner_id, COALESCE(n.workspace_id, 0), n.img, n.title, n.content, n.created_time, n.updated_time, n.version
+        FROM notes n
+        LEFT JOIN attachments a ON a.note_id = n.id
+        WHERE `+cond+` AND (n.title LIKE ? OR n.content LIKE ? OR a.extracted_text LIKE ?)
+        ORDER BY n.id`, append(args, pattern, pattern, pattern)...)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotes(rows)
+}
+
+// GetSharedByID 함수 정의 (userID에게 공유된 개인 노트와 역할 조회)
+func (r *NoteRepository) GetSharedByID(userID, id int) (*model.Note, string, error) {
+	row := r.DB.QueryRow(`
+        SELECT n.id, n.owner_id, n.img, n.title, n.content, n.created_time, n.updated_time, n.version, s.role
+        FROM notes n
+        JOIN note_shares s ON s.note_id = n.id
+        WHERE n.id = ? AND s.user_id = ? AND n.workspace_id IS NULL`, id, userID)
+	note := &model.Note{}
+	var role string
+	err := row.Scan(&note.ID, &note.OwnerID, &note.Img, &note.Title, &note.Content, &note.CreatedTime, &note.UpdatedTime, &note.Version, &role)
+	if err != nil {
+		return nil, "", notFound(err, "note")
+	}
+	return note, role, nil
+}
+
+// GetAllShared 함수 정의 (userID에게 공유된 개인 노트 전체)
+func (r *NoteRepository) GetAllShared(userID int) ([]*model.Note, error) {
+	rows, err := r.DB.Query(`
+        SELECT n.id, n.owner_id, COALESCE(n.workspace_id, 0), n.img, n.title, n.content, n.created_time, n.updated_time, n.version
+        FROM notes n
+        JOIN note_shares s ON s.note_id = n.id
+        WHERE s.user_id = ? AND n.workspace_id IS NULL
+        ORDER BY n.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotes(rows)
 }
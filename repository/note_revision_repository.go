@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+)
+
+// NoteRevisionRepository 구조체 정의
+type NoteRevisionRepository struct {
+	DB DBTX
+}
+
+// NewNoteRevisionRepository 함수 정의
+func NewNoteRevisionRepository(db *sql.DB) *NoteRevisionRepository {
+	return &NoteRevisionRepository{DB: db}
+}
+
+// Create 함수 정의 (노트별 다음 리비전 번호로 저장)
+func (r *NoteRevisionRepository) Create(revision *model.NoteRevision) (int, error) {
+	row := r.DB.QueryRow(`
+        INSERT INTO note_revisions (note_id, revision, img, title, content, created_time)
+        VALUES (?, (SELECT COALESCE(MAX(revision), 0) + 1 FROM note_revisions WHERE note_id = ?), ?, ?, ?, ?)
+        RETURNING id, revision`,
+		revision.NoteID, revision.NoteID, revision.Img, revision.Title, revision.Content, revision.CreatedTime)
+	if err := row.Scan(&revision.ID, &revision.Revision); err != nil {
+		return 0, err
+	}
+	return revision.ID, nil
+}
+
+// GetByNoteID 함수 정의 (최신 리비전부터)
+func (r *NoteRevisionRepository) GetByNoteID(noteID int) ([]*model.NoteRevision, error) {
+	rows, err := r.DB.Query("SELECT id, note_id, revision, img, title, content, created_time FROM note_revisions WHERE note_id = ? ORDER BY revision DESC", noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*model.NoteRevision
+	for rows.Next() {
+		revision := &model.NoteRevision{}
+		err := rows.Scan(&revision.ID, &revision.NoteID, &revision.Revision, &revision.Img, &revision.Title, &revision.Content, &revision.CreatedTime)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetByRevision 함수 정의 (노트의 리비전 번호로 조회)
+func (r *NoteRevisionRepository) GetByRevision(noteID, revision int) (*model.NoteRevision, error) {
+	row := r.DB.QueryRow("SELECT id, note_id, revision, img, title, content, created_time FROM note_revisions WHERE note_id = ? AND revision = ?", noteID, revision)
+	rev := &model.NoteRevision{}
+	if err := row.Scan(&rev.ID, &rev.NoteID, &rev.Revision, &rev.Img, &rev.Title, &rev.Content, &rev.CreatedTime); err != nil {
+		return nil, notFound(err, "revision")
+	}
+	return rev, nil
+}
+
+// LatestRevision 함수 정의 (리비전이 없으면 0)
+func (r *NoteRevisionRepository) LatestRevision(noteID int) (int, error) {
+	var revision int
+	err := r.DB.QueryRow("SELECT COALESCE(MAX(revision), 0) FROM note_revisions WHERE note_id = ?", noteID).Scan(&revision)
+	return revision, err
+}
+
+// DeleteByNoteID 함수 정의
+func (r *NoteRevisionRepository) DeleteByNoteID(noteID int) error {
+	_, err := r.DB.Exec("DELETE FROM note_revisions WHERE note_id = ?", noteID)
+	return err
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"time"
+)
+
+// NoteShareRepository 구조체 정의 (사용자 공유와 공개 링크)
+type NoteShareRepository struct {
+	DB DBTX
+}
+
+// NewNoteShareRepository 함수 정의
+func NewNoteShareRepository(db *sql.DB) *NoteShareRepository {
+	return &NoteShareRepository{DB: db}
+}
+
+// Upsert 함수 정의 (이미 공유한 사용자면 역할만 변경)
+func (r *NoteShareRepository) Upsert(share *model.NoteShare) error {
+	_, err := r.DB.Exec(`
+        INSERT INTO note_shares (note_id, user_id, role, created_time) VALUES (?, ?, ?, ?)
+        ON CONFLICT (note_id, user_id) DO UPDATE SET role = excluded.role`,
+		share.NoteID, share.UserID, share.Role, share.CreatedTime)
+	return err
+}
+
+// GetByNoteID 함수 정의 (공유받은 사용자의 이메일 포함)
+func (r *NoteShareRepository) GetByNoteID(noteID int) ([]*model.NoteShare, error) {
+	rows, err := r.DB.Query(`
+        SELECT s.id, s.note_id, s.user_id, u.email, s.role, s.created_time
+        FROM note_shares s
+        JOIN users u ON u.id = s.user_id
+        WHERE s.note_id = ?
+        ORDER BY s.id`, noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shares []*model.NoteShare
+	for rows.Next() {
+		share := &model.NoteShare{}
+		if err := rows.Scan(&share.ID, &share.NoteID, &share.UserID, &share.Email, &share.Role, &share.CreatedTime); err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, rows.Err()
+}
+
+// Delete 함수 정의 (공유 대상이 없으면 false)
+func (r *NoteShareRepository) Delete(noteID, userID int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM note_shares WHERE note_id = ? AND user_id = ?", noteID, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// DeleteByNoteID 함수 정의 (노트 삭제 시 공유와 공개 링크 모두 삭제)
+func (r *NoteShareRepository) DeleteByNoteID(noteID int) error {
+	if _, err := r.DB.Exec("DELETE FROM note_shares WHERE note_id = ?", noteID); err != nil {
+		return err
+	}
+	_, err := r.DB.Exec("DELETE FROM share_links WHERE note_id = ?", noteID)
+	return err
+}
+
+const shareLinkColumns = "id, note_id, user_id, prefix, token_hash, password_hash, expires_time, revoked_time, created_time"
+
+func scanShareLink(row interface{ Scan(...interface{}) error }) (*model.ShareLink, error) {
+	link := &model.ShareLink{}
+	err := row.Scan(&link.ID, &link.NoteID, &link.UserID, &link.Prefix, &link.TokenHash, &link.PasswordHash, &link.ExpiresTime, &link.RevokedTime, &link.CreatedTime)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// CreateLink 함수 정의
+func (r *NoteShareRepository) CreateLink(link *model.ShareLink) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO share_links (note_id, user_id, prefix, token_hash, password_hash, expires_time, created_time) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		link.NoteID, link.UserID, link.Prefix, link.TokenHash, link.PasswordHash, link.ExpiresTime, link.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetLinkByHash 함수 정의
+func (r *NoteShareRepository) GetLinkByHash(tokenHash string) (*model.ShareLink, error) {
+	row := r.DB.QueryRow("SELECT "+shareLinkColumns+" FROM share_links WHERE token_hash = ?", tokenHash)
+	return scanShareLink(row)
+}
+
+// GetLinksByNoteID 함수 정의
+func (r *NoteShareRepository) GetLinksByNoteID(noteID int) ([]*model.ShareLink, error) {
+	rows, err := r.DB.Query("SELECT "+shareLinkColumns+" FROM share_links WHERE note_id = ? ORDER BY id DESC", noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*model.ShareLink
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RevokeLink 함수 정의 (없거나 이미 폐기된 링크면 false)
+func (r *NoteShareRepository) RevokeLink(noteID, id int, now time.Time) (bool, error) {
+	result, err := r.DB.Exec("UPDATE share_links SET revoked_time = ? WHERE id = ? AND note_id = ? AND revoked_time IS NULL", now, id, noteID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
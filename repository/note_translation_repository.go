@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+)
+
+// NoteTranslationRepository 구조체 정의
+type NoteTranslationRepository struct {
+	DB DBTX
+}
+
+// NewNoteTranslationRepository 함수 정의
+func NewNoteTranslationRepository(db *sql.DB) *NoteTranslationRepository {
+	return &NoteTranslationRepository{DB: db}
+}
+
+// Upsert 함수 정의 (같은 언어 번역본이 있으면 덮어씀)
+func (r *NoteTranslationRepository) Upsert(translation *model.NoteTranslation) error {
+	row := r.DB.QueryRow(`
+        INSERT INTO note_translations (note_id, language, title, content, source_revision, stale, created_time)
+        VALUES (?, ?, ?, ?, ?, 0, ?)
+        ON CONFLICT (note_id, language) DO UPDATE SET
+            title = excluded.title,
+            content = excluded.content,
+            source_revision = excluded.source_revision,
+            stale = 0,
+            updated_time = excluded.created_time
+        RETURNING id`,
+		translation.NoteID, translation.Language, translation.Title, translation.Content, translation.SourceRevision, translation.CreatedTime)
+	return row.Scan(&translation.ID)
+}
+
+// GetByNoteAndLanguage 함수 정의
+func (r *NoteTranslationRepository) GetByNoteAndLanguage(noteID int, language string) (*model.NoteTranslation, error) {
+	row := r.DB.QueryRow("SELECT id, note_id, language, title, content, source_revision, stale, created_time, updated_time FROM note_translations WHERE note_id = ? AND language = ?", noteID, language)
+	translation := &model.NoteTranslation{}
+	err := row.Scan(&translation.ID, &translation.NoteID, &translation.Language, &translation.Title, &translation.Content, &translation.SourceRevision, &translation.Stale, &translation.CreatedTime, &translation.UpdatedTime)
+	if err != nil {
+		return nil, notFound(err, "translation")
+	}
+	return translation, nil
+}
+
+// GetByNoteID 함수 정의
+func (r *NoteTranslationRepository) GetByNoteID(noteID int) ([]*model.NoteTranslation, error) {
+	rows, err := r.DB.Query("SELECT id, note_id, language, title, content, source_revision, stale, created_time, updated_time FROM note_translations WHERE note_id = ? ORDER BY language", noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*model.NoteTranslation
+	for rows.Next() {
+		translation := &model.NoteTranslation{}
+		err := rows.Scan(&translation.ID, &translation.NoteID, &translation.Language, &translation.Title, &translation.Content, &translation.SourceRevision, &translation.Stale, &translation.CreatedTime, &translation.UpdatedTime)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, nil
+}
+
+// MarkStale 함수 정의 (원본 노트가 바뀌면 모든 번역본을 오래된 것으로 표시)
+func (r *NoteTranslationRepository) MarkStale(noteID int) error {
+	_, err := r.DB.Exec("UPDATE note_translations SET stale = 1 WHERE note_id = ?", noteID)
+	return err
+}
+
+// DeleteByNoteID 함수 정의
+func (r *NoteTranslationRepository) DeleteByNoteID(noteID int) error {
+	_, err := r.DB.Exec("DELETE FROM note_translations WHERE note_id = ?", noteID)
+	return err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+)
+
+// PromptTemplateRepository 구조체 정의
+type PromptTemplateRepository struct {
+	DB *sql.DB
+}
+
+// NewPromptTemplateRepository 함수 정의
+func NewPromptTemplateRepository(db *sql.DB) *PromptTemplateRepository {
+	return &PromptTemplateRepository{DB: db}
+}
+
+// Create 함수 정의
+func (r *PromptTemplateRepository) Create(tmpl *model.PromptTemplate) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO prompt_templates (name, description, template, created_time, updated_time) VALUES (?, ?, ?, ?, ?)",
+		tmpl.Name, tmpl.Description, tmpl.Template, tmpl.CreatedTime, tmpl.UpdatedTime)
+	if err != nil {
+		return 0, conflict(err, "prompt template name is already in use")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의
+func (r *PromptTemplateRepository) GetByID(id int) (*model.PromptTemplate, error) {
+	row := r.DB.QueryRow("SELECT id, name, description, template, created_time, updated_time FROM prompt_templates WHERE id = ?", id)
+	tmpl := &model.PromptTemplate{}
+	err := row.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Description, &tmpl.Template, &tmpl.CreatedTime, &tmpl.UpdatedTime)
+	if err != nil {
+		return nil, notFound(err, "prompt template")
+	}
+	return tmpl, nil
+}
+
+// GetByName 함수 정의
+func (r *PromptTemplateRepository) GetByName(name string) (*model.PromptTemplate, error) {
+	row := r.DB.QueryRow("SELECT id, name, description, template, created_time, updated_time FROM prompt_templates WHERE name = ?", name)
+	tmpl := &model.PromptTemplate{}
+	err := row.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Description, &tmpl.Template, &tmpl.CreatedTime, &tmpl.UpdatedTime)
+	if err != nil {
+		return nil, notFound(err, "prompt template")
+	}
+	return tmpl, nil
+}
+
+// GetAll 함수 정의
+func (r *PromptTemplateRepository) GetAll() ([]*model.PromptTemplate, error) {
+	rows, err := r.DB.Query("SELECT id, name, description, template, created_time, updated_time FROM prompt_templates ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*model.PromptTemplate
+	for rows.Next() {
+		tmpl := &model.PromptTemplate{}
+		err := rows.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Description, &tmpl.Template, &tmpl.CreatedTime, &tmpl.UpdatedTime)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// Update 함수 정의
+func (r *PromptTemplateRepository) Update(tmpl *model.PromptTemplate) error {
+	_, err := r.DB.Exec("UPDATE prompt_templates SET name = ?, description = ?, template = ?, updated_time = ? WHERE id = ?",
+		tmpl.Name, tmpl.Description, tmpl.Template, tmpl.UpdatedTime, tmpl.ID)
+	return conflict(err, "prompt template name is already in use")
+}
+
+// Delete 함수 정의
+func (r *PromptTemplateRepository) Delete(id int) error {
+	_, err := r.DB.Exec("DELETE FROM prompt_templates WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// InitializeSchema 함수 정의 (테이블 생성과 기존 테이블에 추가된 컬럼 반영)
+func InitializeSchema(db *sql.DB) error {
+	// 노트 테이블 생성 쿼리
+	createTableQuery := `
+    CREATE TABLE IF NOT EXISTS notes (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        title TEXT,
+        content TEXT NOT NULL,
+        img TEXT,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE TABLE IF NOT EXISTS attachments (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        filename TEXT NOT NULL,
+        path TEXT NOT NULL,
+        mime_type TEXT NOT NULL,
+        extracted_text TEXT NOT NULL DEFAULT '',
+        created_time DATETIME NOT NULL,
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+    CREATE INDEX IF NOT EXISTS idx_attachments_note_id ON attachments(note_id);
+    CREATE TABLE IF NOT EXISTS jobs (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        type TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        status TEXT NOT NULL,
+        attempts INTEGER NOT NULL DEFAULT 0,
+        max_attempts INTEGER NOT NULL,
+        run_at DATETIME NOT NULL,
+        last_error TEXT NOT NULL DEFAULT '',
+        result TEXT NOT NULL DEFAULT '',
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE INDEX IF NOT EXISTS idx_jobs_status_run_at ON jobs(status, run_at);
+    CREATE TABLE IF NOT EXISTS prompt_templates (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name TEXT NOT NULL UNIQUE,
+        description TEXT NOT NULL DEFAULT '',
+        template TEXT NOT NULL,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE TABLE IF NOT EXISTS note_revisions (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        revision INTEGER NOT NULL,
+        img TEXT,
+        title TEXT,
+        content TEXT NOT NULL,
+        created_time DATETIME NOT NULL,
+        UNIQUE (note_id, revision)
+    );
+    CREATE TABLE IF NOT EXISTS note_translations (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        language TEXT NOT NULL,
+        title TEXT,
+        content TEXT NOT NULL,
+        source_revision INTEGER NOT NULL,
+        stale BOOLEAN NOT NULL DEFAULT 0,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME,
+        UNIQUE (note_id, language)
+    );
+    CREATE TABLE IF NOT EXISTS tasks (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        text TEXT NOT NULL,
+        done BOOLEAN NOT NULL DEFAULT 0,
+        due_date DATETIME,
+        source TEXT NOT NULL,
+        span_start INTEGER NOT NULL,
+        span_end INTEGER NOT NULL,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE INDEX IF NOT EXISTS idx_tasks_note_id ON tasks(note_id);
+    CREATE TABLE IF NOT EXISTS ai_usage (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        operation TEXT NOT NULL,
+        model TEXT NOT NULL,
+        user_id INTEGER NOT NULL DEFAULT 0,
+        note_id INTEGER,
+        prompt_tokens INTEGER NOT NULL DEFAULT 0,
+        candidate_tokens INTEGER NOT NULL DEFAULT 0,
+        total_tokens INTEGER NOT NULL DEFAULT 0,
+        latency_ms INTEGER NOT NULL DEFAULT 0,
+        success BOOLEAN NOT NULL,
+        error TEXT NOT NULL DEFAULT '',
+        created_time DATETIME NOT NULL
+    );
+    CREATE INDEX IF NOT EXISTS idx_ai_usage_created_time ON ai_usage(created_time);
+    CREATE TABLE IF NOT EXISTS users (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        email TEXT NOT NULL UNIQUE,
+        password_hash TEXT NOT NULL,
+        is_admin INTEGER NOT NULL DEFAULT 0,
+        created_time DATETIME NOT NULL
+    );
+    CREATE TABLE IF NOT EXISTS refresh_tokens (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        user_id INTEGER NOT NULL,
+        token_hash TEXT NOT NULL UNIQUE,
+        expires_time DATETIME NOT NULL,
+        revoked_time DATETIME,
+        created_time DATETIME NOT NULL,
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    );
+    CREATE TABLE IF NOT EXISTS api_tokens (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        user_id INTEGER NOT NULL,
+        name TEXT NOT NULL,
+        prefix TEXT NOT NULL,
+        token_hash TEXT NOT NULL UNIQUE,
+        scopes TEXT NOT NULL,
+        expires_time DATETIME,
+        last_used_time DATETIME,
+        created_time DATETIME NOT NULL,
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    );
+    CREATE INDEX IF NOT EXISTS idx_api_tokens_user_id ON api_tokens(user_id);
+    CREATE TABLE IF NOT EXISTS note_shares (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        role TEXT NOT NULL,
+        created_time DATETIME NOT NULL,
+        UNIQUE (note_id, user_id)
+    );
+    CREATE INDEX IF NOT EXISTS idx_note_shares_user_id ON note_shares(user_id);
+    CREATE TABLE IF NOT EXISTS share_links (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        prefix TEXT NOT NULL,
+        token_hash TEXT NOT NULL UNIQUE,
+        password_hash TEXT NOT NULL DEFAULT '',
+        expires_time DATETIME,
+        revoked_time DATETIME,
+        created_time DATETIME NOT NULL
+    );
+    CREATE TABLE IF NOT EXISTS workspaces (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name TEXT NOT NULL,
+        created_time DATETIME NOT NULL
+    );
+    CREATE TABLE IF NOT EXISTS workspace_members (
+        workspace_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        role TEXT NOT NULL,
+        created_time DATETIME NOT NULL,
+        PRIMARY KEY (workspace_id, user_id)
+    );
+    CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id ON workspace_members(user_id);
+    CREATE TABLE IF NOT EXISTS workspace_invitations (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        workspace_id INTEGER NOT NULL,
+        email TEXT NOT NULL,
+        role TEXT NOT NULL,
+        prefix TEXT NOT NULL,
+        token_hash TEXT NOT NULL UNIQUE,
+        invited_by INTEGER NOT NULL,
+        expires_time DATETIME NOT NULL,
+        accepted_time DATETIME,
+        created_time DATETIME NOT NULL
+    );
+    CREATE TABLE IF NOT EXISTS note_events (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        type TEXT NOT NULL,
+        note_id INTEGER NOT NULL,
+        owner_id INTEGER NOT NULL,
+        workspace_id INTEGER NOT NULL DEFAULT 0,
+        from_owner_id INTEGER NOT NULL DEFAULT 0,
+        from_workspace_id INTEGER NOT NULL DEFAULT 0,
+        version INTEGER NOT NULL,
+        created_time DATETIME NOT NULL
+    );
+    CREATE INDEX IF NOT EXISTS idx_note_events_owner_id ON note_events(owner_id, id);
+    CREATE INDEX IF NOT EXISTS idx_note_events_workspace_id ON note_events(workspace_id, id);
+    CREATE TABLE IF NOT EXISTS webhooks (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        user_id INTEGER NOT NULL,
+        workspace_id INTEGER NOT NULL DEFAULT 0,
+        url TEXT NOT NULL,
+        secret TEXT NOT NULL,
+        event_types TEXT NOT NULL,
+        active BOOLEAN NOT NULL DEFAULT 1,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE TABLE IF NOT EXISTS webhook_deliveries (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        webhook_id INTEGER NOT NULL,
+        event_id INTEGER NOT NULL DEFAULT 0,
+        event_type TEXT NOT NULL,
+        payload TEXT NOT NULL,
+        status TEXT NOT NULL,
+        attempts INTEGER NOT NULL DEFAULT 0,
+        response_status INTEGER NOT NULL DEFAULT 0,
+        response_body TEXT NOT NULL DEFAULT '',
+        last_error TEXT NOT NULL DEFAULT '',
+        job_id INTEGER NOT NULL DEFAULT 0,
+        replay_of INTEGER NOT NULL DEFAULT 0,
+        created_time DATETIME NOT NULL,
+        updated_time DATETIME
+    );
+    CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, id);
+    -- 웹훅으로 전달을 마친 마지막 노트 이벤트 (행 하나)
+    CREATE TABLE IF NOT EXISTS webhook_cursor (
+        id INTEGER PRIMARY KEY CHECK (id = 1),
+        last_event_id INTEGER NOT NULL,
+        updated_time DATETIME NOT NULL
+    );
+    -- 보관 기간이 지나 삭제한 마지막 노트 이벤트 (행 하나, 이보다 오래된 커서로는 이어받을 수 없음)
+    CREATE TABLE IF NOT EXISTS note_event_retention (
+        id INTEGER PRIMARY KEY CHECK (id = 1),
+        pruned_through INTEGER NOT NULL,
+        updated_time DATETIME NOT NULL
+    );
+    -- 오프라인 클라이언트 ID는 같은 사용자라도 개인 노트와 워크스페이스별로 따로 관리
+    CREATE TABLE IF NOT EXISTS sync_client_ids (
+        user_id INTEGER NOT NULL,
+        workspace_id INTEGER NOT NULL DEFAULT 0,
+        client_id TEXT NOT NULL,
+        note_id INTEGER NOT NULL,
+        created_time DATETIME NOT NULL,
+        PRIMARY KEY (user_id, workspace_id, client_id),
+        FOREIGN KEY (user_id) REFERENCES users(id)
+    );
+    CREATE TABLE IF NOT EXISTS audit_log (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        actor_id INTEGER NOT NULL,
+        action TEXT NOT NULL,
+        target_type TEXT NOT NULL,
+        target_id INTEGER NOT NULL,
+        workspace_id INTEGER NOT NULL DEFAULT 0,
+        before_hash TEXT NOT NULL DEFAULT '',
+        after_hash TEXT NOT NULL DEFAULT '',
+        details TEXT NOT NULL DEFAULT '',
+        ip TEXT NOT NULL DEFAULT '',
+        request_id TEXT NOT NULL DEFAULT '',
+        created_time DATETIME NOT NULL
+    );
+    CREATE INDEX IF NOT EXISTS idx_audit_log_created_time ON audit_log(created_time);
+    CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log(target_type, target_id);
+    -- 감사 기록은 추가만 가능
+    CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+    BEGIN
+        SELECT RAISE(ABORT, 'audit_log is append-only');
+    END;
+    CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+    BEGIN
+        SELECT RAISE(ABORT, 'audit_log is append-only');
+    END;
+    `
+
+	// 테이블 생성 쿼리 실행
+	_, err := db.Exec(createTableQuery)
+	if err != nil {
+		return err
+	}
+
+	// 기존 테이블에 추가된 컬럼 반영
+	columns := []struct {
+		table, column, definition string
+	}{
+		{"attachments", "thumbnail_path", "TEXT NOT NULL DEFAULT ''"},
+		{"notes", "owner_id", "INTEGER REFERENCES users(id)"},
+		{"jobs", "user_id", "INTEGER NOT NULL DEFAULT 0"},
+		{"notes", "workspace_id", "INTEGER REFERENCES workspaces(id)"},
+		{"jobs", "workspace_id", "INTEGER NOT NULL DEFAULT 0"},
+		{"notes", "version", "INTEGER NOT NULL DEFAULT 1"},
+		{"ai_usage", "workspace_id", "INTEGER NOT NULL DEFAULT 0"},
+		{"users", "is_admin", "INTEGER NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := addColumnIfNotExists(db, c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+
+	// 추가된 컬럼에 대한 인덱스
+	_, err = db.Exec(`
+    CREATE INDEX IF NOT EXISTS idx_notes_owner_id ON notes(owner_id);
+    CREATE INDEX IF NOT EXISTS idx_notes_workspace_id ON notes(workspace_id);
+    `)
+	return err
+}
+
+// 컬럼이 없을 때만 ALTER TABLE 실행
+func addColumnIfNotExists(db *sql.DB, table, column, definition string) error {
+	exists, err := hasColumn(db, table, column)
+	if err != nil || exists {
+		return err
+	}
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// hasColumn 함수 정의
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultVal, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"time"
+)
+
+// SyncClientIDRepository 구조체 정의 (오프라인 클라이언트가 만든 노트 ID와 서버 노트 ID 연결, 사용자와 워크스페이스별)
+type SyncClientIDRepository struct {
+	DB DBTX
+}
+
+// NewSyncClientIDRepository 함수 정의
+func NewSyncClientIDRepository(db *sql.DB) *SyncClientIDRepository {
+	return &SyncClientIDRepository{DB: db}
+}
+
+// Get 함수 정의 (연결된 노트 ID, 없으면 sql.ErrNoRows)
+func (r *SyncClientIDRepository) Get(scope model.Scope, clientID string) (int, error) {
+	var noteID int
+	err := r.DB.QueryRow("SELECT note_id FROM sync_client_ids WHERE user_id = ? AND workspace_id = ? AND client_id = ?",
+		scope.UserID, scope.WorkspaceID, clientID).Scan(&noteID)
+	return noteID, err
+}
+
+// Set 함수 정의 (이미 있으면 새 노트 ID로 바꿈)
+func (r *SyncClientIDRepository) Set(scope model.Scope, clientID string, noteID int, now time.Time) error {
+	_, err := r.DB.Exec(`
+        INSERT INTO sync_client_ids (user_id, workspace_id, client_id, note_id, created_time) VALUES (?, ?, ?, ?, ?)
+        ON CONFLICT (user_id, workspace_id, client_id) DO UPDATE SET note_id = excluded.note_id`,
+		scope.UserID, scope.WorkspaceID, clientID, noteID, now)
+	return err
+}
+
+// GetAll 함수 정의 (scope의 노트 ID별 클라이언트 ID)
+func (r *SyncClientIDRepository) GetAll(scope model.Scope) (map[int]string, error) {
+	rows, err := r.DB.Query("SELECT note_id, client_id FROM sync_client_ids WHERE user_id = ? AND workspace_id = ?", scope.UserID, scope.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[int]string)
+	for rows.Next() {
+		var noteID int
+		var clientID string
+		if err := rows.Scan(&noteID, &clientID); err != nil {
+			return nil, err
+		}
+		ids[noteID] = clientID
+	}
+	return ids, rows.Err()
+}
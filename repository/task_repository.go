@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+)
+
+// TaskRepository 구조체 정의
+type TaskRepository struct {
+	DB DBTX
+}
+
+// NewTaskRepository 함수 정의
+func NewTaskRepository(db *sql.DB) *TaskRepository {
+	return &TaskRepository{DB: db}
+}
+
+const taskColumns = "id, note_id, text, done, due_date, source, span_start, span_end, created_time, updated_time"
+
+func scanTask(row interface{ Scan(...interface{}) error }) (*model.Task, error) {
+	task := &model.Task{}
+	err := row.Scan(&task.ID, &task.NoteID, &task.Text, &task.Done, &task.DueDate, &task.Source, &task.SpanStart, &task.SpanEnd, &task.CreatedTime, &task.UpdatedTime)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func scanTasks(rows *sql.Rows) ([]*model.Task, error) {
+	defer rows.Close()
+	var tasks []*model.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// Create 함수 정의
+func (r *TaskRepository) Create(task *model.Task) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO tasks (note_id, text, done, due_date, source, span_start, span_end, created_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		task.NoteID, task.Text, task.Done, task.DueDate, task.Source, task.SpanStart, task.SpanEnd, task.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의
+func (r *TaskRepository) GetByID(id int) (*model.Task, error) {
+	task, err := scanTask(r.DB.QueryRow("SELECT "+taskColumns+" FROM tasks WHERE id = ?", id))
+	return task, notFound(err, "task")
+}
+
+// GetByNoteID 함수 정의 (노트 안의 위치 순서)
+func (r *TaskRepository) GetByNoteID(noteID int) ([]*model.Task, error) {
+	rows, err := r.DB.Query("SELECT "+taskColumns+" FROM tasks WHERE note_id = ? ORDER BY source, span_start, id", noteID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// GetAll 함수 정의 (scope 안의 노트에 속한 할 일, done이 nil이면 전체, 마감일 있는 것 먼저)
+func (r *TaskRepository) GetAll(scope model.Scope, done *bool) ([]*model.Task, error) {
+	cond, args := scopeCondition("", scope)
+	query := "SELECT " + taskColumns + " FROM tasks WHERE note_id IN (SELECT id FROM notes WHERE " + cond + ")"
+	if done != nil {
+		query += " AND done = ?"
+		args = append(args, *done)
+	}
+	query += " ORDER BY due_date IS NULL, due_date, note_id, span_start, id"
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// Update 함수 정의
+func (r *TaskRepository) Update(task *model.Task) error {
+	_, err := r.DB.Exec("UPDATE tasks SET text = ?, done = ?, due_date = ?, span_start = ?, span_end = ?, updated_time = ? WHERE id = ?",
+		task.Text, task.Done, task.DueDate, task.SpanStart, task.SpanEnd, task.UpdatedTime, task.ID)
+	return err
+}
+
+// Delete 함수 정의
+func (r *TaskRepository) Delete(id int) error {
+	_, err := r.DB.Exec("DELETE FROM tasks WHERE id = ?", id)
+	return err
+}
+
+// DeleteByNoteID 함수 정의
+func (r *TaskRepository) DeleteByNoteID(noteID int) error {
+	_, err := r.DB.Exec("DELETE FROM tasks WHERE note_id = ?", noteID)
+	return err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"time"
+)
+
+// UserRepository 구조체 정의
+type UserRepository struct {
+	DB *sql.DB
+}
+
+// NewUserRepository 함수 정의
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{DB: db}
+}
+
+// Create 함수 정의
+func (r *UserRepository) Create(user *model.User) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO users (email, password_hash, created_time) VALUES (?, ?, ?)", user.Email, user.PasswordHash, user.CreatedTime)
+	if err != nil {
+		return 0, conflict(err, "email is already registered")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의
+func (r *UserRepository) GetByID(id int) (*model.User, error) {
+	row := r.DB.QueryRow("SELECT id, email, password_hash, is_admin, created_time FROM users WHERE id = ?", id)
+	user := &model.User{}
+	if err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.IsAdmin, &user.CreatedTime); err != nil {
+		return nil, notFound(err, "user")
+	}
+	return user, nil
+}
+
+// GetByEmail 함수 정의
+func (r *UserRepository) GetByEmail(email string) (*model.User, error) {
+	row := r.DB.QueryRow("SELECT id, email, password_hash, is_admin, created_time FROM users WHERE email = ?", email)
+	user := &model.User{}
+	if err := row.Scan(&user.ID, &user.Email, &user.PasswordHash, &user.IsAdmin, &user.CreatedTime); err != nil {
+		return nil, notFound(err, "user")
+	}
+	return user, nil
+}
+
+// SetAdmin 함수 정의 (해당 이메일의 사용자가 없으면 false)
+func (r *UserRepository) SetAdmin(email string, admin bool) (bool, error) {
+	result, err := r.DB.Exec("UPDATE users SET is_admin = ? WHERE email = ?", admin, email)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// CreateRefreshToken 함수 정의
+func (r *UserRepository) CreateRefreshToken(token *model.RefreshToken) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO refresh_tokens (user_id, token_hash, expires_time, created_time) VALUES (?, ?, ?, ?)",
+		token.UserID, token.TokenHash, token.ExpiresTime, token.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetRefreshToken 함수 정의
+func (r *UserRepository) GetRefreshToken(tokenHash string) (*model.RefreshToken, error) {
+	row := r.DB.QueryRow("SELECT id, user_id, token_hash, expires_time, revoked_time, created_time FROM refresh_tokens WHERE token_hash = ?", tokenHash)
+	token := &model.RefreshToken{}
+	if err := row.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresTime, &token.RevokedTime, &token.CreatedTime); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// RevokeRefreshToken 함수 정의 (이미 폐기된 토큰이면 false)
+func (r *UserRepository) RevokeRefreshToken(id int, now time.Time) (bool, error) {
+	result, err := r.DB.Exec("UPDATE refresh_tokens SET revoked_time = ? WHERE id = ? AND revoked_time IS NULL", now, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
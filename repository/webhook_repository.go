@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"myapp/model"
+	"strings"
+	"time"
+)
+
+// WebhookRepository 구조체 정의 (웹훅, 전송 기록, 이벤트 전달 위치)
+type WebhookRepository struct {
+	DB *sql.DB
+}
+
+// NewWebhookRepository 함수 정의
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{DB: db}
+}
+
+// webhookScopeCondition 함수 정의 (개인 웹훅은 만든 사용자 기준, 워크스페이스 웹훅은 워크스페이스 기준 조건)
+func webhookScopeCondition(scope model.Scope) (string, []interface{}) {
+	if scope.WorkspaceID != 0 {
+		return "workspace_id = ?", []interface{}{scope.WorkspaceID}
+	}
+	return "user_id = ? AND workspace_id = 0", []interface{}{scope.UserID}
+}
+
+const webhookColumns = "id, user_id, workspace_id, url, secret, event_types, active, created_time, updated_time"
+
+func scanWebhook(row interface{ Scan(...interface{}) error }) (*model.Webhook, error) {
+	webhook := &model.Webhook{}
+	var eventTypes string
+	err := row.Scan(&webhook.ID, &webhook.UserID, &webhook.WorkspaceID, &webhook.URL, &webhook.Secret, &eventTypes, &webhook.Active, &webhook.CreatedTime, &webhook.UpdatedTime)
+	if err != nil {
+		return nil, err
+	}
+	webhook.EventTypes = []string{}
+	if eventTypes != "" {
+		webhook.EventTypes = strings.Split(eventTypes, ",")
+	}
+	return webhook, nil
+}
+
+func (r *WebhookRepository) queryWebhooks(query string, args ...interface{}) ([]*model.Webhook, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*model.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// Create 함수 정의
+func (r *WebhookRepository) Create(webhook *model.Webhook) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO webhooks (user_id, workspace_id, url, secret, event_types, active, created_time) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		webhook.UserID, webhook.WorkspaceID, webhook.URL, webhook.Secret, strings.Join(webhook.EventTypes, ","), webhook.Active, webhook.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetByID 함수 정의 (scope 안의 웹훅만 조회)
+func (r *WebhookRepository) GetByID(scope model.Scope, id int) (*model.Webhook, error) {
+	cond, args := webhookScopeCondition(scope)
+	row := r.DB.QueryRow("SELECT "+webhookColumns+" FROM webhooks WHERE id = ? AND "+cond, append([]interface{}{id}, args...)...)
+	webhook, err := scanWebhook(row)
+	return webhook, notFound(err, "webhook")
+}
+
+// GetAll 함수 정의
+func (r *WebhookRepository) GetAll(scope model.Scope) ([]*model.Webhook, error) {
+	cond, args := webhookScopeCondition(scope)
+	return r.queryWebhooks("SELECT "+webhookColumns+" FROM webhooks WHERE "+cond+" ORDER BY id", args...)
+}
+
+// GetActive 함수 정의 (모든 사용자의 활성 웹훅, 이벤트 전달에 사용)
+func (r *WebhookRepository) GetActive() ([]*model.Webhook, error) {
+	return r.queryWebhooks("SELECT " + webhookColumns + " FROM webhooks WHERE active = 1 ORDER BY id")
+}
+
+// GetForDelivery 함수 정의 (범위와 관계없이 ID로 조회, 전송 작업에서 사용)
+func (r *WebhookRepository) GetForDelivery(id int) (*model.Webhook, error) {
+	row := r.DB.QueryRow("SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id)
+	webhook, err := scanWebhook(row)
+	return webhook, notFound(err, "webhook")
+}
+
+// Update 함수 정의 (URL, 비밀 키, 이벤트 종류, 활성 여부)
+func (r *WebhookRepository) Update(webhook *model.Webhook) error {
+	_, err := r.DB.Exec("UPDATE webhooks SET url = ?, secret = ?, event_types = ?, active = ?, updated_time = ? WHERE id = ?",
+		webhook.URL, webhook.Secret, strings.Join(webhook.EventTypes, ","), webhook.Active, webhook.UpdatedTime, webhook.ID)
+	return err
+}
+
+// Delete 함수 정의 (전송 기록도 함께 삭제)
+func (r *WebhookRepository) Delete(id int) error {
+	if _, err := r.DB.Exec("DELETE FROM webhook_deliveries WHERE webhook_id = ?", id); err != nil {
+		return err
+	}
+	_, err := r.DB.Exec("DELETE FROM webhooks WHERE id = ?", id)
+	return err
+}
+
+const deliveryColumns = "id, webhook_id, event_id, event_type, payload, status, attempts, response_status, response_body, last_error, job_id, replay_of, created_time, updated_time"
+
+func scanDelivery(row interface{ Scan(...interface{}) error }) (*model.WebhookDelivery, error) {
+	d := &model.WebhookDelivery{}
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.ResponseStatus,
+		&d.ResponseBody, &d.LastError, &d.JobID, &d.ReplayOf, &d.CreatedTime, &d.UpdatedTime)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// CreateDelivery 함수 정의
+func (r *WebhookRepository) CreateDelivery(d *model.WebhookDelivery) (int, error) {
+	result, err := r.DB.Exec(`
+        INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, replay_of, created_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		d.WebhookID, d.EventID, d.EventType, d.Payload, d.Status, d.ReplayOf, d.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetDelivery 함수 정의
+func (r *WebhookRepository) GetDelivery(id int) (*model.WebhookDelivery, error) {
+	row := r.DB.QueryRow("SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?", id)
+	d, err := scanDelivery(row)
+	return d, notFound(err, "delivery")
+}
+
+// GetDeliveries 함수 정의 (최근 기록부터, limit이 0이면 전체)
+func (r *WebhookRepository) GetDeliveries(webhookID, limit int) ([]*model.WebhookDelivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	rows, err := r.DB.Query(query, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*model.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// SetDeliveryJob 함수 정의
+func (r *WebhookRepository) SetDeliveryJob(id, jobID int) error {
+	_, err := r.DB.Exec("UPDATE webhook_deliveries SET job_id = ? WHERE id = ?", jobID, id)
+	return err
+}
+
+// RecordAttempt 함수 정의 (전송 시도 결과 저장)
+func (r *WebhookRepository) RecordAttempt(d *model.WebhookDelivery) error {
+	_, err := r.DB.Exec(`
+        UPDATE webhook_deliveries SET status = ?, attempts = ?, response_status = ?, response_body = ?, last_error = ?, updated_time = ?
+        WHERE id = ?`,
+		d.Status, d.Attempts, d.ResponseStatus, d.ResponseBody, d.LastError, d.UpdatedTime, d.ID)
+	return err
+}
+
+// GetCursor 함수 정의 (웹훅으로 전달을 마친 마지막 이벤트 ID, 처음이면 sql.ErrNoRows)
+func (r *WebhookRepository) GetCursor() (int64, error) {
+	var id int64
+	err := r.DB.QueryRow("SELECT last_event_id FROM webhook_cursor WHERE id = 1").Scan(&id)
+	return id, err
+}
+
+// SetCursor 함수 정의
+func (r *WebhookRepository) SetCursor(eventID int64, now time.Time) error {
+	_, err := r.DB.Exec(`
+        INSERT INTO webhook_cursor (id, last_event_id, updated_time) VALUES (1, ?, ?)
+        ON CONFLICT (id) DO UPDATE SET last_event_id = excluded.last_event_id, updated_time = excluded.updated_time`, eventID, now)
+	return err
+}
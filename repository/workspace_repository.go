@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/model"
+	"time"
+)
+
+// WorkspaceRepository 구조체 정의 (워크스페이스, 멤버, 초대)
+type WorkspaceRepository struct {
+	DB *sql.DB
+}
+
+// NewWorkspaceRepository 함수 정의
+func NewWorkspaceRepository(db *sql.DB) *WorkspaceRepository {
+	return &WorkspaceRepository{DB: db}
+}
+
+// Create 함수 정의 (워크스페이스를 만들고 ownerID를 owner 멤버로 추가)
+func (r *WorkspaceRepository) Create(workspace *model.Workspace, ownerID int) (int, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("INSERT INTO workspaces (name, created_time) VALUES (?, ?)", workspace.Name, workspace.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec("INSERT INTO workspace_members (workspace_id, user_id, role, created_time) VALUES (?, ?, ?, ?)",
+		id, ownerID, model.WorkspaceRoleOwner, workspace.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	return int(id), tx.Commit()
+}
+
+// GetByUserID 함수 정의 (userID가 속한 워크스페이스와 역할)
+func (r *WorkspaceRepository) GetByUserID(userID int) ([]*model.Workspace, error) {
+	rows, err := r.DB.Query(`
+        SELECT w.id, w.name, m.role, w.created_time
+        FROM workspaces w
+        JOIN workspace_members m ON m.workspace_id = w.id
+        WHERE m.user_id = ?
+        ORDER BY w.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workspaces []*model.Workspace
+	for rows.Next() {
+		workspace := &model.Workspace{}
+		if err := rows.Scan(&workspace.ID, &workspace.Name, &workspace.Role, &workspace.CreatedTime); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, rows.Err()
+}
+
+// GetMemberRole 함수 정의 (멤버가 아니면 sql.ErrNoRows)
+func (r *WorkspaceRepository) GetMemberRole(workspaceID, userID int) (string, error) {
+	var role string
+	err := r.DB.QueryRow("SELECT role FROM workspace_members WHERE workspace_id = ? AND user_id = ?", workspaceID, userID).Scan(&role)
+	return role, err
+}
+
+// GetMembers 함수 정의
+func (r *WorkspaceRepository) GetMembers(workspaceID int) ([]*model.WorkspaceMember, error) {
+	rows, err := r.DB.Query(`
+        SELECT m.workspace_id, m.user_id, u.email, m.role, m.created_time
+        FROM workspace_members m
+        JOIN users u ON u.id = m.user_id
+        WHERE m.workspace_id = ?
+        ORDER BY m.created_time, m.user_id`, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*model.WorkspaceMember
+	for rows.Next() {
+		member := &model.WorkspaceMember{}
+		if err := rows.Scan(&member.WorkspaceID, &member.UserID, &member.Email, &member.Role, &member.CreatedTime); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// CountOwners 함수 정의
+func (r *WorkspaceRepository) CountOwners(workspaceID int) (int, error) {
+	var count int
+	err := r.DB.QueryRow("SELECT COUNT(*) FROM workspace_members WHERE workspace_id = ? AND role = ?", workspaceID, model.WorkspaceRoleOwner).Scan(&count)
+	return count, err
+}
+
+// AddMember 함수 정의 (이미 멤버면 역할을 바꾸지 않음)
+func (r *WorkspaceRepository) AddMember(member *model.WorkspaceMember) error {
+	_, err := r.DB.Exec("INSERT INTO workspace_members (workspace_id, user_id, role, created_time) VALUES (?, ?, ?, ?) ON CONFLICT (workspace_id, user_id) DO NOTHING",
+		member.WorkspaceID, member.UserID, member.Role, member.CreatedTime)
+	return err
+}
+
+// UpdateMemberRole 함수 정의
+func (r *WorkspaceRepository) UpdateMemberRole(workspaceID, userID int, role string) error {
+	_, err := r.DB.Exec("UPDATE workspace_members SET role = ? WHERE workspace_id = ? AND user_id = ?", role, workspaceID, userID)
+	return err
+}
+
+// DeleteMember 함수 정의
+func (r *WorkspaceRepository) DeleteMember(workspaceID, userID int) error {
+	_, err := r.DB.Exec("DELETE FROM workspace_members WHERE workspace_id = ? AND user_id = ?", workspaceID, userID)
+	return err
+}
+
+const invitationColumns = "id, workspace_id, email, role, prefix, token_hash, invited_by, expires_time, accepted_time, created_time"
+
+func scanInvitation(row interface{ Scan(...interface{}) error }) (*model.WorkspaceInvitation, error) {
+	inv := &model.WorkspaceInvitation{}
+	err := row.Scan(&inv.ID, &inv.WorkspaceID, &inv.Email, &inv.Role, &inv.Prefix, &inv.TokenHash, &inv.InvitedBy, &inv.ExpiresTime, &inv.AcceptedTime, &inv.CreatedTime)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// CreateInvitation 함수 정의
+func (r *WorkspaceRepository) CreateInvitation(inv *model.WorkspaceInvitation) (int, error) {
+	result, err := r.DB.Exec("INSERT INTO workspace_invitations (workspace_id, email, role, prefix, token_hash, invited_by, expires_time, created_time) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		inv.WorkspaceID, inv.Email, inv.Role, inv.Prefix, inv.TokenHash, inv.InvitedBy, inv.ExpiresTime, inv.CreatedTime)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// GetInvitationByHash 함수 정의
+func (r *WorkspaceRepository) GetInvitationByHash(tokenHash string) (*model.WorkspaceInvitation, error) {
+	row := r.DB.QueryRow("SELECT "+invitationColumns+" FROM workspace_invitations WHERE token_hash = ?", tokenHash)
+	return scanInvitation(row)
+}
+
+// GetPendingInvitations 함수 정의 (수락되지 않은 초대)
+func (r *WorkspaceRepository) GetPendingInvitations(workspaceID int) ([]*model.WorkspaceInvitation, error) {
+	rows, err := r.DB.Query("SELECT "+invitationColumns+" FROM workspace_invitations WHERE workspace_id = ? AND accepted_time IS NULL ORDER BY id DESC", workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*model.WorkspaceInvitation
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, inv)
+	}
+	return invitations, rows.Err()
+}
+
+// MarkInvitationAccepted 함수 정의 (이미 수락된 초대면 false)
+func (r *WorkspaceRepository) MarkInvitationAccepted(id int, now time.Time) (bool, error) {
+	result, err := r.DB.Exec("UPDATE workspace_invitations SET accepted_time = ? WHERE id = ? AND accepted_time IS NULL", now, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// DeleteInvitation 함수 정의 (수락 전 초대만 취소 가능, 없으면 false)
+func (r *WorkspaceRepository) DeleteInvitation(workspaceID, id int) (bool, error) {
+	result, err := r.DB.Exec("DELETE FROM workspace_invitations WHERE id = ? AND workspace_id = ? AND accepted_time IS NULL", id, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"time"
+)
+
+// API 토큰 권한
+const (
+	ScopeNotesRead  = "notes:read"
+	ScopeNotesWrite = "notes:write"
+	ScopeAIAnalyze  = "ai:analyze"
+)
+
+// AllScopes 발급 가능한 권한 목록
+var AllScopes = []string{ScopeNotesRead, ScopeNotesWrite, ScopeAIAnalyze}
+
+// APITokenPrefix 개인 토큰 접두사 (JWT와 구분)
+const APITokenPrefix = "mat_"
+
+// APITokenService 구조체 정의
+type APITokenService struct {
+	Repo *repository.APITokenRepository
+}
+
+// NewAPITokenService 함수 정의
+func NewAPITokenService(repo *repository.APITokenRepository) *APITokenService {
+	return &APITokenService{Repo: repo}
+}
+
+// IsAPIToken 함수 정의 (Authorization 값이 개인 토큰 형식인지 확인)
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// normalizeScopes 함수 정의 (알 수 없는 권한은 오류, 중복 제거)
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, model.Validation(fmt.Sprintf("at least one scope is required (%s)", strings.Join(AllScopes, ", ")))
+	}
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		known := false
+		for _, s := range AllScopes {
+			known = known || s == scope
+		}
+		if !known {
+			return nil, model.Validation(fmt.Sprintf("unknown scope %q", scope))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// CreateToken 함수 정의 (토큰 원문은 이때 한 번만 반환, expiresIn이 0이면 만료 없음)
+func (s *APITokenService) CreateToken(userID int, name string, scopes []string, expiresIn time.Duration) (*model.APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", model.Validation("name is required")
+	}
+	if expiresIn < 0 {
+		return nil, "", model.Validation("expiry must not be negative")
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	raw := APITokenPrefix + secret
+
+	now := time.Now()
+	token := &model.APIToken{
+		UserID:      userID,
+		Name:        name,
+		Prefix:      raw[:len(APITokenPrefix)+8],
+		TokenHash:   hashToken(raw),
+		Scopes:      scopes,
+		CreatedTime: now,
+	}
+	if expiresIn > 0 {
+		expires := now.Add(expiresIn)
+		token.ExpiresTime = &expires
+	}
+	id, err := s.Repo.Create(token)
+	if err != nil {
+		return nil, "", err
+	}
+	token.ID = id
+	return token, raw, nil
+}
+
+// GetTokens 함수 정의
+func (s *APITokenService) GetTokens(userID int) ([]*model.APIToken, error) {
+	return s.Repo.GetByUserID(userID)
+}
+
+// RevokeToken 함수 정의
+func (s *APITokenService) RevokeToken(userID, id int) error {
+	ok, err := s.Repo.Delete(userID, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.NotFound("API token not found").Wrap(sql.ErrNoRows)
+	}
+	return nil
+}
+
+// Authenticate 함수 정의 (유효한 토큰이면 토큰 정보 반환, 마지막 사용 시각 갱신)
+func (s *APITokenService) Authenticate(raw string) (*model.APIToken, error) {
+	token, err := s.Repo.GetByHash(hashToken(raw))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token.ExpiresTime != nil && now.After(*token.ExpiresTime) {
+		return nil, ErrInvalidToken
+	}
+	if err := s.Repo.TouchLastUsed(token.ID, now); err != nil {
+		return nil, err
+	}
+	token.LastUsedTime = &now
+	return token, nil
+}
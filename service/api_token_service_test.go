@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestAPITokenService(t *testing.T) *APITokenService {
+	return NewAPITokenService(repository.NewAPITokenRepository(newTestDB(t)))
+}
+
+func TestCreateTokenValidatesScopes(t *testing.T) {
+	tokens := newTestAPITokenService(t)
+
+	token, raw, err := tokens.CreateToken(1, " backup script ", []string{"Notes:Read", "notes:read", " ai:analyze "}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Name != "backup script" || strings.Join(token.Scopes, ",") != "notes:read,ai:analyze" || token.ExpiresTime != nil {
+		t.Fatalf("token = %+v, want normalized scopes without expiry", token)
+	}
+	if !IsAPIToken(raw) || !strings.HasPrefix(raw, token.Prefix) || token.TokenHash == raw {
+		t.Fatalf("raw token %q with prefix %q, want a %s token stored only as a hash", raw, token.Prefix, APITokenPrefix)
+	}
+
+	for name, tt := range map[string]struct {
+		tokenName string
+		scopes    []string
+		expiresIn time.Duration
+	}{
+		"no scopes":       {"script", nil, 0},
+		"unknown scope":   {"script", []string{"notes:read", "notes:admin"}, 0},
+		"empty name":      {"  ", []string{"notes:read"}, 0},
+		"negative expiry": {"script", []string{"notes:read"}, -time.Hour},
+	} {
+		var validation *model.Error
+		if _, _, err := tokens.CreateToken(1, tt.tokenName, tt.scopes, tt.expiresIn); !errors.As(err, &validation) || validation.Code != model.ErrCodeValidation {
+			t.Errorf("%s: %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestAuthenticateAPIToken(t *testing.T) {
+	tokens := newTestAPITokenService(t)
+	created, raw, err := tokens.CreateToken(1, "script", []string{ScopeNotesRead}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.Authenticate(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != created.ID || token.UserID != 1 || len(token.Scopes) != 1 || token.Scopes[0] != ScopeNotesRead {
+		t.Fatalf("authenticated token = %+v", token)
+	}
+	listed, err := tokens.GetTokens(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].LastUsedTime == nil {
+		t.Fatalf("tokens = %+v, want the last use recorded", listed)
+	}
+
+	if _, err := tokens.Authenticate(raw + "x"); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("unknown token: %v, want ErrInvalidToken", err)
+	}
+	if err := tokens.RevokeToken(2, created.ID); !errors.Is(err, model.NotFound("API token not found")) {
+		t.Fatalf("revoke by another user: %v, want not found", err)
+	}
+	if err := tokens.RevokeToken(1, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Authenticate(raw); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("revoked token: %v, want ErrInvalidToken", err)
+	}
+
+	_, expired, err := tokens.CreateToken(1, "short", []string{ScopeNotesRead}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err := tokens.Authenticate(expired); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired token: %v, want ErrInvalidToken", err)
+	}
+}
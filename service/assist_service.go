@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"myapp/model"
+	"myapp/utils"
+	"strings"
+)
+
+// 글쓰기 도우미 동작 (프롬프트 템플릿 이름은 "assist_" + 동작)
+var assistActions = map[string]bool{
+	"rewrite":     true,
+	"shorten":     true,
+	"expand":      true,
+	"fix_grammar": true,
+	"continue":    true,
+}
+
+// AssistProposal 구조체 정의 (적용 전 제안 내용과 현재 내용의 diff)
+type AssistProposal struct {
+	NoteID          int              `json:"note_id"`
+	Action          string           `json:"action"`
+	BaseRevision    int              `json:"base_revision"`
+	CurrentContent  string           `json:"current_content"`
+	ProposedContent string           `json:"proposed_content"`
+	Diff            []utils.DiffLine `json:"diff"`
+	UnifiedDiff     string           `json:"unified_diff"`
+}
+
+// AssistService 구조체 정의
+type AssistService struct {
+	Notes   *NoteService
+	Prompts *PromptService
+	Gemini  *GeminiService
+}
+
+// NewAssistService 함수 정의
+func NewAssistService(notes *NoteService, prompts *PromptService, gemini *GeminiService) *AssistService {
+	return &AssistService{Notes: notes, Prompts: prompts, Gemini: gemini}
+}
+
+// ValidateAction 함수 정의
+func ValidateAction(action string) error {
+	if !assistActions[action] {
+		return model.Validation(fmt.Sprintf("unknown assist action %q (rewrite, shorten, expand, fix_grammar, continue)", action))
+	}
+	return nil
+}
+
+// Propose 함수 정의 (노트는 바꾸지 않고 제안만 생성)
+func (s *AssistService) Propose(ctx context.Context, note *model.Note, action, instructions string, overrides GenerationOverrides) (*AssistProposal, error) {
+	if err := ValidateAction(action); err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.Prompts.RenderForNote("assist_"+action, note, instructions, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = WithUsageInfo(ctx, UsageInfo{NoteID: note.ID})
+	generated, err := s.Gemini.GenerateText(ctx, "assist_"+action, prompt, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed := generated
+	if action == "continue" {
+		proposed = strings.TrimRight(note.Content, "\n") + "\n" + generated
+	}
+
+	baseRevision, err := s.Notes.Revisions.LatestRevision(note.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := utils.DiffLines(note.Content, proposed)
+	return &AssistProposal{
+		NoteID:          note.ID,
+		Action:          action,
+		BaseRevision:    baseRevision,
+		CurrentContent:  note.Content,
+		ProposedContent: proposed,
+		Diff:            diff,
+		UnifiedDiff:     utils.UnifiedDiff(diff),
+	}, nil
+}
+
+// ErrStaleProposal 제안 이후 노트가 바뀌었을 때 반환
+var ErrStaleProposal = model.Conflict("note has changed since the proposal was created")
+
+// Apply 함수 정의 (제안 내용을 새 리비전으로 저장, 제안의 baseRevision 이후 노트가 바뀌었으면 거부, version이 0보다 크면 그 버전일 때만 저장)
+func (s *AssistService) Apply(scope model.Scope, noteID, version int, content string, baseRevision int) (*model.Note, error) {
+	note, err := s.Notes.GetNoteByID(scope, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.Notes.Revisions.LatestRevision(noteID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != baseRevision {
+		return nil, ErrStaleProposal
+	}
+	if version == 0 {
+		version = note.Version
+	}
+
+	// 제목과 이미지는 그대로 유지
+	return s.Notes.UpdateNoteIfVersion(scope, noteID, version, note.Title, content, note.Img)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"myapp/model"
+	"myapp/repository"
+	"myapp/utils"
+	"os"
+	"strings"
+	"time"
+)
+
+// 썸네일 긴 변 길이 (픽셀)
+const thumbnailSize = 256
+
+// AttachmentService 구조체 정의
+type AttachmentService struct {
+	Repo      *repository.AttachmentRepository
+	NoteRepo  *repository.NoteRepository
+	Extractor TextExtractor
+	Jobs      *JobService
+}
+
+// NewAttachmentService 함수 정의
+func NewAttachmentService(repo *repository.AttachmentRepository, noteRepo *repository.NoteRepository, extractor TextExtractor, jobs *JobService) *AttachmentService {
+	return &AttachmentService{Repo: repo, NoteRepo: noteRepo, Extractor: extractor, Jobs: jobs}
+}
+
+// UploadAttachment 함수 정의 (이미지 저장 후 텍스트 추출, 작업 큐가 있으면 비동기 처리)
+func (s *AttachmentService) UploadAttachment(ctx context.Context, scope model.Scope, noteID int, filename string, file io.Reader) (*model.Attachment, error) {
+	if _, err := s.NoteRepo.GetByID(scope, noteID); err != nil {
+		return nil, err
+	}
+
+	imgFormat := detectImageFormat(filename)
+	if imgFormat == "" {
+		return nil, model.Validation("unsupported image format")
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	// 썸네일 작업에서 디코딩할 수 있는 형식은 저장하기 전에 크기 확인
+	if err := utils.CheckImageSize(bytes.NewReader(data)); errors.Is(err, utils.ErrImageTooLarge) {
+		return nil, model.Validation(fmt.Sprintf("image must have at most %d pixels", utils.MaxImagePixels))
+	}
+
+	now := time.Now()
+	storedName := fmt.Sprintf("%d_%d_%s", noteID, now.UnixNano(), filename)
+	path, err := utils.SaveImage(bytes.NewReader(data), storedName)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &model.Attachment{
+		NoteID:      noteID,
+		Filename:    filename,
+		Path:        path,
+		MimeType:    "image/" + imgFormat,
+		CreatedTime: now,
+	}
+
+	// 작업 큐가 없으면 바로 추출 (추출 실패는 업로드 실패로 처리하지 않음)
+	if s.Jobs == nil && s.Extractor != nil {
+		text, err := s.Extractor.ExtractText(WithUsageInfo(ctx, UsageInfo{NoteID: noteID}), imgFormat, data)
+		if err != nil {
+			log.Printf("text extraction failed for %s: %v", filename, err)
+		} else {
+			attachment.ExtractedText = text
+		}
+	}
+
+	id, err := s.Repo.Create(attachment)
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = id
+
+	if s.Jobs != nil {
+		payload := AttachmentJobPayload{AttachmentID: id}
+		if _, err := s.Jobs.Enqueue(scope, JobTypeExtractText, payload); err != nil {
+			log.Printf("could not enqueue text extraction for attachment %d: %v", id, err)
+		}
+		if _, err := s.Jobs.Enqueue(scope, JobTypeThumbnail, payload); err != nil {
+			log.Printf("could not enqueue thumbnail for attachment %d: %v", id, err)
+		}
+	}
+	return attachment, nil
+}
+
+// ExtractAttachmentText 함수 정의 (저장된 첨부 이미지에서 텍스트를 다시 추출)
+func (s *AttachmentService) ExtractAttachmentText(ctx context.Context, id int) (string, error) {
+	if s.Extractor == nil {
+		return "", fmt.Errorf("no text extractor configured")
+	}
+	attachment, err := s.Repo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(attachment.Path)
+	if err != nil {
+		return "", err
+	}
+	ctx = WithUsageInfo(ctx, UsageInfo{NoteID: attachment.NoteID})
+	text, err := s.Extractor.ExtractText(ctx, strings.TrimPrefix(attachment.MimeType, "image/"), data)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Repo.UpdateExtractedText(id, text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// CreateAttachmentThumbnail 함수 정의
+func (s *AttachmentService) CreateAttachmentThumbnail(id int) (string, error) {
+	attachment, err := s.Repo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+	thumbPath, err := utils.CreateThumbnail(attachment.Path, thumbnailSize)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Repo.UpdateThumbnailPath(id, thumbPath); err != nil {
+		return "", err
+	}
+	return thumbPath, nil
+}
+
+// GetAttachmentsByNoteID 함수 정의
+func (s *AttachmentService) GetAttachmentsByNoteID(scope model.Scope, noteID int) ([]*model.Attachment, error) {
+	if _, err := s.NoteRepo.GetByID(scope, noteID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetByNoteID(noteID)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"testing"
+)
+
+// fakeTextExtractor 구조체 정의 (고정된 결과 반환)
+type fakeTextExtractor struct {
+	Text  string
+	Err   error
+	Calls int
+}
+
+// ExtractText 함수 정의
+func (e *fakeTextExtractor) ExtractText(ctx context.Context, imgFormat string, data []byte) (string, error) {
+	e.Calls++
+	if e.Err != nil {
+		return "", e.Err
+	}
+	return e.Text, nil
+}
+
+func newTestAttachmentService(t *testing.T, extractor TextExtractor) (*AttachmentService, *NoteService) {
+	t.Helper()
+	chdirTemp(t)
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	return NewAttachmentService(repository.NewAttachmentRepository(db), notes.Repo, extractor, nil), notes
+}
+
+func TestUploadAttachmentIndexesExtractedText(t *testing.T) {
+	extractor := &fakeTextExtractor{Text: "receipt total 42 kiwi"}
+	attachments, notes := newTestAttachmentService(t, extractor)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "groceries", "weekly shopping", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachment, err := attachments.UploadAttachment(context.Background(), scope, note.ID, "receipt.png", strings.NewReader("png bytes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extractor.Calls != 1 {
+		t.Fatalf("extractor called %d times, want 1", extractor.Calls)
+	}
+	if attachment.ExtractedText != extractor.Text {
+		t.Fatalf("extracted text = %q, want %q", attachment.ExtractedText, extractor.Text)
+	}
+
+	// 제목과 내용에 없는 단어도 첨부 이미지의 텍스트로 검색됨
+	found, err := notes.SearchNotes(scope, "kiwi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != note.ID {
+		t.Fatalf("search found %v, want note %d", found, note.ID)
+	}
+
+	// 다른 사용자의 검색에는 나오지 않음
+	found, err = notes.SearchNotes(model.Scope{UserID: 2}, "kiwi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Fatalf("other user's search found %d notes, want 0", len(found))
+	}
+}
+
+func TestUploadAttachmentKeepsFileWhenExtractionFails(t *testing.T) {
+	extractor := &fakeTextExtractor{Err: errors.New("model unavailable")}
+	attachments, notes := newTestAttachmentService(t, extractor)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "scan", "page", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachment, err := attachments.UploadAttachment(context.Background(), scope, note.ID, "scan.jpg", strings.NewReader("jpeg bytes"))
+	if err != nil {
+		t.Fatalf("upload failed with extraction error: %v", err)
+	}
+	if attachment.ID == 0 || attachment.ExtractedText != "" {
+		t.Fatalf("attachment = %+v, want stored without text", attachment)
+	}
+}
+
+func TestUploadAttachmentRejectsUnsupportedFormat(t *testing.T) {
+	extractor := &fakeTextExtractor{Text: "unused"}
+	attachments, notes := newTestAttachmentService(t, extractor)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "doc", "text", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = attachments.UploadAttachment(context.Background(), scope, note.ID, "notes.txt", strings.NewReader("plain"))
+	var appErr *model.Error
+	if !errors.As(err, &appErr) || appErr.Code != model.ErrCodeValidation {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+	if extractor.Calls != 0 {
+		t.Fatalf("extractor called for unsupported format")
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"time"
+)
+
+// 감사 기록 동작
+const (
+	AuditNoteCreate       = "note.create"
+	AuditNoteUpdate       = "note.update"
+	AuditNoteRestore      = "note.restore"
+	AuditNoteDelete       = "note.delete"
+	AuditNoteMerge        = "note.merge"
+	AuditNoteMove         = "note.move"
+	AuditNoteAnalyze      = "note.analyze"
+	AuditNoteAssistApply  = "note.assist_apply"
+	AuditTaskUpdate       = "task.update"
+	AuditShareCreate      = "share.create"
+	AuditShareDelete      = "share.delete"
+	AuditShareLinkCreate  = "share_link.create"
+	AuditShareLinkRevoke  = "share_link.revoke"
+	AuditWorkspaceCreate  = "workspace.create"
+	AuditMemberUpdate     = "workspace.member_update"
+	AuditMemberRemove     = "workspace.member_remove"
+	AuditInvitationCreate = "workspace.invitation_create"
+	AuditInvitationRevoke = "workspace.invitation_revoke"
+	AuditInvitationAccept = "workspace.invitation_accept"
+	AuditAPITokenCreate   = "api_token.create"
+	AuditAPITokenRevoke   = "api_token.revoke"
+	AuditPromptCreate     = "prompt_template.create"
+	AuditPromptUpdate     = "prompt_template.update"
+	AuditPromptDelete     = "prompt_template.delete"
+	AuditWebhookCreate    = "webhook.create"
+	AuditWebhookUpdate    = "webhook.update"
+	AuditWebhookDelete    = "webhook.delete"
+	AuditWebhookReplay    = "webhook.replay"
+)
+
+// 감사 대상 종류
+const (
+	AuditTargetNote      = "note"
+	AuditTargetTask      = "task"
+	AuditTargetWorkspace = "workspace"
+	AuditTargetAPIToken  = "api_token"
+	AuditTargetPrompt    = "prompt_template"
+	AuditTargetWebhook   = "webhook"
+)
+
+// AuditService 구조체 정의
+type AuditService struct {
+	Repo *repository.AuditRepository
+}
+
+// NewAuditService 함수 정의
+func NewAuditService(repo *repository.AuditRepository) *AuditService {
+	return &AuditService{Repo: repo}
+}
+
+// NoteHash 함수 정의 (노트 상태의 SHA-256, nil이면 빈 문자열)
+func NoteHash(note *model.Note) string {
+	if note == nil {
+		return ""
+	}
+	return hashFields(note.Title, note.Content, note.Img)
+}
+
+// PromptHash 함수 정의 (프롬프트 템플릿 상태의 SHA-256, nil이면 빈 문자열)
+func PromptHash(tmpl *model.PromptTemplate) string {
+	if tmpl == nil {
+		return ""
+	}
+	return hashFields(tmpl.Name, tmpl.Description, tmpl.Template)
+}
+
+func hashFields(fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Record 함수 정의 (변경이 커밋된 뒤 별도로 기록하며, 기록 실패가 요청을 실패시키지 않도록 로그만 남김)
+//
+// 감사 기록은 최선 노력(best effort)임. 변경과 같은 트랜잭션이 아니므로 기록에 실패하거나 그 사이 서버가 멈추면
+// 변경은 남고 기록은 빠질 수 있음. 빠진 기록은 서버 로그의 "audit: could not record"로 확인.
+func (s *AuditService) Record(entry *model.AuditEntry) {
+	entry.CreatedTime = time.Now().UTC()
+	if _, err := s.Repo.Create(entry); err != nil {
+		log.Printf("audit: could not record %s on %s %d: %v", entry.Action, entry.TargetType, entry.TargetID, err)
+	}
+}
+
+// Query 함수 정의
+func (s *AuditService) Query(filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	return s.Repo.Query(filter)
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 인증 오류
+var (
+	ErrInvalidCredentials = model.Unauthorized("invalid email or password")
+	ErrEmailTaken         = model.Conflict("email is already registered")
+	ErrInvalidToken       = model.Unauthorized("invalid or expired token")
+)
+
+// 비밀번호 길이 제한 (bcrypt는 72바이트까지만 사용하므로 그보다 긴 비밀번호는 거부)
+const (
+	minPasswordLength = 8
+	maxPasswordBytes  = 72
+)
+
+// StreamTokenTTL 이벤트 스트림 토큰 유효 시간 (브라우저는 EventSource, WebSocket에 헤더를 붙일 수 없어 쿼리로 보내므로 짧게 유지)
+const StreamTokenTTL = time.Minute
+
+// streamTokenAudience 스트림 토큰의 aud, 액세스 토큰과 서로 바꿔 쓸 수 없도록 구분
+const streamTokenAudience = "events"
+
+// streamClaims 구조체 정의 (발급할 때 확인한 워크스페이스 포함)
+type streamClaims struct {
+	jwt.RegisteredClaims
+	WorkspaceID int `json:"workspace_id,omitempty"`
+}
+
+// AuthTokens 구조체 정의 (로그인, 갱신 응답)
+type AuthTokens struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// AuthService 구조체 정의
+type AuthService struct {
+	Repo       *repository.UserRepository
+	Notes      *repository.NoteRepository
+	Secret     []byte
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// NewAuthService 함수 정의
+func NewAuthService(repo *repository.UserRepository, notes *repository.NoteRepository, secret string, accessTTL, refreshTTL time.Duration) *AuthService {
+	return &AuthService{Repo: repo, Notes: notes, Secret: []byte(secret), AccessTTL: accessTTL, RefreshTTL: refreshTTL}
+}
+
+// SetAdmin 함수 정의 (운영자 명령으로만 호출, 가입한 이메일만으로는 관리자 권한을 얻을 수 없음)
+func (s *AuthService) SetAdmin(email string, admin bool) error {
+	ok, err := s.Repo.SetAdmin(strings.ToLower(strings.TrimSpace(email)), admin)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.NotFound("user not found")
+	}
+	return nil
+}
+
+// CountOrphanedNotes 함수 정의 (사용자 계정 도입 전에 만들어져 소유자가 없는 노트 수)
+func (s *AuthService) CountOrphanedNotes() (int, error) {
+	return s.Notes.CountOrphans()
+}
+
+// ClaimOrphanedNotes 함수 정의 (운영자 명령으로만 호출, 소유자 없는 노트를 email 계정의 개인 노트로 넘김)
+func (s *AuthService) ClaimOrphanedNotes(email string) (int64, error) {
+	user, err := s.Repo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return 0, err
+	}
+	return s.Notes.ClaimOrphans(user.ID)
+}
+
+// Signup 함수 정의 (비밀번호는 bcrypt 해시로 저장)
+func (s *AuthService) Signup(email, password string) (*model.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, model.Validation("invalid email address")
+	}
+	if len(password) < minPasswordLength {
+		return nil, model.Validation(fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+	}
+	if len(password) > maxPasswordBytes {
+		return nil, model.Validation(fmt.Sprintf("password must be at most %d bytes", maxPasswordBytes))
+	}
+
+	if _, err := s.Repo.GetByEmail(email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &model.User{
+		Email:        email,
+		PasswordHash: string(hash),
+		CreatedTime:  time.Now(),
+	}
+	// 조회와 저장 사이에 같은 이메일로 가입한 경우 UNIQUE 제약 위반으로 확인
+	id, err := s.Repo.Create(user)
+	if errors.Is(err, ErrEmailTaken) {
+		return nil, ErrEmailTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.ID = id
+	return user, nil
+}
+
+// Login 함수 정의
+func (s *AuthService) Login(email, password string) (*AuthTokens, error) {
+	user, err := s.Repo.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return s.issueTokens(user.ID)
+}
+
+// Refresh 함수 정의 (리프레시 토큰은 한 번만 사용 가능, 새 토큰 쌍 발급)
+func (s *AuthService) Refresh(refreshToken string) (*AuthTokens, error) {
+	token, err := s.Repo.GetRefreshToken(hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if token.RevokedTime != nil || time.Now().After(token.ExpiresTime) {
+		return nil, ErrInvalidToken
+	}
+
+	ok, err := s.Repo.RevokeRefreshToken(token.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return s.issueTokens(token.UserID)
+}
+
+// Logout 함수 정의 (리프레시 토큰 폐기)
+func (s *AuthService) Logout(refreshToken string) error {
+	token, err := s.Repo.GetRefreshToken(hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.Repo.RevokeRefreshToken(token.ID, time.Now())
+	return err
+}
+
+// GetUser 함수 정의
+func (s *AuthService) GetUser(id int) (*model.User, error) {
+	return s.Repo.GetByID(id)
+}
+
+// IsAdmin 함수 정의 (운영자가 grant-admin 명령으로 지정한 사용자인지 확인)
+func (s *AuthService) IsAdmin(userID int) (bool, error) {
+	user, err := s.Repo.GetByID(userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin, nil
+}
+
+// ParseAccessToken 함수 정의 (유효한 토큰이면 사용자 ID 반환)
+func (s *AuthService) ParseAccessToken(tokenString string) (int, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	// 스트림 토큰처럼 aud가 있는 토큰은 액세스 토큰으로 쓸 수 없음
+	if err != nil || len(claims.Audience) > 0 {
+		return 0, ErrInvalidToken
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return userID, nil
+}
+
+// IssueStreamToken 함수 정의 (이벤트 스트림 연결에만 쓰는 짧은 토큰, workspaceID가 0이면 개인 노트)
+func (s *AuthService) IssueStreamToken(userID, workspaceID int) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(StreamTokenTTL)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, streamClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			Audience:  jwt.ClaimStrings{streamTokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		WorkspaceID: workspaceID,
+	}).SignedString(s.Secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ParseStreamToken 함수 정의 (유효한 스트림 토큰이면 사용자 ID와 워크스페이스 ID 반환)
+func (s *AuthService) ParseStreamToken(tokenString string) (int, int, error) {
+	claims := &streamClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(), jwt.WithAudience(streamTokenAudience))
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+	return userID, claims.WorkspaceID, nil
+}
+
+// issueTokens 함수 정의 (액세스 토큰은 JWT, 리프레시 토큰은 임의 문자열)
+func (s *AuthService) issueTokens(userID int) (*AuthTokens, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.AccessTTL)
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   strconv.Itoa(userID),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString(s.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.Repo.CreateRefreshToken(&model.RefreshToken{
+		UserID:      userID,
+		TokenHash:   hashToken(refreshToken),
+		ExpiresTime: now.Add(s.RefreshTTL),
+		CreatedTime: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// randomToken 함수 정의 (32바이트 임의 값의 16진 문자열)
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken 함수 정의 (토큰은 SHA-256 해시로만 저장)
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
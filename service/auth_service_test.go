@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestAuthService(t *testing.T) (*AuthService, *repository.NoteRepository) {
+	db := newTestDB(t)
+	notes := repository.NewNoteRepository(db)
+	return NewAuthService(repository.NewUserRepository(db), notes, strings.Repeat("k", 32), time.Minute, time.Hour), notes
+}
+
+func TestOrphanedNotesAreOnlyAssignedByTheOperator(t *testing.T) {
+	auth, notes := newTestAuthService(t)
+	// 계정 도입 전 스키마로 만든 노트 (owner_id 없음)
+	if _, err := notes.DB.Exec("INSERT INTO notes (img, title, content, created_time) VALUES ('', 'old', 'from before accounts', ?)", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 가입만으로는 넘겨받지 않음
+	owner, err := auth.Signup("admin@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found, _ := notes.GetAll(model.Scope{UserID: owner.ID}); len(found) != 0 {
+		t.Fatalf("signup received %d orphaned notes", len(found))
+	}
+	if count, err := auth.CountOrphanedNotes(); err != nil || count != 1 {
+		t.Fatalf("orphaned notes = %d (err %v), want 1", count, err)
+	}
+
+	claimed, err := auth.ClaimOrphanedNotes("Admin@Example.com")
+	if err != nil || claimed != 1 {
+		t.Fatalf("claimed %d notes (err %v), want 1", claimed, err)
+	}
+	found, err := notes.GetAll(model.Scope{UserID: owner.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Title != "old" {
+		t.Fatalf("owner notes = %v, want the orphaned note", found)
+	}
+	if _, err := auth.ClaimOrphanedNotes("nobody@example.com"); !errors.Is(err, model.NotFound("user not found")) {
+		t.Fatalf("claim for an unknown account: %v", err)
+	}
+}
+
+func TestAdminRightsComeFromTheOperatorFlag(t *testing.T) {
+	auth, _ := newTestAuthService(t)
+	user, err := auth.Signup("admin@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isAdmin, err := auth.IsAdmin(user.ID); err != nil || isAdmin {
+		t.Fatalf("new account is admin = %v (err %v)", isAdmin, err)
+	}
+
+	if err := auth.SetAdmin(" ADMIN@example.com", true); err != nil {
+		t.Fatal(err)
+	}
+	if isAdmin, err := auth.IsAdmin(user.ID); err != nil || !isAdmin {
+		t.Fatalf("granted account is admin = %v (err %v)", isAdmin, err)
+	}
+	if err := auth.SetAdmin("admin@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	if isAdmin, _ := auth.IsAdmin(user.ID); isAdmin {
+		t.Fatal("revoked account is still admin")
+	}
+	if err := auth.SetAdmin("nobody@example.com", true); !errors.Is(err, model.NotFound("user not found")) {
+		t.Fatalf("grant for an unknown account: %v", err)
+	}
+}
+
+func TestSignupRejectsPasswordsBcryptWouldTruncate(t *testing.T) {
+	auth, _ := newTestAuthService(t)
+	_, err := auth.Signup("user@example.com", strings.Repeat("p", maxPasswordBytes+1))
+	var modelErr *model.Error
+	if !errors.As(err, &modelErr) || modelErr.Code != model.ErrCodeValidation {
+		t.Fatalf("signup with a %d byte password: %v, want a validation error", maxPasswordBytes+1, err)
+	}
+	if _, err := auth.Signup("user@example.com", strings.Repeat("p", maxPasswordBytes)); err != nil {
+		t.Fatalf("signup with a %d byte password: %v", maxPasswordBytes, err)
+	}
+}
+
+func TestConcurrentSignupsWithTheSameEmailConflict(t *testing.T) {
+	auth, _ := newTestAuthService(t)
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = auth.Signup("same@example.com", "password123")
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrEmailTaken):
+			t.Fatalf("signup error = %v, want ErrEmailTaken", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("%d signups succeeded, want 1", created)
+	}
+}
+
+func TestAccessTokenRoundTrip(t *testing.T) {
+	auth, _ := newTestAuthService(t)
+	user, err := auth.Signup("user@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := auth.Login("user@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID, err := auth.ParseAccessToken(tokens.AccessToken); err != nil || userID != user.ID {
+		t.Fatalf("parsed user %d (err %v), want %d", userID, err, user.ID)
+	}
+
+	other := NewAuthService(auth.Repo, auth.Notes, strings.Repeat("x", 32), time.Minute, time.Hour)
+	if _, err := other.ParseAccessToken(tokens.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("token signed with another key: %v", err)
+	}
+	expired := NewAuthService(auth.Repo, auth.Notes, string(auth.Secret), -time.Minute, time.Hour)
+	old, err := expired.Login("user@example.com", "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.ParseAccessToken(old.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expired token: %v", err)
+	}
+}
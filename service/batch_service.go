@@ -0,0 +1,147 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"myapp/model"
+)
+
+// 일괄 처리 연산 종류
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+	BatchOpMove   = "move"
+)
+
+// MaxBatchOperations 요청 하나에 담을 수 있는 최대 연산 수
+const MaxBatchOperations = 100
+
+// ErrBatchAborted atomic 모드에서 다른 연산이 실패해 함께 취소된 연산의 오류
+var ErrBatchAborted = model.Conflict("not applied because another operation in the batch failed")
+
+// BatchOperation 구조체 정의 (Version이 0이 아니면 그 버전일 때만 수정, 삭제, 이동)
+type BatchOperation struct {
+	Op          string
+	ID          int
+	Version     int
+	Title       string
+	Content     string
+	Img         string
+	WorkspaceID int
+}
+
+// BatchResult 구조체 정의 (Err가 nil이면 성공, Before는 감사 기록용 변경 전 노트)
+type BatchResult struct {
+	Op     string
+	Note   *model.Note
+	Before *model.Note
+	Err    error
+}
+
+// BatchService 구조체 정의
+type BatchService struct {
+	DB         *sql.DB
+	Notes      *NoteService
+	Workspaces *WorkspaceService
+}
+
+// NewBatchService 함수 정의
+func NewBatchService(db *sql.DB, notes *NoteService, workspaces *WorkspaceService) *BatchService {
+	return &BatchService{DB: db, Notes: notes, Workspaces: workspaces}
+}
+
+// Execute 함수 정의 (한 트랜잭션에서 실행, atomic이면 하나라도 실패할 때 전체 취소하고 false 반환, 아니면 실패한 연산만 취소)
+func (s *BatchService) Execute(scope model.Scope, ops []BatchOperation, atomic bool) ([]BatchResult, bool, error) {
+	// 이동 대상 워크스페이스 권한은 트랜잭션을 시작하기 전에 확인
+	targets := map[int]error{}
+	for _, op := range ops {
+		if op.Op == BatchOpMove && op.WorkspaceID != 0 {
+			if _, ok := targets[op.WorkspaceID]; !ok {
+				targets[op.WorkspaceID] = s.checkMoveTarget(scope.UserID, op.WorkspaceID)
+			}
+		}
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+	notes := s.Notes.WithTx(tx)
+
+	results := make([]BatchResult, len(ops))
+	for i, op := range ops {
+		if !atomic {
+			if _, err := tx.Exec("SAVEPOINT batch_item"); err != nil {
+				return nil, false, err
+			}
+		}
+
+		mark := notes.Events.mark()
+		results[i] = s.apply(notes, scope, op, targets[op.WorkspaceID])
+
+		if results[i].Err != nil && atomic {
+			for j := range results {
+				if j != i {
+					results[j] = BatchResult{Op: ops[j].Op, Err: ErrBatchAborted}
+				}
+			}
+			return results, false, nil
+		}
+		if !atomic {
+			if results[i].Err != nil {
+				if _, err := tx.Exec("ROLLBACK TO batch_item"); err != nil {
+					return nil, false, err
+				}
+				notes.Events.discard(mark)
+			}
+			if _, err := tx.Exec("RELEASE batch_item"); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	notes.Events.Flush()
+	return results, true, nil
+}
+
+// checkMoveTarget 함수 정의 (대상 워크스페이스의 member 이상이어야 이동 가능)
+func (s *BatchService) checkMoveTarget(userID, workspaceID int) error {
+	role, err := s.Workspaces.GetRole(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if !model.WorkspaceRoleAtLeast(role, model.WorkspaceRoleMember) {
+		return ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+// apply 함수 정의 (연산 하나 실행, targetErr는 이동 대상 워크스페이스 권한 확인 결과)
+func (s *BatchService) apply(notes *NoteService, scope model.Scope, op BatchOperation, targetErr error) BatchResult {
+	result := BatchResult{Op: op.Op}
+	switch op.Op {
+	case BatchOpCreate:
+		result.Note, result.Err = notes.CreateNote(scope, op.Title, op.Content, op.Img)
+	case BatchOpUpdate:
+		result.Before, _, _ = notes.GetAccessibleNote(scope, op.ID)
+		result.Note, result.Err = notes.UpdateNoteIfVersion(scope, op.ID, op.Version, op.Title, op.Content, op.Img)
+	case BatchOpDelete:
+		result.Before, _ = notes.GetNoteByID(scope, op.ID)
+		result.Err = notes.DeleteNoteIfVersion(scope, op.ID, op.Version)
+	case BatchOpMove:
+		if targetErr != nil {
+			result.Err = targetErr
+			break
+		}
+		result.Before, _ = notes.GetNoteByID(scope, op.ID)
+		result.Note, result.Err = notes.MoveNote(scope, op.ID, op.Version, op.WorkspaceID)
+	default:
+		result.Err = model.Validation(fmt.Sprintf("unknown op %q", op.Op))
+	}
+	return result
+}
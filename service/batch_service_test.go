@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"testing"
+)
+
+// receivedEvents 함수 정의 (구독으로 이미 전달된 이벤트 종류, 기다리지 않음)
+func receivedEvents(sub *Subscription) []string {
+	var types []string
+	for {
+		select {
+		case event := <-sub.Events:
+			types = append(types, event.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func newTestBatchService(t *testing.T) (*BatchService, *NoteService) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	return NewBatchService(db, notes, nil), notes
+}
+
+func TestAtomicBatchRollsBackEveryOperation(t *testing.T) {
+	batch, notes := newTestBatchService(t)
+	scope := model.Scope{UserID: 1}
+	note, err := notes.CreateNote(scope, "kept", "original", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := notes.Events.Subscribe(scope, model.NoteEventFilter{})
+	defer notes.Events.Unsubscribe(sub)
+
+	results, committed, err := batch.Execute(scope, []BatchOperation{
+		{Op: BatchOpCreate, Title: "new", Content: "created in the batch"},
+		{Op: BatchOpUpdate, ID: note.ID, Title: "kept", Content: "changed"},
+		{Op: BatchOpDelete, ID: note.ID, Version: note.Version + 5},
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committed {
+		t.Fatal("atomic batch with a failing operation was committed")
+	}
+	if !errors.Is(results[0].Err, ErrBatchAborted) || !errors.Is(results[1].Err, ErrBatchAborted) || !errors.Is(results[2].Err, ErrVersionMismatch) {
+		t.Fatalf("results = %v, %v, %v; want two aborted and a version mismatch", results[0].Err, results[1].Err, results[2].Err)
+	}
+
+	all, err := notes.GetAllNotes(scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Content != "original" || all[0].Version != note.Version {
+		t.Fatalf("notes after rollback = %+v, want only the untouched note", all)
+	}
+	if history, _ := notes.Events.History(scope, model.NoteEventFilter{}); len(history) != 1 {
+		t.Fatalf("stored events after rollback = %d, want only the earlier note.created", len(history))
+	}
+	if got := receivedEvents(sub); len(got) != 0 {
+		t.Fatalf("subscriber received %v from a rolled back batch", got)
+	}
+}
+
+func TestBestEffortBatchKeepsSuccessfulOperations(t *testing.T) {
+	batch, notes := newTestBatchService(t)
+	scope := model.Scope{UserID: 1}
+	note, err := notes.CreateNote(scope, "kept", "original", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := notes.Events.Subscribe(scope, model.NoteEventFilter{})
+	defer notes.Events.Unsubscribe(sub)
+
+	results, committed, err := batch.Execute(scope, []BatchOperation{
+		{Op: BatchOpCreate, Title: "new", Content: "created in the batch"},
+		{Op: BatchOpUpdate, ID: note.ID, Version: note.Version + 5, Title: "stale", Content: "stale"},
+		{Op: BatchOpDelete, ID: note.ID + 100},
+		{Op: BatchOpUpdate, ID: note.ID, Version: note.Version, Title: "kept", Content: "changed"},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !committed {
+		t.Fatal("best-effort batch was not committed")
+	}
+	if results[0].Err != nil || results[3].Err != nil {
+		t.Fatalf("successful operations failed: %v, %v", results[0].Err, results[3].Err)
+	}
+	if !errors.Is(results[1].Err, ErrVersionMismatch) || !errors.Is(results[2].Err, model.NotFound("note not found")) {
+		t.Fatalf("failed operations = %v, %v; want a version mismatch and not found", results[1].Err, results[2].Err)
+	}
+
+	current, err := notes.GetNoteByID(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Content != "changed" || current.Version != note.Version+1 {
+		t.Fatalf("note = %q version %d, want the later update applied once", current.Content, current.Version)
+	}
+	if got := receivedEvents(sub); len(got) != 2 || got[0] != model.NoteEventCreated || got[1] != model.NoteEventUpdated {
+		t.Fatalf("subscriber received %v, want note.created then note.updated", got)
+	}
+	if history, _ := notes.Events.History(scope, model.NoteEventFilter{}); len(history) != 3 {
+		t.Fatalf("stored events = %d, want the earlier create and the two committed operations", len(history))
+	}
+}
+
+func TestDiscardedEventsAreNeverDelivered(t *testing.T) {
+	db := newTestDB(t)
+	events := NewEventBus(nil)
+	scope := model.Scope{UserID: 1}
+	sub := events.Subscribe(scope, model.NoteEventFilter{})
+	defer events.Unsubscribe(sub)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	pending := events.WithTx(tx)
+	if err := pending.Publish(&model.NoteEvent{Type: model.NoteEventCreated, NoteID: 1, OwnerID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	mark := pending.mark()
+	if err := pending.Publish(&model.NoteEvent{Type: model.NoteEventDeleted, NoteID: 1, OwnerID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := receivedEvents(sub); len(got) != 0 {
+		t.Fatalf("events delivered before Flush: %v", got)
+	}
+
+	pending.discard(mark)
+	pending.Flush()
+	if got := receivedEvents(sub); len(got) != 1 || got[0] != model.NoteEventCreated {
+		t.Fatalf("delivered %v, want only the event before the mark", got)
+	}
+	pending.Flush()
+	if got := receivedEvents(sub); len(got) != 0 {
+		t.Fatalf("second Flush delivered %v again", got)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"myapp/utils"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	shingleSize   = 3
+	signatureSize = 128
+)
+
+// DuplicateGroup 구조체 정의 (중복 후보 묶음)
+type DuplicateGroup struct {
+	Kind       string          `json:"kind"`
+	NoteIDs    []int           `json:"note_ids"`
+	Similarity float64         `json:"similarity"`
+	Pairs      []DuplicatePair `json:"pairs"`
+}
+
+// DuplicatePair 구조체 정의
+type DuplicatePair struct {
+	NoteID      int     `json:"note_id"`
+	OtherNoteID int     `json:"other_note_id"`
+	Similarity  float64 `json:"similarity"`
+}
+
+// DuplicateService 구조체 정의
+type DuplicateService struct {
+	DB          *sql.DB
+	Notes       *NoteService
+	Attachments *repository.AttachmentRepository
+}
+
+// NewDuplicateService 함수 정의
+func NewDuplicateService(db *sql.DB, notes *NoteService, attachments *repository.AttachmentRepository) *DuplicateService {
+	return &DuplicateService{DB: db, Notes: notes, Attachments: attachments}
+}
+
+// noteText 함수 정의 (비교 대상 텍스트: 제목 + 내용)
+func noteText(note *model.Note) string {
+	return note.Title + "\n" + note.Content
+}
+
+// FindDuplicates 함수 정의 (내용 해시가 같은 노트와 MinHash 유사도가 threshold 이상인 노트를 묶음)
+func (s *DuplicateService) FindDuplicates(scope model.Scope, threshold float64) ([]*DuplicateGroup, error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, model.Validation("threshold must be between 0 and 1")
+	}
+
+	notes, err := s.Notes.GetAllNotes(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// 완전 중복: 정규화한 내용의 해시로 묶음
+	var groups []*DuplicateGroup
+	byHash := make(map[string][]int)
+	var hashes []string
+	for _, note := range notes {
+		hash := utils.ContentHash(noteText(note))
+		if _, ok := byHash[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		byHash[hash] = append(byHash[hash], note.ID)
+	}
+	exact := make(map[int]bool)
+	for _, hash := range hashes {
+		ids := byHash[hash]
+		if len(ids) < 2 {
+			continue
+		}
+		group := &DuplicateGroup{Kind: "exact", NoteIDs: ids, Similarity: 1}
+		for _, other := range ids[1:] {
+			group.Pairs = append(group.Pairs, DuplicatePair{NoteID: ids[0], OtherNoteID: other, Similarity: 1})
+			exact[other] = true
+		}
+		groups = append(groups, group)
+	}
+
+	// 유사 중복: 완전 중복의 대표 노트만 비교
+	var candidates []*model.Note
+	var signatures [][]uint64
+	for _, note := range notes {
+		if exact[note.ID] {
+			continue
+		}
+		candidates = append(candidates, note)
+		signatures = append(signatures, utils.MinHash(utils.Shingles(noteText(note), shingleSize), signatureSize))
+	}
+
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type candidatePair struct {
+		i, j       int
+		similarity float64
+	}
+	var pairs []candidatePair
+	for _, pair := range lshCandidatePairs(signatures, threshold) {
+		i, j := pair[0], pair[1]
+		similarity := utils.EstimateJaccard(signatures[i], signatures[j])
+		if similarity < threshold {
+			continue
+		}
+		pairs = append(pairs, candidatePair{i: i, j: j, similarity: similarity})
+		parent[find(i)] = find(j)
+	}
+
+	nearGroups := make(map[int]*DuplicateGroup)
+	var roots []int
+	for _, pair := range pairs {
+		i, j := pair.i, pair.j
+		root := find(i)
+		group, ok := nearGroups[root]
+		if !ok {
+			group = &DuplicateGroup{Kind: "near"}
+			nearGroups[root] = group
+			roots = append(roots, root)
+		}
+		group.Pairs = append(group.Pairs, DuplicatePair{
+			NoteID:      candidates[i].ID,
+			OtherNoteID: candidates[j].ID,
+			Similarity:  pair.similarity,
+		})
+	}
+	for _, root := range roots {
+		group := nearGroups[root]
+		seen := make(map[int]bool)
+		for _, pair := range group.Pairs {
+			for _, id := range []int{pair.NoteID, pair.OtherNoteID} {
+				if !seen[id] {
+					seen[id] = true
+					group.NoteIDs = append(group.NoteIDs, id)
+				}
+			}
+			if pair.Similarity > group.Similarity {
+				group.Similarity = pair.Similarity
+			}
+		}
+		sort.Ints(group.NoteIDs)
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// lshCandidatePairs 함수 정의 (LSH 밴드가 하나라도 같은 서명 쌍만 비교 대상으로 고름, i < j 순으로 정렬)
+func lshCandidatePairs(signatures [][]uint64, threshold float64) [][2]int {
+	rows := utils.LSHRows(signatureSize, threshold)
+	type bucketKey struct {
+		band int
+		hash uint64
+	}
+	buckets := make(map[bucketKey][]int)
+	seen := make(map[[2]int]bool)
+	var pairs [][2]int
+	for j, signature := range signatures {
+		for band, hash := range utils.LSHBands(signature, rows) {
+			key := bucketKey{band: band, hash: hash}
+			for _, i := range buckets[key] {
+				if pair := [2]int{i, j}; !seen[pair] {
+					seen[pair] = true
+					pairs = append(pairs, pair)
+				}
+			}
+			buckets[key] = append(buckets[key], j)
+		}
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a][0] != pairs[b][0] {
+			return pairs[a][0] < pairs[b][0]
+		}
+		return pairs[a][1] < pairs[b][1]
+	})
+	return pairs
+}
+
+// MergeNotes 함수 정의 (sourceIDs 노트의 내용과 첨부를 targetID 노트로 합치고 원본 삭제, 중간에 실패하면 아무것도 바뀌지 않음)
+func (s *DuplicateService) MergeNotes(scope model.Scope, targetID int, sourceIDs []int) (*model.Note, error) {
+	if len(sourceIDs) == 0 {
+		return nil, model.Validation("at least one source note is required")
+	}
+
+	target, err := s.Notes.GetNoteByID(scope, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []*model.Note
+	seen := map[int]bool{targetID: true}
+	for _, id := range sourceIDs {
+		if seen[id] {
+			return nil, model.Validation(fmt.Sprintf("note %d is listed more than once", id))
+		}
+		seen[id] = true
+		source, err := s.Notes.GetNoteByID(scope, id)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	// 완전히 같은 내용은 한 번만 남김
+	contents := []string{target.Content}
+	hashes := map[string]bool{utils.ContentHash(target.Content): true}
+	img := target.Img
+	for _, source := range sources {
+		hash := utils.ContentHash(source.Content)
+		if !hashes[hash] {
+			hashes[hash] = true
+			contents = append(contents, source.Content)
+		}
+		if img == "" {
+			img = source.Img
+		}
+	}
+
+	content := strings.Join(contents, "\n\n---\n\n")
+	if utf8.RuneCountInString(content) > MaxNoteContentLength {
+		return nil, model.Validation(fmt.Sprintf("merged content would be longer than %d characters", MaxNoteContentLength))
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	notes := s.Notes.WithTx(tx)
+	attachments := &repository.AttachmentRepository{DB: tx}
+
+	merged, err := notes.UpdateNoteIfVersion(scope, targetID, target.Version, target.Title, content, img)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		if err := attachments.MoveToNote(source.ID, targetID); err != nil {
+			return nil, err
+		}
+		// 조회 후 바뀐 원본은 삭제하지 않고 전체 취소
+		if err := notes.DeleteNoteIfVersion(scope, source.ID, source.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	notes.Events.Flush()
+	return merged, nil
+}
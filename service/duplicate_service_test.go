@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"testing"
+)
+
+func TestFindDuplicatesGroupsExactAndNearCopies(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	duplicates := NewDuplicateService(db, notes, repository.NewAttachmentRepository(db))
+	scope := model.Scope{UserID: 1}
+
+	base := "meeting notes: discuss the roadmap for the next quarter, assign owners to each milestone and review the budget"
+	create := func(content string) int {
+		note, err := notes.CreateNote(scope, "", content, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return note.ID
+	}
+	original := create(base)
+	copied := create(strings.ToUpper(base))
+	edited := create(base + " before friday")
+	for i := 0; i < 20; i++ {
+		create(fmt.Sprintf("unrelated note %d about groceries, the gym schedule and a book I want to read number %d", i, i*7))
+	}
+
+	groups, err := duplicates.FindDuplicates(scope, 0.7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exact, near *DuplicateGroup
+	for _, group := range groups {
+		switch group.Kind {
+		case "exact":
+			exact = group
+		case "near":
+			near = group
+		}
+	}
+	if exact == nil || len(exact.NoteIDs) != 2 || exact.NoteIDs[0] != original || exact.NoteIDs[1] != copied {
+		t.Fatalf("exact group = %+v, want notes %d and %d", exact, original, copied)
+	}
+	if near == nil || len(near.NoteIDs) != 2 || near.NoteIDs[0] != original || near.NoteIDs[1] != edited || near.Similarity < 0.7 {
+		t.Fatalf("near group = %+v, want notes %d and %d", near, original, edited)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("%d groups, want 2 (unrelated notes must not be grouped)", len(groups))
+	}
+}
+
+func TestMergeNotesRejectsContentOverTheLimit(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	duplicates := NewDuplicateService(db, notes, repository.NewAttachmentRepository(db))
+	scope := model.Scope{UserID: 1}
+
+	target, err := notes.CreateNote(scope, "target", strings.Repeat("a", MaxNoteContentLength/2), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := notes.CreateNote(scope, "source", strings.Repeat("b", MaxNoteContentLength/2), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = duplicates.MergeNotes(scope, target.ID, []int{source.ID})
+	var modelErr *model.Error
+	if !errors.As(err, &modelErr) || modelErr.Code != model.ErrCodeValidation {
+		t.Fatalf("merge over the limit: %v, want a validation error", err)
+	}
+	if _, err := notes.GetNoteByID(scope, source.ID); err != nil {
+		t.Fatalf("source note was deleted by a rejected merge: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"myapp/model"
+	"myapp/repository"
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer 구독자별로 쌓아 둘 수 있는 이벤트 수 (넘치면 구독을 끊음)
+const subscriptionBuffer = 256
+
+// EventBus 구조체 정의 (노트 변경 이벤트를 저장한 뒤 구독자에게 전달, 저장된 이벤트로 끊긴 지점부터 이어받기 가능)
+type EventBus struct {
+	Repo *repository.NoteEventRepository
+
+	hub *eventHub
+	// WithTx 복사본은 커밋 후 Flush할 때까지 전달하지 않고 모아 둠
+	pending *[]*model.NoteEvent
+}
+
+// eventHub 구조체 정의 (EventBus와 트랜잭션 복사본이 함께 쓰는 구독자 목록)
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	listeners   []chan struct{}
+}
+
+// Subscription 구조체 정의 (Events는 버퍼가 넘치거나 Unsubscribe하면 닫힘, 닫히면 마지막 ID로 다시 구독)
+type Subscription struct {
+	Events <-chan *model.NoteEvent
+
+	events chan *model.NoteEvent
+	scope  model.Scope
+	filter model.NoteEventFilter
+}
+
+// NewEventBus 함수 정의
+func NewEventBus(repo *repository.NoteEventRepository) *EventBus {
+	return &EventBus{Repo: repo, hub: &eventHub{subscribers: make(map[*Subscription]struct{})}}
+}
+
+// WithTx 함수 정의 (tx에 이벤트를 저장하고, 전달은 Flush를 호출할 때까지 미루는 복사본)
+func (b *EventBus) WithTx(tx repository.DBTX) *EventBus {
+	return &EventBus{Repo: &repository.NoteEventRepository{DB: tx}, hub: b.hub, pending: &[]*model.NoteEvent{}}
+}
+
+// Publish 함수 정의 (이벤트를 저장하고 ID를 채운 뒤 구독자에게 전달)
+func (b *EventBus) Publish(event *model.NoteEvent) error {
+	if event.CreatedTime.IsZero() {
+		event.CreatedTime = time.Now().UTC()
+	}
+	id, err := b.Repo.Create(event)
+	if err != nil {
+		return err
+	}
+	event.ID = id
+
+	if b.pending != nil {
+		*b.pending = append(*b.pending, event)
+		return nil
+	}
+	b.hub.broadcast(event)
+	return nil
+}
+
+// Flush 함수 정의 (커밋 후 모아 둔 이벤트 전달)
+func (b *EventBus) Flush() {
+	if b.pending == nil {
+		return
+	}
+	for _, event := range *b.pending {
+		b.hub.broadcast(event)
+	}
+	*b.pending = nil
+}
+
+// mark 함수 정의 (지금까지 모아 둔 이벤트 수, 세이브포인트로 되돌릴 때 discard에 사용)
+func (b *EventBus) mark() int {
+	if b.pending == nil {
+		return 0
+	}
+	return len(*b.pending)
+}
+
+// discard 함수 정의 (mark 이후 모아 둔 이벤트 버림)
+func (b *EventBus) discard(mark int) {
+	if b.pending != nil && len(*b.pending) > mark {
+		*b.pending = (*b.pending)[:mark]
+	}
+}
+
+// Subscribe 함수 정의 (scope에서 보이고 filter에 맞는 이벤트 구독, 반드시 Unsubscribe 호출)
+func (b *EventBus) Subscribe(scope model.Scope, filter model.NoteEventFilter) *Subscription {
+	events := make(chan *model.NoteEvent, subscriptionBuffer)
+	sub := &Subscription{Events: events, events: events, scope: scope, filter: filter}
+
+	b.hub.mu.Lock()
+	defer b.hub.mu.Unlock()
+	b.hub.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe 함수 정의
+func (b *EventBus) Unsubscribe(sub *Subscription) {
+	b.hub.mu.Lock()
+	defer b.hub.mu.Unlock()
+	b.hub.remove(sub)
+}
+
+// Notify 함수 정의 (이벤트가 전달될 때마다 신호를 받는 채널, 신호가 밀려 있으면 하나로 합침)
+func (b *EventBus) Notify() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	b.hub.mu.Lock()
+	defer b.hub.mu.Unlock()
+	b.hub.listeners = append(b.hub.listeners, ch)
+	return ch
+}
+
+// ErrCursorExpired 보관 기간이 지나 삭제된 이벤트 이후부터 이어받으려 할 때 반환 (클라이언트는 전체를 다시 받아야 함)
+var ErrCursorExpired = model.NewError(model.ErrCodeGone, "events after this cursor were pruned; fetch a full snapshot and resume from its cursor")
+
+// CheckCursor 함수 정의 (afterID 이후의 이벤트 중 삭제된 것이 있으면 ErrCursorExpired)
+func (b *EventBus) CheckCursor(afterID int64) error {
+	prunedThrough, err := b.Repo.PrunedThrough()
+	if err != nil {
+		return err
+	}
+	if afterID < prunedThrough {
+		return ErrCursorExpired
+	}
+	return nil
+}
+
+// History 함수 정의 (저장된 이벤트 중 filter.AfterID 이후의 이벤트, 오래된 것부터)
+func (b *EventBus) History(scope model.Scope, filter model.NoteEventFilter) ([]*model.NoteEvent, error) {
+	return b.Repo.GetAfter(scope, filter)
+}
+
+// broadcast 함수 정의 (받지 못하는 구독자는 끊어서 다른 구독자를 막지 않음)
+func (h *eventHub) broadcast(event *model.NoteEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !event.VisibleTo(sub.scope) || !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub)
+		}
+	}
+	for _, ch := range h.listeners {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// remove 함수 정의 (mu를 잡은 상태에서 호출)
+func (h *eventHub) remove(sub *Subscription) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"myapp/repository"
+	"time"
+)
+
+// eventPruneInterval 보관 기간이 지난 노트 이벤트를 삭제하는 주기
+const eventPruneInterval = time.Hour
+
+// EventRetention 구조체 정의 (MaxAge보다 오래된 노트 이벤트 삭제, 웹훅으로 아직 전달하지 않은 이벤트는 남김)
+//
+// 삭제한 마지막 ID를 기록해 두고, 그보다 오래된 커서로 이어받으려는 요청은 ErrCursorExpired(410)로 거절함.
+// 클라이언트는 커서 없이 전체를 다시 받은 뒤 새 커서로 이어감.
+type EventRetention struct {
+	DB       *sql.DB
+	Webhooks *repository.WebhookRepository
+	MaxAge   time.Duration
+}
+
+// NewEventRetention 함수 정의 (maxAge가 0이면 삭제하지 않음)
+func NewEventRetention(db *sql.DB, webhooks *repository.WebhookRepository, maxAge time.Duration) *EventRetention {
+	return &EventRetention{DB: db, Webhooks: webhooks, MaxAge: maxAge}
+}
+
+// Start 함수 정의 (시작할 때와 이후 주기마다 삭제)
+func (r *EventRetention) Start(ctx context.Context) {
+	if r.MaxAge <= 0 {
+		return
+	}
+	go func() {
+		for {
+			if deleted, err := r.Prune(time.Now()); err != nil {
+				log.Printf("events: prune failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("events: pruned %d note events older than %s", deleted, r.MaxAge)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(eventPruneInterval):
+			}
+		}
+	}()
+}
+
+// Prune 함수 정의 (삭제와 삭제한 마지막 ID 기록을 한 트랜잭션으로 처리, 삭제한 이벤트 수 반환)
+func (r *EventRetention) Prune(now time.Time) (int64, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	events := &repository.NoteEventRepository{DB: tx}
+
+	maxID, err := events.LatestID()
+	if err != nil {
+		return 0, err
+	}
+	// 웹훅 전달 위치 이후의 이벤트는 아직 전달 전이므로 남김 (전달 위치는 늘어나기만 하므로 트랜잭션 밖에서 읽어도 됨)
+	webhookCursor, err := r.Webhooks.GetCursor()
+	switch {
+	case err == nil:
+		maxID = min(maxID, webhookCursor)
+	case !errors.Is(err, sql.ErrNoRows):
+		return 0, err
+	}
+
+	lastID, deleted, err := events.DeleteBefore(now.Add(-r.MaxAge), maxID)
+	if err != nil || lastID == 0 {
+		return 0, err
+	}
+	if err := events.SetPrunedThrough(lastID, now); err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
+}
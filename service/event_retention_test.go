@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"testing"
+	"time"
+)
+
+func TestEventRetentionKeepsUndeliveredEvents(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	scope := model.Scope{UserID: 1}
+	for _, title := range []string{"a", "b", "c"} {
+		if _, err := notes.CreateNote(scope, title, "content", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	webhooks := repository.NewWebhookRepository(db)
+	if err := webhooks.SetCursor(2, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	retention := NewEventRetention(db, webhooks, time.Minute)
+	deleted, err := retention.Prune(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Fatalf("deleted %d events, want 2 (event 3 is not delivered to webhooks yet)", deleted)
+	}
+
+	if err := notes.Events.CheckCursor(1); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("cursor before the pruned events: got %v, want ErrCursorExpired", err)
+	}
+	if err := notes.Events.CheckCursor(2); err != nil {
+		t.Errorf("cursor at the last pruned event: %v", err)
+	}
+
+	sync := NewSyncService(db, notes, repository.NewSyncClientIDRepository(db))
+	if _, _, _, err := sync.Changes(scope, 0, 10); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("sync from a pruned cursor: got %v, want ErrCursorExpired", err)
+	}
+	changes, _, _, err := sync.Changes(scope, 2, 10)
+	if err != nil || len(changes) != 1 {
+		t.Fatalf("sync from the last pruned event: %d changes, %v", len(changes), err)
+	}
+}
+
+func TestEventRetentionSkipsRecentEvents(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	if _, err := notes.CreateNote(model.Scope{UserID: 1}, "a", "content", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := NewEventRetention(db, repository.NewWebhookRepository(db), 24*time.Hour).Prune(time.Now())
+	if err != nil || deleted != 0 {
+		t.Fatalf("deleted %d recent events, err %v", deleted, err)
+	}
+	if err := notes.Events.CheckCursor(0); err != nil {
+		t.Errorf("nothing pruned but cursor 0 rejected: %v", err)
+	}
+}
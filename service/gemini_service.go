@@ -3,13 +3,16 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"myapp/model"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/generative-ai-go/genai"
 	"github.com/joho/godotenv"
@@ -17,10 +20,23 @@ import (
 )
 
 type GeminiService struct {
-	Client *genai.Client
+	Client  *genai.Client
+	Usage   *UsageService
+	Catalog *ModelCatalog
+	Timeout time.Duration
+	Retry   RetryPolicy
+	Breaker *CircuitBreaker
 }
 
-func NewGeminiService() (*GeminiService, error) {
+// GeminiOptions 구조체 정의 (호출 타임아웃, 재시도, 서킷 브레이커 설정)
+type GeminiOptions struct {
+	Timeout          time.Duration
+	MaxRetries       int
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+func NewGeminiService(usage *UsageService, catalog *ModelCatalog, opts GeminiOptions) (*GeminiService, error) {
 	if err := godotenv.Load(); err != nil {
 		return nil, fmt.Errorf("error loading .env file: %v", err)
 	}
@@ -35,7 +51,92 @@ func NewGeminiService() (*GeminiService, error) {
 		return nil, err
 	}
 
-	return &GeminiService{Client: client}, nil
+	return &GeminiService{
+		Client:  client,
+		Usage:   usage,
+		Catalog: catalog,
+		Timeout: opts.Timeout,
+		Retry: RetryPolicy{
+			MaxRetries: opts.MaxRetries,
+			BaseDelay:  500 * time.Millisecond,
+			MaxDelay:   5 * time.Second,
+		},
+		Breaker: NewCircuitBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+	}, nil
+}
+
+// generate 함수 정의 (한도 확인, 서킷 브레이커, 호출별 타임아웃과 재시도 적용 후 사용량 기록)
+func (gs *GeminiService) generate(ctx context.Context, operation string, settings GenerationSettings, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	if err := gs.Usage.CheckQuota(ctx); err != nil {
+		return nil, err
+	}
+	if err := gs.Breaker.Allow(); err != nil {
+		return nil, &AIError{Kind: err, Err: errors.New("circuit breaker is open")}
+	}
+
+	genModel := gs.Client.GenerativeModel(settings.Model)
+	settings.apply(genModel)
+	for attempt := 0; ; attempt++ {
+		resp, err := gs.generateOnce(ctx, operation, settings.Model, genModel, parts)
+		if err == nil {
+			gs.Breaker.Success()
+			return resp, nil
+		}
+
+		// 호출한 쪽에서 취소한 경우는 업스트림 상태와 무관
+		if ctx.Err() != nil {
+			gs.Breaker.Release()
+			return nil, ctx.Err()
+		}
+
+		kind, transient := classifyAIError(err)
+		if !transient {
+			// 응답은 받았으므로 업스트림은 살아 있음
+			gs.Breaker.Success()
+			log.Printf("gemini %s failed: %v", operation, err)
+			return nil, &AIError{Kind: kind, Err: err}
+		}
+		if attempt >= gs.Retry.MaxRetries {
+			gs.Breaker.Failure()
+			log.Printf("gemini %s failed after %d attempts: %v", operation, attempt+1, err)
+			return nil, &AIError{Kind: kind, Err: err}
+		}
+		if err := sleepContext(ctx, gs.Retry.delay(attempt)); err != nil {
+			gs.Breaker.Release()
+			return nil, err
+		}
+	}
+}
+
+// generateOnce 함수 정의 (타임아웃을 걸고 한 번 호출)
+func (gs *GeminiService) generateOnce(ctx context.Context, operation, modelName string, genModel *genai.GenerativeModel, parts []genai.Part) (*genai.GenerateContentResponse, error) {
+	callCtx := ctx
+	if gs.Timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, gs.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	resp, err := genModel.GenerateContent(callCtx, parts...)
+
+	usage := &model.AIUsage{
+		Operation: operation,
+		Model:     modelName,
+		LatencyMs: time.Since(start).Milliseconds(),
+		Success:   err == nil,
+	}
+	if err != nil {
+		usage.Error = err.Error()
+	}
+	if resp != nil && resp.UsageMetadata != nil {
+		usage.PromptTokens = int(resp.UsageMetadata.PromptTokenCount)
+		usage.CandidateTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+		usage.TotalTokens = int(resp.UsageMetadata.TotalTokenCount)
+	}
+	gs.Usage.Record(ctx, usage)
+
+	return resp, err
 }
 
 func (gs *GeminiService) HandleRequest(w http.ResponseWriter, r *http.Request) {
@@ -85,8 +186,7 @@ func (gs *GeminiService) HandleRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Generate response from Gemini
-	ctx := context.Background()
-	model := gs.Client.GenerativeModel("gemini-1.5-flash")
+	ctx := r.Context()
 
 	prompt := []genai.Part{
 		genai.Text(requestText),
@@ -94,7 +194,13 @@ func (gs *GeminiService) HandleRequest(w http.ResponseWriter, r *http.Request) {
 		genai.ImageData(img2Format, imgData2),
 	}
 
-	resp, err := model.GenerateContent(ctx, prompt...)
+	settings, err := gs.Catalog.VisionSettings()
+	if err != nil {
+		http.Error(w, "No model available for images", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := gs.generate(ctx, "compare_images", settings, prompt...)
 	if err != nil {
 		http.Error(w, "Error generating content", http.StatusInternalServerError)
 		return
@@ -124,15 +230,15 @@ func detectImageFormat(filename string) string {
 	}
 }
 
-func (gs *GeminiService) AnalyzeNoteContentAndImage(ctx context.Context, note *model.Note, requestText string) (string, error) {
-	// GenerativeModel 호출
-	model := gs.Client.GenerativeModel("gemini-1.5-flash")
-
-	prompt := []genai.Part{
-		genai.Text(fmt.Sprintf("Analyze the content and image of the following note. Request: %s\nContent: %s\nImage URL: %s", requestText, note.Content, note.Img)),
+// GenerateFromPrompt 함수 정의 (프롬프트 템플릿으로 만든 프롬프트 실행, 모델과 파라미터는 요청별로 변경 가능)
+func (gs *GeminiService) GenerateFromPrompt(ctx context.Context, prompt string, overrides GenerationOverrides) (string, error) {
+	settings, err := gs.Catalog.Resolve(overrides)
+	if err != nil {
+		return "", err
 	}
 
-	resp, err := model.GenerateContent(ctx, prompt...)
+	// GenerativeModel 호출
+	resp, err := gs.generate(ctx, "analyze", settings, genai.Text(prompt))
 	if err != nil {
 		return "", err
 	}
@@ -144,3 +250,35 @@ func (gs *GeminiService) AnalyzeNoteContentAndImage(ctx context.Context, note *m
 
 	return string(respJSON), nil
 }
+
+// GenerateText 함수 정의 (응답 JSON 대신 생성된 텍스트만 반환)
+func (gs *GeminiService) GenerateText(ctx context.Context, operation, prompt string, overrides GenerationOverrides) (string, error) {
+	settings, err := gs.Catalog.Resolve(overrides)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := gs.generate(ctx, operation, settings, genai.Text(prompt))
+	if err != nil {
+		return "", err
+	}
+	return responseText(resp), nil
+}
+
+// GenerateJSON 함수 정의 (JSON 응답을 요청하고 v에 디코딩)
+func (gs *GeminiService) GenerateJSON(ctx context.Context, operation, prompt string, overrides GenerationOverrides, v interface{}) error {
+	settings, err := gs.Catalog.Resolve(overrides)
+	if err != nil {
+		return err
+	}
+	settings.ResponseMIMEType = "application/json"
+
+	resp, err := gs.generate(ctx, operation, settings, genai.Text(prompt))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(responseText(resp)), v); err != nil {
+		return &AIError{Kind: ErrUpstreamFailed, Err: fmt.Errorf("invalid JSON response: %v", err)}
+	}
+	return nil
+}
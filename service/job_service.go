@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"myapp/model"
+	"myapp/repository"
+	"sync"
+	"time"
+)
+
+// JobFunc 타입 정의 (작업 실행 함수, 결과 문자열 반환)
+type JobFunc func(ctx context.Context, job *model.Job) (string, error)
+
+// ErrJobNotCancellable 작업이 이미 끝났거나 존재하지 않을 때 반환
+var ErrJobNotCancellable = model.Conflict("job is already finished")
+
+// permanentError 재시도하지 않을 오류 표시
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 함수 정의 (재시도 없이 바로 dead 처리할 오류로 감쌈)
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// JobService 구조체 정의 (SQLite 기반 작업 큐와 워커)
+type JobService struct {
+	Repo         *repository.JobRepository
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	MaxAttempts  int
+
+	mu       sync.Mutex
+	handlers map[string]JobFunc
+	running  map[int]context.CancelFunc
+	wake     chan struct{}
+}
+
+// NewJobService 함수 정의
+func NewJobService(repo *repository.JobRepository) *JobService {
+	return &JobService{
+		Repo:         repo,
+		PollInterval: time.Second,
+		BaseBackoff:  2 * time.Second,
+		MaxBackoff:   5 * time.Minute,
+		MaxAttempts:  5,
+		handlers:     make(map[string]JobFunc),
+		running:      make(map[int]context.CancelFunc),
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Register 함수 정의 (작업 타입별 실행 함수 등록)
+func (s *JobService) Register(jobType string, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = fn
+}
+
+// Enqueue 함수 정의 (payload는 JSON으로 저장, scope는 작업을 요청한 사용자와 워크스페이스)
+func (s *JobService) Enqueue(scope model.Scope, jobType string, payload interface{}) (*model.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	job := &model.Job{
+		UserID:      scope.UserID,
+		WorkspaceID: scope.WorkspaceID,
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      model.JobStatusPending,
+		MaxAttempts: s.MaxAttempts,
+		RunAt:       now,
+		CreatedTime: now,
+	}
+	id, err := s.Repo.Create(job)
+	if err != nil {
+		return nil, err
+	}
+	job.ID = id
+
+	// 대기 중인 워커 깨우기
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// GetJob 함수 정의 (다른 사용자의 작업은 찾을 수 없음으로 처리)
+func (s *JobService) GetJob(userID, id int) (*model.Job, error) {
+	job, err := s.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, model.NotFound("job not found").Wrap(sql.ErrNoRows)
+	}
+	return job, nil
+}
+
+// CancelJob 함수 정의 (대기 중이면 바로 취소, 실행 중이면 컨텍스트 취소)
+func (s *JobService) CancelJob(userID, id int) (*model.Job, error) {
+	if _, err := s.GetJob(userID, id); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.Repo.Cancel(id, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrJobNotCancellable
+	}
+
+	s.mu.Lock()
+	if cancel, found := s.running[id]; found {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return s.Repo.GetByID(id)
+}
+
+// Start 함수 정의 (workers 개수만큼 워커 실행, ctx 종료 시 정지)
+func (s *JobService) Start(ctx context.Context, workers int) error {
+	if err := s.Repo.RequeueRunning(time.Now().UTC()); err != nil {
+		return err
+	}
+	for i := 0; i < workers; i++ {
+		go s.work(ctx)
+	}
+	return nil
+}
+
+func (s *JobService) work(ctx context.Context) {
+	for {
+		job, err := s.Repo.ClaimNext(time.Now().UTC())
+		if err != nil {
+			log.Printf("job queue: claim failed: %v", err)
+		}
+		if job != nil {
+			s.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-time.After(s.PollInterval):
+		}
+	}
+}
+
+func (s *JobService) run(ctx context.Context, job *model.Job) {
+	s.mu.Lock()
+	fn, ok := s.handlers[job.Type]
+	jobCtx, cancel := context.WithCancel(ctx)
+	s.running[job.ID] = cancel
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+		cancel()
+	}()
+
+	var result string
+	var err error
+	if !ok {
+		err = Permanent(fmt.Errorf("unknown job type %q", job.Type))
+	} else {
+		result, err = fn(WithUsageInfo(jobCtx, UsageInfo{UserID: job.UserID, WorkspaceID: job.WorkspaceID}), job)
+	}
+
+	// 취소된 작업은 상태 조건 때문에 아래 업데이트가 적용되지 않음
+	now := time.Now().UTC()
+	var perm *permanentError
+	switch {
+	case err == nil:
+		err = s.Repo.Complete(job.ID, result, now)
+	case errors.As(err, &perm) || job.Attempts >= job.MaxAttempts:
+		log.Printf("job %d (%s) dead after %d attempts: %v", job.ID, job.Type, job.Attempts, err)
+		err = s.Repo.Fail(job.ID, err.Error(), now)
+	default:
+		err = s.Repo.Retry(job.ID, err.Error(), now.Add(s.backoff(job.Attempts)), now)
+	}
+	if err != nil {
+		log.Printf("job %d: could not update status: %v", job.ID, err)
+	}
+}
+
+// backoff 함수 정의 (BaseBackoff * 2^(attempts-1), MaxBackoff로 제한)
+func (s *JobService) backoff(attempts int) time.Duration {
+	d := s.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= s.MaxBackoff {
+			return s.MaxBackoff
+		}
+	}
+	return d
+}
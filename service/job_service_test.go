@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"testing"
+	"time"
+)
+
+func newTestJobService(t *testing.T) *JobService {
+	return NewJobService(repository.NewJobRepository(newTestDB(t)))
+}
+
+// claim 함수 정의 (now 기준으로 실행 가능한 작업 하나를 가져옴)
+func claim(t *testing.T, jobs *JobService, now time.Time) *model.Job {
+	t.Helper()
+	job, err := jobs.Repo.ClaimNext(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func getJob(t *testing.T, jobs *JobService, id int) *model.Job {
+	t.Helper()
+	job, err := jobs.Repo.GetByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestClaimNextTakesTheOldestDueJobOnce(t *testing.T) {
+	jobs := newTestJobService(t)
+	scope := model.Scope{UserID: 1}
+	first, err := jobs.Enqueue(scope, "echo", "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jobs.Enqueue(scope, "echo", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	job := claim(t, jobs, now)
+	if job == nil || job.ID != first.ID || job.Status != model.JobStatusRunning || job.Attempts != 1 {
+		t.Fatalf("claimed %+v, want the first job running on attempt 1", job)
+	}
+	if next := claim(t, jobs, now); next == nil || next.ID == first.ID {
+		t.Fatalf("second claim = %+v, want the other job", next)
+	}
+	if none := claim(t, jobs, now); none != nil {
+		t.Fatalf("claimed %+v from an empty queue", none)
+	}
+}
+
+func TestFailedJobIsRetriedWithBackoff(t *testing.T) {
+	jobs := newTestJobService(t)
+	calls := 0
+	jobs.Register("flaky", func(ctx context.Context, job *model.Job) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errors.New("temporary")
+		}
+		return "done", nil
+	})
+	created, err := jobs.Enqueue(model.Scope{UserID: 1}, "flaky", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs.run(context.Background(), claim(t, jobs, time.Now().UTC()))
+	job := getJob(t, jobs, created.ID)
+	if job.Status != model.JobStatusPending || job.LastError != "temporary" || job.Attempts != 1 {
+		t.Fatalf("after failure: %+v, want pending with the error kept", job)
+	}
+	if wait := time.Until(job.RunAt); wait <= 0 || wait > jobs.BaseBackoff {
+		t.Fatalf("retry scheduled in %v, want within the base backoff of %v", wait, jobs.BaseBackoff)
+	}
+	if early := claim(t, jobs, time.Now().UTC()); early != nil {
+		t.Fatalf("claimed %+v before its backoff elapsed", early)
+	}
+
+	jobs.run(context.Background(), claim(t, jobs, job.RunAt))
+	job = getJob(t, jobs, created.ID)
+	if job.Status != model.JobStatusSucceeded || job.Result != "done" || job.LastError != "" || job.Attempts != 2 {
+		t.Fatalf("after retry: %+v, want succeeded on attempt 2", job)
+	}
+}
+
+func TestBackoffDoublesUpToTheCap(t *testing.T) {
+	jobs := &JobService{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	for attempts, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		3:  4 * time.Second,
+		4:  8 * time.Second,
+		5:  10 * time.Second,
+		50: 10 * time.Second,
+	} {
+		if got := jobs.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestJobIsDeadLettered(t *testing.T) {
+	tests := []struct {
+		name     string
+		jobType  string
+		err      error
+		attempts int
+	}{
+		{"after max attempts", "failing", errors.New("still broken"), 2},
+		{"on a permanent error", "failing", Permanent(errors.New("bad payload")), 1},
+		{"for an unknown type", "missing", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := newTestJobService(t)
+			jobs.MaxAttempts = 2
+			jobs.Register("failing", func(ctx context.Context, job *model.Job) (string, error) {
+				return "", tt.err
+			})
+			created, err := jobs.Enqueue(model.Scope{UserID: 1}, tt.jobType, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// 재시도 시각을 기다리지 않도록 충분히 뒤의 시각으로 가져옴
+			later := time.Now().UTC().Add(time.Hour)
+			for job := claim(t, jobs, later); job != nil; job = claim(t, jobs, later) {
+				jobs.run(context.Background(), job)
+			}
+			job := getJob(t, jobs, created.ID)
+			if job.Status != model.JobStatusDead || job.Attempts != tt.attempts || job.LastError == "" {
+				t.Fatalf("job = %+v, want dead after %d attempts with the error kept", job, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestCancelJob(t *testing.T) {
+	jobs := newTestJobService(t)
+	started := make(chan struct{})
+	jobs.Register("slow", func(ctx context.Context, job *model.Job) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	pending, err := jobs.Enqueue(model.Scope{UserID: 1}, "slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := jobs.CancelJob(2, pending.ID); !errors.Is(err, model.NotFound("job not found")) {
+		t.Fatalf("cancel by another user = %v, want not found", err)
+	}
+	job, err := jobs.CancelJob(1, pending.ID)
+	if err != nil || job.Status != model.JobStatusCancelled {
+		t.Fatalf("cancel pending = %+v, %v; want cancelled", job, err)
+	}
+	if claimed := claim(t, jobs, time.Now().UTC()); claimed != nil {
+		t.Fatalf("claimed cancelled job %+v", claimed)
+	}
+	if _, err := jobs.CancelJob(1, pending.ID); !errors.Is(err, ErrJobNotCancellable) {
+		t.Fatalf("second cancel = %v, want %v", err, ErrJobNotCancellable)
+	}
+
+	running, err := jobs.Enqueue(model.Scope{UserID: 1}, "slow", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimed := claim(t, jobs, time.Now().UTC())
+	done := make(chan struct{})
+	go func() {
+		jobs.run(context.Background(), claimed)
+		close(done)
+	}()
+	<-started
+	if _, err := jobs.CancelJob(1, running.ID); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("running job did not stop after it was cancelled")
+	}
+	// 취소로 끝난 실행이 재시도로 되돌리지 않음
+	if job := getJob(t, jobs, running.ID); job.Status != model.JobStatusCancelled {
+		t.Fatalf("status = %s, want cancelled", job.Status)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"myapp/model"
+	"myapp/utils"
+)
+
+// 작업 타입 정의
+const (
+	JobTypeAnalyzeNote = "analyze_note"
+	JobTypeExtractText = "extract_text"
+	JobTypeThumbnail   = "thumbnail"
+	JobTypeTranslate   = "translate_note"
+
+	JobTypeWebhookDelivery = "webhook_delivery"
+)
+
+// AnalyzeJobPayload 구조체 정의
+type AnalyzeJobPayload struct {
+	NoteID   int    `json:"note_id"`
+	Request  string `json:"request"`
+	Template string `json:"template"`
+	Language string `json:"language"`
+
+	Overrides GenerationOverrides `json:"overrides"`
+}
+
+// TranslateJobPayload 구조체 정의
+type TranslateJobPayload struct {
+	NoteID   int    `json:"note_id"`
+	Language string `json:"language"`
+}
+
+// AttachmentJobPayload 구조체 정의
+type AttachmentJobPayload struct {
+	AttachmentID int `json:"attachment_id"`
+}
+
+// WebhookJobPayload 구조체 정의
+type WebhookJobPayload struct {
+	DeliveryID int `json:"delivery_id"`
+}
+
+// RegisterJobHandlers 함수 정의 (AI, 이미지 처리, 웹훅 전송 작업 등록)
+func RegisterJobHandlers(jobs *JobService, noteService *NoteService, geminiService *GeminiService, promptService *PromptService, attachmentService *AttachmentService, translationService *TranslationService, webhookService *WebhookService) {
+	jobs.Register(JobTypeAnalyzeNote, func(ctx context.Context, job *model.Job) (string, error) {
+		var payload AnalyzeJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", Permanent(err)
+		}
+		note, err := noteService.GetNoteByID(job.Scope(), payload.NoteID)
+		if err != nil {
+			return "", permanentIfNotFound(err)
+		}
+		prompt, err := promptService.RenderForNote(payload.Template, note, payload.Request, payload.Language)
+		if err != nil {
+			return "", Permanent(err)
+		}
+		return geminiService.GenerateFromPrompt(WithUsageInfo(ctx, UsageInfo{NoteID: note.ID}), prompt, payload.Overrides)
+	})
+
+	jobs.Register(JobTypeTranslate, func(ctx context.Context, job *model.Job) (string, error) {
+		var payload TranslateJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", Permanent(err)
+		}
+		translation, err := translationService.TranslateNote(ctx, job.Scope(), payload.NoteID, payload.Language)
+		if err != nil {
+			return "", permanentIfNotFound(err)
+		}
+		return fmt.Sprintf("translated to %s at revision %d", translation.Language, translation.SourceRevision), nil
+	})
+
+	jobs.Register(JobTypeExtractText, func(ctx context.Context, job *model.Job) (string, error) {
+		var payload AttachmentJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", Permanent(err)
+		}
+		text, err := attachmentService.ExtractAttachmentText(ctx, payload.AttachmentID)
+		return text, permanentIfNotFound(err)
+	})
+
+	jobs.Register(JobTypeThumbnail, func(ctx context.Context, job *model.Job) (string, error) {
+		var payload AttachmentJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", Permanent(err)
+		}
+		path, err := attachmentService.CreateAttachmentThumbnail(payload.AttachmentID)
+		// 디코딩할 수 없거나 너무 큰 이미지는 다시 시도해도 같은 결과
+		if errors.Is(err, utils.ErrImageTooLarge) || errors.Is(err, image.ErrFormat) {
+			return "", Permanent(err)
+		}
+		return path, permanentIfNotFound(err)
+	})
+
+	jobs.Register(JobTypeWebhookDelivery, func(ctx context.Context, job *model.Job) (string, error) {
+		var payload WebhookJobPayload
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", Permanent(err)
+		}
+		return webhookService.Deliver(ctx, job, payload.DeliveryID)
+	})
+}
+
+// 삭제된 대상은 재시도해도 소용없으므로 바로 dead 처리
+func permanentIfNotFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return Permanent(err)
+	}
+	return err
+}
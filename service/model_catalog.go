@@ -0,0 +1,192 @@
+package service
+
+import (
+	"fmt"
+	"myapp/model"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// ModelInfo 구조체 정의 (모델별 기능)
+type ModelInfo struct {
+	Name            string `json:"name"`
+	Vision          bool   `json:"vision"`
+	MaxInputTokens  int    `json:"max_input_tokens"`
+	MaxOutputTokens int    `json:"max_output_tokens"`
+	Default         bool   `json:"default"`
+}
+
+// 알려진 모델의 기능 (목록에 없는 모델은 텍스트 전용 기본값 사용)
+var knownModels = map[string]ModelInfo{
+	"gemini-1.5-flash":    {Vision: true, MaxInputTokens: 1048576, MaxOutputTokens: 8192},
+	"gemini-1.5-flash-8b": {Vision: true, MaxInputTokens: 1048576, MaxOutputTokens: 8192},
+	"gemini-1.5-pro":      {Vision: true, MaxInputTokens: 2097152, MaxOutputTokens: 8192},
+	"gemini-1.0-pro":      {Vision: false, MaxInputTokens: 30720, MaxOutputTokens: 2048},
+}
+
+// 안전 설정 임계값 이름
+var safetyThresholds = map[string]genai.HarmBlockThreshold{
+	"":                 genai.HarmBlockUnspecified,
+	"low_and_above":    genai.HarmBlockLowAndAbove,
+	"medium_and_above": genai.HarmBlockMediumAndAbove,
+	"only_high":        genai.HarmBlockOnlyHigh,
+	"none":             genai.HarmBlockNone,
+}
+
+// GenerationSettings 구조체 정의 (호출 1회에 적용할 모델과 생성 파라미터)
+type GenerationSettings struct {
+	Model             string
+	Temperature       *float32
+	MaxOutputTokens   *int32
+	SystemInstruction string
+	SafetyThreshold   genai.HarmBlockThreshold
+
+	// 응답 형식 (JSON 응답이 필요한 내부 호출에서만 지정)
+	ResponseMIMEType string
+}
+
+// GenerationOverrides 구조체 정의 (요청별로 바꿀 수 있는 값)
+type GenerationOverrides struct {
+	Model           string   `json:"model,omitempty"`
+	Temperature     *float32 `json:"temperature,omitempty"`
+	MaxOutputTokens *int32   `json:"max_output_tokens,omitempty"`
+}
+
+// ModelCatalog 구조체 정의 (허용된 모델 목록과 기본 설정)
+type ModelCatalog struct {
+	Models   []ModelInfo
+	Defaults GenerationSettings
+}
+
+// ModelCatalogOptions 구조체 정의
+type ModelCatalogOptions struct {
+	Models            []string
+	DefaultModel      string
+	Temperature       *float32
+	MaxOutputTokens   int
+	SystemInstruction string
+	SafetyThreshold   string
+}
+
+// NewModelCatalog 함수 정의 (기본 모델은 허용 목록에 있어야 함)
+func NewModelCatalog(opts ModelCatalogOptions) (*ModelCatalog, error) {
+	if len(opts.Models) == 0 {
+		return nil, fmt.Errorf("at least one AI model must be configured")
+	}
+	defaultModel := opts.DefaultModel
+	if defaultModel == "" {
+		defaultModel = opts.Models[0]
+	}
+
+	threshold, ok := safetyThresholds[strings.ToLower(opts.SafetyThreshold)]
+	if !ok {
+		return nil, fmt.Errorf("unknown safety threshold %q", opts.SafetyThreshold)
+	}
+
+	catalog := &ModelCatalog{
+		Defaults: GenerationSettings{
+			Model:             defaultModel,
+			Temperature:       opts.Temperature,
+			SystemInstruction: opts.SystemInstruction,
+			SafetyThreshold:   threshold,
+		},
+	}
+	if opts.MaxOutputTokens > 0 {
+		n := int32(opts.MaxOutputTokens)
+		catalog.Defaults.MaxOutputTokens = &n
+	}
+
+	foundDefault := false
+	for _, name := range opts.Models {
+		info, ok := knownModels[name]
+		if !ok {
+			info = ModelInfo{MaxInputTokens: 30720, MaxOutputTokens: 2048}
+		}
+		info.Name = name
+		info.Default = name == defaultModel
+		foundDefault = foundDefault || info.Default
+		catalog.Models = append(catalog.Models, info)
+	}
+	if !foundDefault {
+		return nil, fmt.Errorf("default model %q is not in the configured model list", defaultModel)
+	}
+	return catalog, nil
+}
+
+// Lookup 함수 정의
+func (c *ModelCatalog) Lookup(name string) (ModelInfo, bool) {
+	for _, info := range c.Models {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return ModelInfo{}, false
+}
+
+// Resolve 함수 정의 (기본 설정에 요청별 값을 덮어쓰고 허용 범위 확인)
+func (c *ModelCatalog) Resolve(overrides GenerationOverrides) (GenerationSettings, error) {
+	settings := c.Defaults
+	if overrides.Model != "" {
+		settings.Model = overrides.Model
+	}
+	info, ok := c.Lookup(settings.Model)
+	if !ok {
+		return settings, model.Validation(fmt.Sprintf("model %q is not allowed", settings.Model))
+	}
+
+	if overrides.Temperature != nil {
+		if *overrides.Temperature < 0 || *overrides.Temperature > 2 {
+			return settings, model.Validation("temperature must be between 0 and 2")
+		}
+		settings.Temperature = overrides.Temperature
+	}
+	if overrides.MaxOutputTokens != nil {
+		settings.MaxOutputTokens = overrides.MaxOutputTokens
+	}
+	if settings.MaxOutputTokens != nil {
+		if *settings.MaxOutputTokens < 1 || int(*settings.MaxOutputTokens) > info.MaxOutputTokens {
+			return settings, model.Validation(fmt.Sprintf("max_output_tokens must be between 1 and %d for %s", info.MaxOutputTokens, info.Name))
+		}
+	}
+	return settings, nil
+}
+
+// VisionSettings 함수 정의 (이미지 입력이 필요한 호출용, 기본 모델이 이미지를 못 읽으면 다른 모델 선택)
+func (c *ModelCatalog) VisionSettings() (GenerationSettings, error) {
+	settings := c.Defaults
+	if info, ok := c.Lookup(settings.Model); ok && info.Vision {
+		return settings, nil
+	}
+	for _, info := range c.Models {
+		if info.Vision {
+			settings.Model = info.Name
+			settings.MaxOutputTokens = nil
+			return settings, nil
+		}
+	}
+	return settings, model.Validation("no configured model supports image input")
+}
+
+// apply 함수 정의 (genai 모델에 설정 반영)
+func (s GenerationSettings) apply(genModel *genai.GenerativeModel) {
+	genModel.Temperature = s.Temperature
+	genModel.MaxOutputTokens = s.MaxOutputTokens
+	genModel.ResponseMIMEType = s.ResponseMIMEType
+	if s.SystemInstruction != "" {
+		genModel.SystemInstruction = genai.NewUserContent(genai.Text(s.SystemInstruction))
+	}
+	if s.SafetyThreshold != genai.HarmBlockUnspecified {
+		for _, category := range []genai.HarmCategory{
+			genai.HarmCategoryHarassment,
+			genai.HarmCategoryHateSpeech,
+			genai.HarmCategorySexuallyExplicit,
+			genai.HarmCategoryDangerousContent,
+		} {
+			genModel.SafetySettings = append(genModel.SafetySettings, &genai.SafetySetting{
+				Category:  category,
+				Threshold: s.SafetyThreshold,
+			})
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func float32Ptr(v float32) *float32 { return &v }
+func int32Ptr(v int32) *int32       { return &v }
+
+func TestNewModelCatalog(t *testing.T) {
+	catalog, err := NewModelCatalog(ModelCatalogOptions{
+		Models:          []string{"gemini-1.0-pro", "gemini-1.5-flash", "custom-model"},
+		DefaultModel:    "gemini-1.5-flash",
+		MaxOutputTokens: 1024,
+		SafetyThreshold: "ONLY_HIGH",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Models) != 3 || catalog.Models[1].Name != "gemini-1.5-flash" || !catalog.Models[1].Default || catalog.Models[0].Default {
+		t.Fatalf("models = %+v, want the configured order with gemini-1.5-flash as default", catalog.Models)
+	}
+	// 목록에 없는 모델은 텍스트 전용 기본값
+	if custom := catalog.Models[2]; custom.Vision || custom.MaxOutputTokens != 2048 {
+		t.Fatalf("unknown model = %+v, want text-only defaults", custom)
+	}
+	if catalog.Defaults.MaxOutputTokens == nil || *catalog.Defaults.MaxOutputTokens != 1024 || catalog.Defaults.SafetyThreshold != genai.HarmBlockOnlyHigh {
+		t.Fatalf("defaults = %+v", catalog.Defaults)
+	}
+
+	first, err := NewModelCatalog(ModelCatalogOptions{Models: []string{"gemini-1.0-pro", "gemini-1.5-pro"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Defaults.Model != "gemini-1.0-pro" || first.Defaults.MaxOutputTokens != nil {
+		t.Fatalf("defaults without a default model = %+v, want the first model and no token limit", first.Defaults)
+	}
+
+	for name, opts := range map[string]ModelCatalogOptions{
+		"no models":                {},
+		"default not in the list":  {Models: []string{"gemini-1.5-flash"}, DefaultModel: "gemini-1.5-pro"},
+		"unknown safety threshold": {Models: []string{"gemini-1.5-flash"}, SafetyThreshold: "sometimes"},
+	} {
+		if _, err := NewModelCatalog(opts); err == nil {
+			t.Errorf("%s: catalog created", name)
+		}
+	}
+}
+
+func TestResolveValidatesOverridesAgainstTheAllowlist(t *testing.T) {
+	catalog, err := NewModelCatalog(ModelCatalogOptions{
+		Models:            []string{"gemini-1.5-flash", "gemini-1.0-pro"},
+		Temperature:       float32Ptr(0.4),
+		SystemInstruction: "간결하게 답하세요",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := catalog.Resolve(GenerationOverrides{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Model != "gemini-1.5-flash" || *settings.Temperature != 0.4 || settings.SystemInstruction != "간결하게 답하세요" {
+		t.Fatalf("settings without overrides = %+v, want the defaults", settings)
+	}
+
+	settings, err = catalog.Resolve(GenerationOverrides{Model: "gemini-1.0-pro", Temperature: float32Ptr(1.5), MaxOutputTokens: int32Ptr(2048)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Model != "gemini-1.0-pro" || *settings.Temperature != 1.5 || *settings.MaxOutputTokens != 2048 {
+		t.Fatalf("settings with overrides = %+v", settings)
+	}
+	if *catalog.Defaults.Temperature != 0.4 {
+		t.Fatal("Resolve changed the catalog defaults")
+	}
+
+	for name, overrides := range map[string]GenerationOverrides{
+		"model outside the allowlist":  {Model: "gemini-ultra"},
+		"negative temperature":         {Temperature: float32Ptr(-0.1)},
+		"temperature above 2":          {Temperature: float32Ptr(2.1)},
+		"zero max tokens":              {MaxOutputTokens: int32Ptr(0)},
+		"max tokens above model limit": {Model: "gemini-1.0-pro", MaxOutputTokens: int32Ptr(4096)},
+	} {
+		var validation *model.Error
+		if _, err := catalog.Resolve(overrides); !errors.As(err, &validation) || validation.Code != model.ErrCodeValidation {
+			t.Errorf("%s: %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestVisionSettingsPicksAModelThatReadsImages(t *testing.T) {
+	catalog, err := NewModelCatalog(ModelCatalogOptions{Models: []string{"gemini-1.0-pro", "gemini-1.5-flash"}, MaxOutputTokens: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := catalog.VisionSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Model != "gemini-1.5-flash" || settings.MaxOutputTokens != nil {
+		t.Fatalf("vision settings = %+v, want gemini-1.5-flash without the text model's token limit", settings)
+	}
+
+	textOnly, err := NewModelCatalog(ModelCatalogOptions{Models: []string{"gemini-1.0-pro"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := textOnly.VisionSettings(); err == nil {
+		t.Fatal("vision settings from a text-only catalog")
+	}
+}
+
+func TestGenerationSettingsApply(t *testing.T) {
+	settings := GenerationSettings{
+		Temperature:       float32Ptr(0.2),
+		MaxOutputTokens:   int32Ptr(100),
+		SystemInstruction: "한국어로 답하세요",
+		SafetyThreshold:   genai.HarmBlockLowAndAbove,
+		ResponseMIMEType:  "application/json",
+	}
+	genModel := &genai.GenerativeModel{}
+	settings.apply(genModel)
+	if *genModel.Temperature != 0.2 || *genModel.MaxOutputTokens != 100 || genModel.ResponseMIMEType != "application/json" || genModel.SystemInstruction == nil {
+		t.Fatalf("model = %+v", genModel)
+	}
+	if len(genModel.SafetySettings) != 4 || genModel.SafetySettings[0].Threshold != genai.HarmBlockLowAndAbove {
+		t.Fatalf("safety settings = %+v, want every category at low_and_above", genModel.SafetySettings)
+	}
+
+	plain := &genai.GenerativeModel{}
+	GenerationSettings{}.apply(plain)
+	if plain.SystemInstruction != nil || len(plain.SafetySettings) != 0 {
+		t.Fatalf("model without settings = %+v, want the provider defaults", plain)
+	}
+}
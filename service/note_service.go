@@ -1,75 +1,410 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"myapp/model"
 	"myapp/repository"
+	"myapp/utils"
 	"time"
 )
 
-// NoteService 구조체 정의
+// NoteService 구조체 정의 (DB는 변경마다 트랜잭션을 여는 데 사용, WithTx 복사본은 nil)
 type NoteService struct {
-	Repo *repository.NoteRepository
+	DB           *sql.DB
+	Repo         *repository.NoteRepository
+	Revisions    *repository.NoteRevisionRepository
+	Translations *repository.NoteTranslationRepository
+	Tasks        *repository.TaskRepository
+	Shares       *repository.NoteShareRepository
+	Events       *EventBus
 }
 
+// MaxNoteContentLength 노트 내용 최대 길이 (글자 수, API 검증의 max=100000과 같은 값)
+const MaxNoteContentLength = 100000
+
+// ErrNoteReadOnly 보기 권한만 공유받은 노트를 수정하려 할 때 반환
+var ErrNoteReadOnly = model.Forbidden("note is shared with view-only access")
+
+// ErrVersionMismatch 요청한 버전(If-Match)과 현재 노트 버전이 다를 때 반환
+var ErrVersionMismatch = model.NewError(model.ErrCodePreconditionFailed, "note has been modified since the given version")
+
 // NewNoteService 함수 정의
-func NewNoteService(repo *repository.NoteRepository) *NoteService {
-	return &NoteService{Repo: repo}
+func NewNoteService(db *sql.DB, repo *repository.NoteRepository, revisions *repository.NoteRevisionRepository, translations *repository.NoteTranslationRepository, tasks *repository.TaskRepository, shares *repository.NoteShareRepository, events *EventBus) *NoteService {
+	return &NoteService{DB: db, Repo: repo, Revisions: revisions, Translations: translations, Tasks: tasks, Shares: shares, Events: events}
+}
+
+// WithTx 함수 정의 (모든 저장소가 tx를 사용하는 복사본, 여러 노트 변경을 한 트랜잭션으로 묶을 때 사용, 커밋 후 Events.Flush 호출)
+func (s *NoteService) WithTx(tx repository.DBTX) *NoteService {
+	return &NoteService{
+		Repo:         &repository.NoteRepository{DB: tx},
+		Revisions:    &repository.NoteRevisionRepository{DB: tx},
+		Translations: &repository.NoteTranslationRepository{DB: tx},
+		Tasks:        &repository.TaskRepository{DB: tx},
+		Shares:       &repository.NoteShareRepository{DB: tx},
+		Events:       s.Events.WithTx(tx),
+	}
+}
+
+// inTx 함수 정의 (노트 하나의 변경을 한 트랜잭션으로 실행하고 커밋 후 이벤트 전달, 이미 WithTx 복사본이면 호출한 쪽의 트랜잭션 사용)
+func (s *NoteService) inTx(fn func(notes *NoteService) error) error {
+	if s.DB == nil {
+		return fn(s)
+	}
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	notes := s.WithTx(tx)
+	if err := fn(notes); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	notes.Events.Flush()
+	return nil
+}
+
+// publish 함수 정의 (노트의 현재 위치와 버전으로 변경 이벤트 발행)
+func (s *NoteService) publish(eventType string, note *model.Note) error {
+	return s.Events.Publish(&model.NoteEvent{
+		Type:        eventType,
+		NoteID:      note.ID,
+		OwnerID:     note.OwnerID,
+		WorkspaceID: note.WorkspaceID,
+		Version:     note.Version,
+	})
+}
+
+// saveRevision 함수 정의 (현재 노트 상태를 새 리비전으로 저장)
+func (s *NoteService) saveRevision(note *model.Note) error {
+	_, err := s.Revisions.Create(&model.NoteRevision{
+		NoteID:      note.ID,
+		Img:         note.Img,
+		Title:       note.Title,
+		Content:     note.Content,
+		CreatedTime: time.Now(),
+	})
+	return err
 }
 
 // CreateNote 함수 정의
-func (s *NoteService) CreateNote(title, content, img string) (*model.Note, error) {
-	now := time.Now()
+func (s *NoteService) CreateNote(scope model.Scope, title, content, img string) (*model.Note, error) {
+	return s.CreateNoteAt(scope, title, content, img, time.Now(), nil)
+}
+
+// CreateNoteAt 함수 정의 (다른 곳에서 가져온 노트는 원래 작성, 수정 시각으로 저장)
+func (s *NoteService) CreateNoteAt(scope model.Scope, title, content, img string, createdTime time.Time, updatedTime *time.Time) (*model.Note, error) {
 	note := &model.Note{
+		OwnerID:     scope.UserID,
+		WorkspaceID: scope.WorkspaceID,
 		Title:       title,
 		Content:     content,
 		Img:         img,
-		CreatedTime: now,
-		UpdatedTime: nil,
+		CreatedTime: createdTime,
+		UpdatedTime: updatedTime,
+		Version:     1,
 	}
-	id, err := s.Repo.Create(note)
+	err := s.inTx(func(notes *NoteService) error {
+		id, err := notes.Repo.Create(note)
+		if err != nil {
+			return err
+		}
+		note.ID = id
+		if err := notes.saveRevision(note); err != nil {
+			return err
+		}
+		if err := notes.syncTasks(note); err != nil {
+			return err
+		}
+		return notes.publish(model.NoteEventCreated, note)
+	})
 	if err != nil {
 		return nil, err
 	}
-	note.ID = id
 	return note, nil
 }
 
 // GetAllNotes 함수 정의
-func (s *NoteService) GetAllNotes() ([]*model.Note, error) {
-	return s.Repo.GetAll()
+func (s *NoteService) GetAllNotes(scope model.Scope) ([]*model.Note, error) {
+	return s.Repo.GetAll(scope)
 }
 
 // GetNoteByID 함수 정의
-func (s *NoteService) GetNoteByID(id int) (*model.Note, error) {
-	return s.Repo.GetByID(id)
+func (s *NoteService) GetNoteByID(scope model.Scope, id int) (*model.Note, error) {
+	return s.Repo.GetByID(scope, id)
 }
 
-// UpdateNote 함수 정의
-func (s *NoteService) UpdateNote(id int, title, content, img string) (*model.Note, error) {
-	now := time.Now()
-	note := &model.Note{
-		ID:          id,
-		Title:       title,
-		Content:     content,
-		Img:         img,
-		UpdatedTime: &now,
+// GetAccessibleNote 함수 정의 (scope 안의 노트 또는 공유받은 개인 노트와 접근 역할 조회)
+func (s *NoteService) GetAccessibleNote(scope model.Scope, id int) (*model.Note, string, error) {
+	note, err := s.Repo.GetByID(scope, id)
+	if err == nil {
+		return note, model.ShareRoleOwner, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) || scope.WorkspaceID != 0 {
+		return nil, "", err
+	}
+	return s.Repo.GetSharedByID(scope.UserID, id)
+}
+
+// GetSharedNotes 함수 정의 (다른 사용자에게 공유받은 노트 목록)
+func (s *NoteService) GetSharedNotes(userID int) ([]*model.Note, error) {
+	return s.Repo.GetAllShared(userID)
+}
+
+// UpdateNote 함수 정의 (소유자와 편집 권한으로 공유받은 사용자만 수정 가능)
+func (s *NoteService) UpdateNote(scope model.Scope, id int, title, content, img string) (*model.Note, error) {
+	return s.UpdateNoteIfVersion(scope, id, 0, title, content, img)
+}
+
+// UpdateNoteIfVersion 함수 정의 (version이 0이 아니면 현재 버전이 같을 때만 수정)
+func (s *NoteService) UpdateNoteIfVersion(scope model.Scope, id, version int, title, content, img string) (*model.Note, error) {
+	var updatedNote *model.Note
+	err := s.inTx(func(notes *NoteService) error {
+		existing, role, err := notes.GetAccessibleNote(scope, id)
+		if err != nil {
+			return err
+		}
+		if role == model.ShareRoleViewer {
+			return ErrNoteReadOnly
+		}
+		// 공유받은 노트는 소유자의 개인 노트 범위에서 수정
+		if role != model.ShareRoleOwner {
+			scope = model.Scope{UserID: existing.OwnerID}
+		}
+
+		now := time.Now()
+		note := &model.Note{
+			ID:          id,
+			Title:       title,
+			Content:     content,
+			Img:         img,
+			UpdatedTime: &now,
+			Version:     version,
+		}
+
+		updated, err := notes.Repo.Update(scope, note)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return versionMismatch(version)
+		}
+
+		// 업데이트된 노트를 다시 조회
+		updatedNote, err = notes.Repo.GetByID(scope, id)
+		if err != nil {
+			return err
+		}
+		if err := notes.saveRevision(updatedNote); err != nil {
+			return err
+		}
+		// 원본이 바뀌었으므로 번역본은 다시 번역 필요
+		if err := notes.Translations.MarkStale(id); err != nil {
+			return err
+		}
+		if err := notes.syncTasks(updatedNote); err != nil {
+			return err
+		}
+		return notes.publish(model.NoteEventUpdated, updatedNote)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedNote, nil
+}
+
+// versionMismatch 함수 정의 (조회와 수정 사이에 노트가 바뀌거나 삭제되어 아무 행도 바뀌지 않았을 때의 오류)
+func versionMismatch(version int) error {
+	if version == 0 {
+		return model.NotFound("note not found")
+	}
+	return ErrVersionMismatch
+}
+
+// DeleteNote 함수 정의
+func (s *NoteService) DeleteNote(scope model.Scope, id int) error {
+	return s.DeleteNoteIfVersion(scope, id, 0)
+}
+
+// DeleteNoteIfVersion 함수 정의 (version이 0이 아니면 현재 버전이 같을 때만 삭제)
+func (s *NoteService) DeleteNoteIfVersion(scope model.Scope, id, version int) error {
+	return s.inTx(func(notes *NoteService) error {
+		// 다른 사용자의 노트에 딸린 데이터를 지우지 않도록 먼저 확인
+		existing, err := notes.Repo.GetByID(scope, id)
+		if err != nil {
+			return err
+		}
+		deleted, err := notes.Repo.Delete(scope, id, version)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return versionMismatch(version)
+		}
+		if err := notes.Translations.DeleteByNoteID(id); err != nil {
+			return err
+		}
+		if err := notes.Tasks.DeleteByNoteID(id); err != nil {
+			return err
+		}
+		if err := notes.Shares.DeleteByNoteID(id); err != nil {
+			return err
+		}
+		if err := notes.Revisions.DeleteByNoteID(id); err != nil {
+			return err
+		}
+		return notes.publish(model.NoteEventDeleted, existing)
+	})
+}
+
+// MoveNote 함수 정의 (개인 노트와 워크스페이스 사이 이동, 대상 워크스페이스 권한은 호출하는 쪽에서 확인)
+func (s *NoteService) MoveNote(scope model.Scope, id, version, workspaceID int) (*model.Note, error) {
+	if workspaceID == scope.WorkspaceID {
+		return nil, model.Validation("note is already in the target workspace")
 	}
+	var note *model.Note
+	err := s.inTx(func(notes *NoteService) error {
+		existing, err := notes.Repo.GetByID(scope, id)
+		if err != nil {
+			return err
+		}
 
-	err := s.Repo.Update(note)
+		// 개인 노트로 옮기면 옮긴 사용자의 노트가 됨
+		ownerID := existing.OwnerID
+		if workspaceID == 0 {
+			ownerID = scope.UserID
+		}
+		moved, err := notes.Repo.Move(scope, id, version, ownerID, workspaceID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return versionMismatch(version)
+		}
+		// 워크스페이스 노트는 개별 공유할 수 없으므로 공유와 공개 링크 삭제
+		if workspaceID != 0 {
+			if err := notes.Shares.DeleteByNoteID(id); err != nil {
+				return err
+			}
+		}
+		note, err = notes.Repo.GetByID(model.Scope{UserID: ownerID, WorkspaceID: workspaceID}, id)
+		if err != nil {
+			return err
+		}
+		// 이전 위치의 구독자도 노트가 빠져나간 것을 알 수 있도록 이전 위치 포함
+		return notes.Events.Publish(&model.NoteEvent{
+			Type:            model.NoteEventMoved,
+			NoteID:          note.ID,
+			OwnerID:         note.OwnerID,
+			WorkspaceID:     note.WorkspaceID,
+			FromOwnerID:     existing.OwnerID,
+			FromWorkspaceID: existing.WorkspaceID,
+			Version:         note.Version,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
+	return note, nil
+}
 
-	// 업데이트된 노트를 다시 조회
-	updatedNote, err := s.Repo.GetByID(id)
+// GetNoteRevisions 함수 정의
+func (s *NoteService) GetNoteRevisions(scope model.Scope, id int) ([]*model.NoteRevision, error) {
+	if _, err := s.Repo.GetByID(scope, id); err != nil {
+		return nil, err
+	}
+	return s.Revisions.GetByNoteID(id)
+}
+
+// RestoreRevision 함수 정의 (리비전의 제목, 내용, 이미지로 노트를 되돌림, 이력은 지우지 않고 새 리비전으로 저장)
+func (s *NoteService) RestoreRevision(scope model.Scope, id, version, revision int) (*model.Note, error) {
+	var restored *model.Note
+	err := s.inTx(func(notes *NoteService) error {
+		note, err := notes.Repo.GetByID(scope, id)
+		if err != nil {
+			return err
+		}
+		rev, err := notes.Revisions.GetByRevision(id, revision)
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			version = note.Version
+		}
+		restored, err = notes.UpdateNoteIfVersion(scope, id, version, rev.Title, rev.Content, rev.Img)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return restored, nil
+}
 
-	return updatedNote, nil
+// SearchNotes 함수 정의
+func (s *NoteService) SearchNotes(scope model.Scope, query string) ([]*model.Note, error) {
+	return s.Repo.Search(scope, query)
 }
 
-// DeleteNote 함수 정의
-func (s *NoteService) DeleteNote(id int) error {
-	return s.Repo.Delete(id)
+// syncTasks 함수 정의 (내용의 체크박스와 할 일 목록을 맞춤, 같은 텍스트의 할 일은 ID 유지)
+func (s *NoteService) syncTasks(note *model.Note) error {
+	existing, err := s.Tasks.GetByNoteID(note.ID)
+	if err != nil {
+		return err
+	}
+
+	var unmatched []*model.Task
+	for _, task := range existing {
+		if task.Source == model.TaskSourceCheckbox {
+			unmatched = append(unmatched, task)
+		}
+	}
+
+	now := time.Now()
+	for _, item := range utils.ParseChecklist(note.Content) {
+		var task *model.Task
+		for i, candidate := range unmatched {
+			if candidate.Text == item.Text {
+				task = candidate
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				break
+			}
+		}
+
+		if task == nil {
+			_, err := s.Tasks.Create(&model.Task{
+				NoteID:      note.ID,
+				Text:        item.Text,
+				Done:        item.Done,
+				DueDate:     item.DueDate,
+				Source:      model.TaskSourceCheckbox,
+				SpanStart:   item.Start,
+				SpanEnd:     item.End,
+				CreatedTime: now,
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		task.Done = item.Done
+		task.DueDate = item.DueDate
+		task.SpanStart = item.Start
+		task.SpanEnd = item.End
+		task.UpdatedTime = &now
+		if err := s.Tasks.Update(task); err != nil {
+			return err
+		}
+	}
+
+	// 내용에서 사라진 체크박스
+	for _, task := range unmatched {
+		if err := s.Tasks.Delete(task.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"sync"
+	"testing"
+)
+
+func TestRestoreRevisionSavesANewRevision(t *testing.T) {
+	notes := newTestNoteService(newTestDB(t))
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "first", "original content", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := notes.UpdateNote(scope, note.ID, "second", "edited content", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := notes.RestoreRevision(scope, note.ID, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Title != "first" || restored.Content != "original content" {
+		t.Fatalf("restored note = %q / %q, want the first revision", restored.Title, restored.Content)
+	}
+	revisions, err := notes.GetNoteRevisions(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 || revisions[0].Revision != 3 || revisions[0].Content != "original content" {
+		t.Fatalf("revisions after restore = %d (latest %+v), want 3 with the restored content on top", len(revisions), revisions[0])
+	}
+
+	if _, err := notes.RestoreRevision(scope, note.ID, restored.Version-1, 2); !errors.Is(err, ErrVersionMismatch) {
+		t.Fatalf("restore with a stale version: %v, want ErrVersionMismatch", err)
+	}
+	if _, err := notes.RestoreRevision(scope, note.ID, 0, 9); !errors.Is(err, model.NotFound("revision not found")) {
+		t.Fatalf("restore of a missing revision: %v", err)
+	}
+	if _, err := notes.RestoreRevision(model.Scope{UserID: 2}, note.ID, 0, 1); !errors.Is(err, model.NotFound("note not found")) {
+		t.Fatalf("restore by another user: %v", err)
+	}
+}
+
+func TestFailedUpdateLeavesNoPartialChange(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	scope := model.Scope{UserID: 1}
+	note, err := notes.CreateNote(scope, "title", "- [ ] milk", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := notes.Events.Subscribe(scope, model.NoteEventFilter{})
+	defer notes.Events.Unsubscribe(sub)
+
+	// 노트 행을 바꾼 뒤 리비전 저장에서 실패하도록 만듦
+	if _, err := db.Exec("DROP TABLE note_revisions"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := notes.UpdateNote(scope, note.ID, "changed", "- [x] milk", ""); err == nil {
+		t.Fatal("update without a revisions table succeeded")
+	}
+
+	current, err := notes.GetNoteByID(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Title != "title" || current.Version != note.Version {
+		t.Fatalf("note after a failed update = %q version %d, want it unchanged", current.Title, current.Version)
+	}
+	if got := receivedEvents(sub); len(got) != 0 {
+		t.Fatalf("failed update delivered %v", got)
+	}
+	if history, _ := notes.Events.History(scope, model.NoteEventFilter{}); len(history) != 1 {
+		t.Fatalf("stored events = %d, want only note.created", len(history))
+	}
+}
+
+func TestConcurrentNoteChangesWaitForEachOther(t *testing.T) {
+	notes := newTestNoteService(newTestDB(t))
+	scope := model.Scope{UserID: 1}
+	note, err := notes.CreateNote(scope, "title", "content", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 읽은 뒤 쓰는 트랜잭션끼리 SQLite 잠금으로 실패하지 않아야 함
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				_, errs[i] = notes.UpdateNote(scope, note.ID, "title", "content", "")
+			} else {
+				_, errs[i] = notes.CreateNote(scope, "other", "content", "")
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent change failed: %v", err)
+		}
+	}
+	current, err := notes.GetNoteByID(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Version != note.Version+len(errs)/2 {
+		t.Fatalf("version = %d, want %d after every update", current.Version, note.Version+len(errs)/2)
+	}
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultPromptTemplate 분석 요청에 템플릿이 지정되지 않았을 때 사용
+const DefaultPromptTemplate = "analyze"
+
+// PromptVars 구조체 정의 (템플릿에서 사용할 수 있는 변수)
+type PromptVars struct {
+	Title    string
+	Content  string
+	Image    string
+	Request  string
+	Language string
+}
+
+// 기본 템플릿 목록 (서버 시작 시 없으면 생성)
+var defaultPromptTemplates = []model.PromptTemplate{
+	{
+		Name:        "analyze",
+		Description: "Free-form analysis of the note following the user's request",
+		Template:    "Analyze the content and image of the following note. Request: {{.Request}}\nContent: {{.Content}}\nImage URL: {{.Image}}",
+	},
+	{
+		Name:        "summarize",
+		Description: "Short summary of the note",
+		Template:    "Summarize the following note in {{.Language}} in a few sentences.{{if .Request}} {{.Request}}{{end}}\nTitle: {{.Title}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "translate",
+		Description: "Translate the note into the requested language",
+		Template:    "Translate the following note into {{.Language}}. Keep the original formatting and return only the translation.{{if .Request}} {{.Request}}{{end}}\nTitle: {{.Title}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "extract_action_items",
+		Description: "List the action items found in the note",
+		Template:    "Extract every action item from the following note as a markdown checklist (\"- [ ] ...\") written in {{.Language}}. Return only the list.{{if .Request}} {{.Request}}{{end}}\nTitle: {{.Title}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "proofread",
+		Description: "Fix spelling and grammar without changing the meaning",
+		Template:    "Proofread the following note written in {{.Language}}. Fix spelling and grammar without changing the meaning and return only the corrected text.{{if .Request}} {{.Request}}{{end}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "translate_variant",
+		Description: "Translation stored as a localized variant of the note",
+		Template:    "Translate the title and content of the following note into {{.Language}}. Keep markdown formatting, names and code unchanged. Respond with a JSON object {\"title\": string, \"content\": string}.{{if .Request}} {{.Request}}{{end}}\nTitle: {{.Title}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "assist_rewrite",
+		Description: "Writing assist: rewrite the note content",
+		Template:    "Rewrite the following note to be clearer and better organized. Keep the language, meaning and markdown formatting. Return only the rewritten content.{{if .Request}} {{.Request}}{{end}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "assist_shorten",
+		Description: "Writing assist: shorten the note content",
+		Template:    "Shorten the following note to about half its length while keeping the key points, language and markdown formatting. Return only the shortened content.{{if .Request}} {{.Request}}{{end}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "assist_expand",
+		Description: "Writing assist: expand the note content",
+		Template:    "Expand the following note with more detail and explanation. Keep the language and markdown formatting. Return only the expanded content.{{if .Request}} {{.Request}}{{end}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "assist_fix_grammar",
+		Description: "Writing assist: fix spelling and grammar",
+		Template:    "Fix spelling and grammar mistakes in the following note without changing its meaning, language or formatting. Return only the corrected content.{{if .Request}} {{.Request}}{{end}}\nContent:\n{{.Content}}",
+	},
+	{
+		Name:        "assist_continue",
+		Description: "Writing assist: continue writing the note",
+		Template:    "Continue writing the following note in the same language, tone and formatting. Return only the new text that should be appended, without repeating the existing content.{{if .Request}} {{.Request}}{{end}}\nTitle: {{.Title}}\nContent:\n{{.Content}}",
+	},
+}
+
+// internalPromptTemplates 기능이 이름으로 찾는 기본 템플릿 (지우거나 바꾸면 해당 기능이 동작하지 않으므로 관리자도 수정, 삭제 불가)
+var internalPromptTemplates = map[string]bool{
+	DefaultPromptTemplate:  true,
+	"extract_action_items": true,
+	"translate_variant":    true,
+	"assist_rewrite":       true,
+	"assist_shorten":       true,
+	"assist_expand":        true,
+	"assist_fix_grammar":   true,
+	"assist_continue":      true,
+}
+
+// ErrInternalTemplate 내부용 템플릿을 만들거나 수정, 삭제하려 할 때
+var ErrInternalTemplate = model.Forbidden("this prompt template is used by a built-in feature and cannot be created, changed or deleted")
+
+// IsInternalTemplate 함수 정의
+func IsInternalTemplate(name string) bool {
+	return internalPromptTemplates[name]
+}
+
+// PromptService 구조체 정의
+type PromptService struct {
+	Repo *repository.PromptTemplateRepository
+}
+
+// NewPromptService 함수 정의
+func NewPromptService(repo *repository.PromptTemplateRepository) *PromptService {
+	return &PromptService{Repo: repo}
+}
+
+// SeedDefaults 함수 정의 (기본 템플릿이 없으면 생성, 사용자가 수정한 템플릿은 유지)
+func (s *PromptService) SeedDefaults() error {
+	for _, tmpl := range defaultPromptTemplates {
+		_, err := s.Repo.GetByName(tmpl.Name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		seed := tmpl
+		seed.CreatedTime = time.Now()
+		if _, err := s.Repo.Create(&seed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePromptTemplate 함수 정의
+func parsePromptTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// validateTemplate 함수 정의 (이름, 문법, 변수 확인)
+func validateTemplate(name, text string) error {
+	if strings.TrimSpace(name) == "" {
+		return model.Validation("template name is required")
+	}
+	if strings.TrimSpace(text) == "" {
+		return model.Validation("template text is required")
+	}
+	t, err := parsePromptTemplate(name, text)
+	if err != nil {
+		return model.Validation(fmt.Sprintf("invalid template: %v", err))
+	}
+	// 빈 변수로 한 번 실행해서 존재하지 않는 변수 사용 여부 확인
+	if err := t.Execute(&strings.Builder{}, PromptVars{}); err != nil {
+		return model.Validation(fmt.Sprintf("invalid template: %v", err))
+	}
+	return nil
+}
+
+// CreateTemplate 함수 정의
+func (s *PromptService) CreateTemplate(name, description, text string) (*model.PromptTemplate, error) {
+	if err := validateTemplate(name, text); err != nil {
+		return nil, err
+	}
+	if IsInternalTemplate(name) {
+		return nil, ErrInternalTemplate
+	}
+	tmpl := &model.PromptTemplate{
+		Name:        name,
+		Description: description,
+		Template:    text,
+		CreatedTime: time.Now(),
+	}
+	id, err := s.Repo.Create(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.ID = id
+	return tmpl, nil
+}
+
+// GetAllTemplates 함수 정의
+func (s *PromptService) GetAllTemplates() ([]*model.PromptTemplate, error) {
+	return s.Repo.GetAll()
+}
+
+// GetTemplateByID 함수 정의
+func (s *PromptService) GetTemplateByID(id int) (*model.PromptTemplate, error) {
+	return s.Repo.GetByID(id)
+}
+
+// UpdateTemplate 함수 정의
+func (s *PromptService) UpdateTemplate(id int, name, description, text string) (*model.PromptTemplate, error) {
+	if err := validateTemplate(name, text); err != nil {
+		return nil, err
+	}
+	current, err := s.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if IsInternalTemplate(current.Name) || IsInternalTemplate(name) {
+		return nil, ErrInternalTemplate
+	}
+	now := time.Now()
+	tmpl := &model.PromptTemplate{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Template:    text,
+		UpdatedTime: &now,
+	}
+	if err := s.Repo.Update(tmpl); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetByID(id)
+}
+
+// DeleteTemplate 함수 정의
+func (s *PromptService) DeleteTemplate(id int) error {
+	current, err := s.Repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if IsInternalTemplate(current.Name) {
+		return ErrInternalTemplate
+	}
+	return s.Repo.Delete(id)
+}
+
+// RenderForNote 함수 정의 (이름으로 템플릿을 찾아 노트 정보로 프롬프트 생성)
+func (s *PromptService) RenderForNote(name string, note *model.Note, request, language string) (string, error) {
+	if name == "" {
+		name = DefaultPromptTemplate
+	}
+	if language == "" {
+		language = "the same language as the note"
+	}
+
+	tmpl, err := s.Repo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", model.NotFound(fmt.Sprintf("prompt template %q not found", name))
+		}
+		return "", err
+	}
+
+	t, err := parsePromptTemplate(tmpl.Name, tmpl.Template)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	err = t.Execute(&sb, PromptVars{
+		Title:    note.Title,
+		Content:  note.Content,
+		Image:    note.Img,
+		Request:  request,
+		Language: language,
+	})
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
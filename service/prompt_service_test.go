@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"testing"
+)
+
+func newTestPromptService(t *testing.T) *PromptService {
+	prompts := NewPromptService(repository.NewPromptTemplateRepository(newTestDB(t)))
+	if err := prompts.SeedDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	return prompts
+}
+
+func TestInternalPromptTemplatesCannotBeChanged(t *testing.T) {
+	prompts := newTestPromptService(t)
+	for _, name := range []string{DefaultPromptTemplate, "translate_variant", "assist_rewrite"} {
+		tmpl, err := prompts.Repo.GetByName(name)
+		if err != nil {
+			t.Fatalf("%s was not seeded: %v", name, err)
+		}
+		if _, err := prompts.UpdateTemplate(tmpl.ID, name, "changed", "{{.Content}}"); !errors.Is(err, ErrInternalTemplate) {
+			t.Fatalf("update %s: %v, want ErrInternalTemplate", name, err)
+		}
+		if _, err := prompts.UpdateTemplate(tmpl.ID, name+"_old", "renamed", tmpl.Template); !errors.Is(err, ErrInternalTemplate) {
+			t.Fatalf("rename %s: %v, want ErrInternalTemplate", name, err)
+		}
+		if err := prompts.DeleteTemplate(tmpl.ID); !errors.Is(err, ErrInternalTemplate) {
+			t.Fatalf("delete %s: %v, want ErrInternalTemplate", name, err)
+		}
+	}
+
+	// 다른 템플릿을 내부용 이름으로 바꿀 수도 없음
+	summarize, err := prompts.Repo.GetByName("summarize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prompts.UpdateTemplate(summarize.ID, "assist_continue", "", "{{.Content}}"); !errors.Is(err, ErrInternalTemplate) {
+		t.Fatalf("rename to an internal name: %v", err)
+	}
+	if _, err := prompts.UpdateTemplate(summarize.ID, "summarize", "shorter", "Summarize: {{.Content}}"); err != nil {
+		t.Fatalf("update of a regular template: %v", err)
+	}
+	if err := prompts.DeleteTemplate(summarize.ID); err != nil {
+		t.Fatalf("delete of a regular template: %v", err)
+	}
+}
+
+func TestDefaultPromptTemplatesRender(t *testing.T) {
+	prompts := newTestPromptService(t)
+	templates, err := prompts.GetAllTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	note := &model.Note{Title: "Groceries", Content: "milk and eggs", Img: "cat.png"}
+	for _, tmpl := range templates {
+		prompt, err := prompts.RenderForNote(tmpl.Name, note, "", "Korean")
+		if err != nil {
+			t.Fatalf("render %s: %v", tmpl.Name, err)
+		}
+		if !strings.Contains(prompt, note.Content) {
+			t.Fatalf("%s prompt does not contain the note content: %q", tmpl.Name, prompt)
+		}
+	}
+}
+
+func TestCreateTemplateRejectsUnknownVariables(t *testing.T) {
+	prompts := newTestPromptService(t)
+	_, err := prompts.CreateTemplate("tagged", "", "Tags: {{.Tags}}")
+	var modelErr *model.Error
+	if !errors.As(err, &modelErr) || modelErr.Code != model.ErrCodeValidation {
+		t.Fatalf("template using .Tags: %v, want a validation error", err)
+	}
+}
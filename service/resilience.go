@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"myapp/model"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AI 제공자 오류 종류 (502/503/504로 응답)
+var (
+	ErrUpstreamFailed      = model.Upstream("AI provider returned an error")
+	ErrUpstreamUnavailable = model.NewError(model.ErrCodeUpstreamUnavailable, "AI provider is temporarily unavailable")
+	ErrUpstreamTimeout     = model.NewError(model.ErrCodeUpstreamTimeout, "AI provider timed out")
+)
+
+// AIError 구조체 정의 (원본 오류는 로그용으로만 보관)
+type AIError struct {
+	Kind error
+	Err  error
+}
+
+func (e *AIError) Error() string   { return e.Kind.Error() }
+func (e *AIError) Unwrap() []error { return []error{e.Kind, e.Err} }
+
+// httpCoder Google API 오류의 HTTP 상태 코드 조회용
+type httpCoder interface {
+	HTTPCode() int
+}
+
+// classifyAIError 함수 정의 (오류 종류와 재시도 가능 여부 판단)
+func classifyAIError(err error) (kind error, transient bool) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrUpstreamTimeout, true
+	}
+
+	var coder httpCoder
+	if errors.As(err, &coder) {
+		switch code := coder.HTTPCode(); {
+		case code == http.StatusGatewayTimeout:
+			return ErrUpstreamTimeout, true
+		case code == http.StatusTooManyRequests, code == http.StatusServiceUnavailable:
+			return ErrUpstreamUnavailable, true
+		case code >= 500:
+			return ErrUpstreamFailed, true
+		case code > 0:
+			return ErrUpstreamFailed, false
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrUpstreamTimeout, true
+		}
+		return ErrUpstreamUnavailable, true
+	}
+	return ErrUpstreamFailed, false
+}
+
+// RetryPolicy 구조체 정의
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// delay 함수 정의 (지수 백오프에 full jitter 적용)
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// sleepContext 함수 정의 (컨텍스트가 끝나면 바로 반환)
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// 서킷 브레이커 상태
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker 구조체 정의 (연속 실패가 threshold를 넘으면 cooldown 동안 바로 실패)
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker 함수 정의 (threshold가 0 이하이면 비활성)
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Allow 함수 정의 (열린 상태면 ErrUpstreamUnavailable, cooldown 후에는 시험 호출 하나만 허용)
+func (b *CircuitBreaker) Allow() error {
+	if b == nil || b.Threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.Cooldown {
+			return ErrUpstreamUnavailable
+		}
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		return ErrUpstreamUnavailable
+	}
+	return nil
+}
+
+// Success 함수 정의
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+// Failure 함수 정의
+func (b *CircuitBreaker) Failure() {
+	if b == nil || b.Threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.Threshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Release 함수 정의 (시험 호출이 업스트림 상태를 확인하지 못하고 끝난 경우, 열린 상태로 되돌려 다음 호출이 다시 시험)
+// openedAt을 유지하므로 cooldown은 이미 지난 상태이고, 닫지 않으므로 확인되지 않은 업스트림에 전체 요청을 보내지 않음
+func (b *CircuitBreaker) Release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerReleaseKeepsProbing(t *testing.T) {
+	breaker := NewCircuitBreaker(1, time.Millisecond)
+	breaker.Failure()
+	if err := breaker.Allow(); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("open breaker allowed a call: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	// cooldown 후 시험 호출 하나만 허용
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("probe not allowed after cooldown: %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("second call allowed while probing: %v", err)
+	}
+
+	// 시험 호출이 취소되면 닫지 않고 다음 호출이 다시 시험
+	breaker.Release()
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("next probe not allowed after release: %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("breaker closed without a successful probe: %v", err)
+	}
+
+	breaker.Success()
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("breaker still open after success: %v", err)
+	}
+}
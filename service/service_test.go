@@ -0,0 +1,44 @@
+package service
+
+import (
+	"database/sql"
+	"myapp/repository"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestDB 함수 정의 (임시 디렉터리의 SQLite 데이터베이스에 스키마 생성)
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := repository.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.InitializeSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// newTestNoteService 함수 정의
+func newTestNoteService(db *sql.DB) *NoteService {
+	return NewNoteService(db, repository.NewNoteRepository(db), repository.NewNoteRevisionRepository(db), repository.NewNoteTranslationRepository(db),
+		repository.NewTaskRepository(db), repository.NewNoteShareRepository(db), NewEventBus(repository.NewNoteEventRepository(db)))
+}
+
+// chdirTemp 함수 정의 (업로드 파일이 저장소 안에 남지 않도록 임시 디렉터리에서 실행)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
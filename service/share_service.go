@@ -0,0 +1,223 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrSharePasswordRequired 비밀번호가 걸린 공개 링크에 비밀번호가 없거나 틀렸을 때 반환
+var ErrSharePasswordRequired = model.Unauthorized("a valid password is required for this link")
+
+// ErrInvalidImageSignature 공개 노트 이미지 서명 URL이 틀렸거나 만료되었을 때 반환
+var ErrInvalidImageSignature = model.Unauthorized("image link is invalid or expired, reload the note")
+
+// ErrWorkspaceNoteShare 워크스페이스 노트를 개별 공유하려 할 때 반환
+var ErrWorkspaceNoteShare = model.Validation("workspace notes cannot be shared individually, invite the user to the workspace instead")
+
+// ShareLinkPrefix 공개 링크 토큰 접두사
+const ShareLinkPrefix = "shr_"
+
+// SignedImageTTL 공개 노트 이미지 서명 URL 유효 기간
+const SignedImageTTL = 5 * time.Minute
+
+// ShareService 구조체 정의 (노트 공유와 공개 링크 관리, 모두 소유자만 가능)
+type ShareService struct {
+	Repo       *repository.NoteShareRepository
+	Notes      *NoteService
+	Users      *repository.UserRepository
+	SigningKey []byte
+}
+
+// NewShareService 함수 정의 (signingKey는 이미지 서명 URL에 사용)
+func NewShareService(repo *repository.NoteShareRepository, notes *NoteService, users *repository.UserRepository, signingKey string) *ShareService {
+	return &ShareService{Repo: repo, Notes: notes, Users: users, SigningKey: []byte(signingKey)}
+}
+
+// personalNote 함수 정의 (공유와 공개 링크는 개인 노트에서만 가능)
+func (s *ShareService) personalNote(scope model.Scope, noteID int) (*model.Note, error) {
+	if scope.WorkspaceID != 0 {
+		return nil, ErrWorkspaceNoteShare
+	}
+	return s.Notes.GetNoteByID(scope, noteID)
+}
+
+// ShareNote 함수 정의 (email 사용자에게 viewer 또는 editor 권한으로 공유)
+func (s *ShareService) ShareNote(scope model.Scope, noteID int, email, role string) (*model.NoteShare, error) {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return nil, err
+	}
+	if role != model.ShareRoleViewer && role != model.ShareRoleEditor {
+		return nil, model.Validation(fmt.Sprintf("role must be %q or %q", model.ShareRoleViewer, model.ShareRoleEditor))
+	}
+
+	user, err := s.Users.GetByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NotFound(fmt.Sprintf("no user with email %q", email))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == scope.UserID {
+		return nil, model.Validation("cannot share a note with its owner")
+	}
+
+	share := &model.NoteShare{
+		NoteID:      noteID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		Role:        role,
+		CreatedTime: time.Now(),
+	}
+	if err := s.Repo.Upsert(share); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// GetShares 함수 정의
+func (s *ShareService) GetShares(scope model.Scope, noteID int) ([]*model.NoteShare, error) {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetByNoteID(noteID)
+}
+
+// RemoveShare 함수 정의
+func (s *ShareService) RemoveShare(scope model.Scope, noteID, userID int) error {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return err
+	}
+	ok, err := s.Repo.Delete(noteID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.NotFound("share not found").Wrap(sql.ErrNoRows)
+	}
+	return nil
+}
+
+// CreateLink 함수 정의 (링크 토큰 원문은 이때 한 번만 반환, password가 비어 있으면 비밀번호 없음, expiresIn이 0이면 만료 없음)
+func (s *ShareService) CreateLink(scope model.Scope, noteID int, password string, expiresIn time.Duration) (*model.ShareLink, string, error) {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return nil, "", err
+	}
+	if expiresIn < 0 {
+		return nil, "", model.Validation("expiry must not be negative")
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	raw := ShareLinkPrefix + secret
+
+	now := time.Now()
+	link := &model.ShareLink{
+		NoteID:      noteID,
+		UserID:      scope.UserID,
+		Prefix:      raw[:len(ShareLinkPrefix)+8],
+		TokenHash:   hashToken(raw),
+		CreatedTime: now,
+	}
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, "", err
+		}
+		link.PasswordHash = string(hash)
+	}
+	if expiresIn > 0 {
+		expires := now.Add(expiresIn)
+		link.ExpiresTime = &expires
+	}
+	id, err := s.Repo.CreateLink(link)
+	if err != nil {
+		return nil, "", err
+	}
+	link.ID = id
+	return link, raw, nil
+}
+
+// GetLinks 함수 정의
+func (s *ShareService) GetLinks(scope model.Scope, noteID int) ([]*model.ShareLink, error) {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetLinksByNoteID(noteID)
+}
+
+// RevokeLink 함수 정의
+func (s *ShareService) RevokeLink(scope model.Scope, noteID, linkID int) error {
+	if _, err := s.personalNote(scope, noteID); err != nil {
+		return err
+	}
+	ok, err := s.Repo.RevokeLink(noteID, linkID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.NotFound("share link not found").Wrap(sql.ErrNoRows)
+	}
+	return nil
+}
+
+// OpenLink 함수 정의 (유효한 공개 링크면 노트 반환, 폐기되었거나 만료된 링크는 ErrInvalidToken)
+func (s *ShareService) OpenLink(raw, password string) (*model.Note, error) {
+	return s.openLink(raw, password, true)
+}
+
+// SignImage 함수 정의 (비밀번호를 확인한 뒤 이미지 요청에 쓸 만료 시각과 서명, URL에 비밀번호를 넣지 않기 위해 사용)
+func (s *ShareService) SignImage(raw string, now time.Time) (int64, string) {
+	expires := now.Add(SignedImageTTL).Unix()
+	return expires, s.imageSignature(raw, expires)
+}
+
+// OpenLinkSigned 함수 정의 (서명이 맞고 만료 전이면 비밀번호 없이 노트 반환, 링크 폐기와 만료는 그대로 확인)
+func (s *ShareService) OpenLinkSigned(raw string, expires int64, signature string) (*model.Note, error) {
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(signature), []byte(s.imageSignature(raw, expires))) {
+		return nil, ErrInvalidImageSignature
+	}
+	return s.openLink(raw, "", false)
+}
+
+// imageSignature 함수 정의
+func (s *ShareService) imageSignature(raw string, expires int64) string {
+	mac := hmac.New(sha256.New, s.SigningKey)
+	fmt.Fprintf(mac, "share-image\n%s\n%d", raw, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// openLink 함수 정의
+func (s *ShareService) openLink(raw, password string, checkPassword bool) (*model.Note, error) {
+	link, err := s.Repo.GetLinkByHash(hashToken(raw))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if link.RevokedTime != nil || (link.ExpiresTime != nil && time.Now().After(*link.ExpiresTime)) {
+		return nil, ErrInvalidToken
+	}
+	if checkPassword && link.PasswordHash != "" && bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+		return nil, ErrSharePasswordRequired
+	}
+
+	// 링크를 만든 소유자 기준으로 조회 (노트가 삭제되었으면 찾을 수 없음)
+	note, err := s.Notes.GetNoteByID(model.Scope{UserID: link.UserID}, link.NoteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidToken
+	}
+	return note, err
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"myapp/model"
+	"myapp/repository"
+	"time"
+)
+
+// 동기화 방식
+//
+// 받기: 커서(마지막으로 받은 노트 이벤트 ID) 이후 바뀐 노트마다 현재 상태 하나를 보냄.
+// 삭제되었거나 scope 밖으로 옮겨진 노트는 tombstone(deleted)으로 보냄. 커서가 없으면 scope의 전체 노트.
+// 같은 변경을 다시 받아도 결과가 같으므로 클라이언트는 받은 커서를 적용이 끝난 뒤에 저장하면 됨.
+//
+// 보내기: 변경마다 따로 적용하고(하나가 실패해도 나머지는 적용) 결과를 돌려줌.
+// 오프라인에서 만든 노트는 client_id로 구분하며, 같은 client_id로 다시 보내도 노트가 두 번 만들어지지 않음.
+// client_id는 사용자의 개인 노트와 워크스페이스마다 따로 관리하므로 다른 scope의 같은 client_id와 섞이지 않음.
+//
+// 충돌 처리
+//   - 수정과 수정: base_version이 현재 버전과 다르면 서버 내용을 유지하고, 클라이언트 내용은 "(conflicted copy)" 노트로 새로 만듦
+//   - 수정과 삭제: 서버에서 삭제된 노트를 클라이언트가 수정했으면 클라이언트 내용으로 새 노트를 만듦 (수정이 삭제보다 우선)
+//   - 삭제와 수정: 서버에서 수정된 노트를 클라이언트가 삭제했으면 삭제하지 않고 서버 내용 유지
+//   - 삭제와 삭제: 이미 삭제된 노트의 삭제는 성공으로 처리
+//   - 내용이 같은 수정은 버전과 관계없이 충돌이 아님
+
+// 동기화 변경 종류
+const (
+	SyncOpUpsert = "upsert"
+	SyncOpDelete = "delete"
+)
+
+// 동기화 변경 결과
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusError    = "error"
+)
+
+// 충돌 또는 특수한 경우의 처리 방법
+const (
+	SyncResolutionConflictCopy   = "conflict_copy"
+	SyncResolutionRecreated      = "recreated"
+	SyncResolutionServerWins     = "server_wins"
+	SyncResolutionAlreadyDeleted = "already_deleted"
+	SyncResolutionUnchanged      = "unchanged"
+)
+
+// MaxSyncChanges 요청 하나에 담을 수 있는 최대 변경 수, DefaultSyncPageSize 받기 한 번에 읽는 이벤트 수
+const (
+	MaxSyncChanges      = 500
+	DefaultSyncPageSize = 500
+)
+
+// conflictCopySuffix 충돌한 클라이언트 내용으로 만든 노트 제목 뒤에 붙임
+const conflictCopySuffix = " (conflicted copy)"
+
+// SyncChange 구조체 정의 (클라이언트 변경, 대상은 ID 또는 ClientID로 지정, BaseVersion은 클라이언트가 마지막으로 받은 버전)
+type SyncChange struct {
+	Op          string
+	ID          int
+	ClientID    string
+	BaseVersion int
+	Title       string
+	Content     string
+	Img         string
+}
+
+// SyncResult 구조체 정의 (Note는 적용 후 서버 상태, 삭제되면 nil, Action은 감사 기록용 실제 변경 종류)
+type SyncResult struct {
+	ClientID     string
+	NoteID       int
+	Status       string
+	Resolution   string
+	Action       string
+	Note         *model.Note
+	Before       *model.Note
+	ConflictCopy *model.Note
+	Err          error
+}
+
+// SyncedNote 구조체 정의 (받기 결과 하나, Note가 nil이면 tombstone)
+type SyncedNote struct {
+	NoteID   int
+	ClientID string
+	Note     *model.Note
+}
+
+// SyncService 구조체 정의
+type SyncService struct {
+	DB        *sql.DB
+	Notes     *NoteService
+	ClientIDs *repository.SyncClientIDRepository
+}
+
+// NewSyncService 함수 정의
+func NewSyncService(db *sql.DB, notes *NoteService, clientIDs *repository.SyncClientIDRepository) *SyncService {
+	return &SyncService{DB: db, Notes: notes, ClientIDs: clientIDs}
+}
+
+// Snapshot 함수 정의 (scope의 전체 노트와 이후 받기에 쓸 커서, 커서를 먼저 읽어 사이에 바뀐 노트는 다음 받기에 포함)
+func (s *SyncService) Snapshot(scope model.Scope) ([]SyncedNote, int64, error) {
+	cursor, err := s.Notes.Events.Repo.LatestID()
+	if err != nil {
+		return nil, 0, err
+	}
+	notes, err := s.Notes.GetAllNotes(scope)
+	if err != nil {
+		return nil, 0, err
+	}
+	clientIDs, err := s.ClientIDs.GetAll(scope)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	synced := make([]SyncedNote, len(notes))
+	for i, note := range notes {
+		synced[i] = SyncedNote{NoteID: note.ID, ClientID: clientIDs[note.ID], Note: note}
+	}
+	return synced, cursor, nil
+}
+
+// Changes 함수 정의 (cursor 이후 최대 limit개의 이벤트에서 바뀐 노트의 현재 상태, 더 남아 있으면 true, 보관 기간이 지난 커서는 ErrCursorExpired)
+func (s *SyncService) Changes(scope model.Scope, cursor int64, limit int) ([]SyncedNote, int64, bool, error) {
+	events, err := s.Notes.Events.History(scope, model.NoteEventFilter{AfterID: cursor, Limit: limit})
+	if err != nil {
+		return nil, 0, false, err
+	}
+	// 이벤트를 읽은 뒤 확인해야 그 사이 삭제된 이벤트도 알 수 있음
+	if err := s.Notes.Events.CheckCursor(cursor); err != nil {
+		return nil, 0, false, err
+	}
+	if len(events) == 0 {
+		return nil, cursor, false, nil
+	}
+	clientIDs, err := s.ClientIDs.GetAll(scope)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	// 같은 노트의 이벤트가 여러 개면 마지막 이벤트 순서로 한 번만 보냄
+	last := make(map[int]int)
+	for i, event := range events {
+		last[event.NoteID] = i
+	}
+	var synced []SyncedNote
+	for i, event := range events {
+		if last[event.NoteID] != i {
+			continue
+		}
+		note, err := s.Notes.GetNoteByID(scope, event.NoteID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, false, err
+		}
+		synced = append(synced, SyncedNote{NoteID: event.NoteID, ClientID: clientIDs[event.NoteID], Note: note})
+	}
+	return synced, events[len(events)-1].ID, len(events) == limit, nil
+}
+
+// Push 함수 정의 (한 트랜잭션에서 변경마다 세이브포인트로 적용, 실패한 변경만 취소)
+func (s *SyncService) Push(scope model.Scope, changes []SyncChange) ([]SyncResult, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	notes := s.Notes.WithTx(tx)
+	clientIDs := &repository.SyncClientIDRepository{DB: tx}
+
+	results := make([]SyncResult, len(changes))
+	for i, change := range changes {
+		if _, err := tx.Exec("SAVEPOINT sync_change"); err != nil {
+			return nil, err
+		}
+		mark := notes.Events.mark()
+
+		results[i] = s.apply(notes, clientIDs, scope, change)
+
+		if results[i].Err != nil {
+			results[i].Status = SyncStatusError
+			if _, err := tx.Exec("ROLLBACK TO sync_change"); err != nil {
+				return nil, err
+			}
+			notes.Events.discard(mark)
+		}
+		if _, err := tx.Exec("RELEASE sync_change"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	notes.Events.Flush()
+	return results, nil
+}
+
+// apply 함수 정의 (변경 하나에 충돌 처리 규칙 적용)
+func (s *SyncService) apply(notes *NoteService, clientIDs *repository.SyncClientIDRepository, scope model.Scope, change SyncChange) SyncResult {
+	result := SyncResult{ClientID: change.ClientID, NoteID: change.ID}
+
+	baseVersion := change.BaseVersion
+	if result.NoteID == 0 && change.ClientID != "" {
+		noteID, err := clientIDs.Get(scope, change.ClientID)
+		switch {
+		case err == nil:
+			result.NoteID = noteID
+			// 생성 응답을 받지 못하고 다시 보낸 변경은 생성된 버전을 기준으로 적용
+			if baseVersion == 0 {
+				baseVersion = 1
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			result.Err = err
+			return result
+		}
+	}
+
+	var current *model.Note
+	if result.NoteID != 0 {
+		note, err := notes.GetNoteByID(scope, result.NoteID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			result.Err = err
+			return result
+		}
+		current = note
+	}
+	result.Before = current
+
+	if change.Op == SyncOpDelete {
+		return s.applyDelete(notes, scope, result, current, baseVersion)
+	}
+	return s.applyUpsert(notes, clientIDs, scope, change, result, current, baseVersion)
+}
+
+// applyDelete 함수 정의
+func (s *SyncService) applyDelete(notes *NoteService, scope model.Scope, result SyncResult, current *model.Note, baseVersion int) SyncResult {
+	if current == nil {
+		result.Status = SyncStatusApplied
+		result.Resolution = SyncResolutionAlreadyDeleted
+		return result
+	}
+	if current.Version != baseVersion {
+		result.Status = SyncStatusConflict
+		result.Resolution = SyncResolutionServerWins
+		result.Note = current
+		return result
+	}
+	if result.Err = notes.DeleteNoteIfVersion(scope, current.ID, baseVersion); result.Err != nil {
+		return result
+	}
+	result.Status = SyncStatusApplied
+	result.Action = SyncOpDelete
+	return result
+}
+
+// applyUpsert 함수 정의
+func (s *SyncService) applyUpsert(notes *NoteService, clientIDs *repository.SyncClientIDRepository, scope model.Scope, change SyncChange, result SyncResult, current *model.Note, baseVersion int) SyncResult {
+	// 새 노트 또는 서버에서 삭제된 노트 (삭제된 노트는 클라이언트 내용으로 다시 만듦)
+	if current == nil {
+		note, err := notes.CreateNote(scope, change.Title, change.Content, change.Img)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if change.ClientID != "" {
+			if result.Err = clientIDs.Set(scope, change.ClientID, note.ID, time.Now()); result.Err != nil {
+				return result
+			}
+		}
+		if result.NoteID != 0 {
+			result.Resolution = SyncResolutionRecreated
+		}
+		result.NoteID = note.ID
+		result.Status = SyncStatusApplied
+		result.Action = BatchOpCreate
+		result.Note = note
+		return result
+	}
+
+	if current.Title == change.Title && current.Content == change.Content && current.Img == change.Img {
+		result.Status = SyncStatusApplied
+		result.Resolution = SyncResolutionUnchanged
+		result.Note = current
+		return result
+	}
+
+	if current.Version != baseVersion {
+		copied, err := notes.CreateNote(scope, change.Title+conflictCopySuffix, change.Content, change.Img)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Status = SyncStatusConflict
+		result.Resolution = SyncResolutionConflictCopy
+		result.Note = current
+		result.ConflictCopy = copied
+		return result
+	}
+
+	updated, err := notes.UpdateNoteIfVersion(scope, current.ID, baseVersion, change.Title, change.Content, change.Img)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Status = SyncStatusApplied
+	result.Action = BatchOpUpdate
+	result.Note = updated
+	return result
+}
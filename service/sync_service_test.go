@@ -0,0 +1,42 @@
+package service
+
+import (
+	"myapp/model"
+	"myapp/repository"
+	"testing"
+)
+
+func TestSyncClientIDsAreScopedByWorkspace(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	sync := NewSyncService(db, notes, repository.NewSyncClientIDRepository(db))
+	personal := model.Scope{UserID: 1}
+	workspace := model.Scope{UserID: 1, WorkspaceID: 7}
+	change := SyncChange{Op: SyncOpUpsert, ClientID: "local-1", Title: "t", Content: "c"}
+
+	personalResults, err := sync.Push(personal, []SyncChange{change})
+	if err != nil || personalResults[0].Err != nil {
+		t.Fatalf("personal push: %v %v", err, personalResults[0].Err)
+	}
+	workspaceResults, err := sync.Push(workspace, []SyncChange{change})
+	if err != nil || workspaceResults[0].Err != nil {
+		t.Fatalf("workspace push: %v %v", err, workspaceResults[0].Err)
+	}
+	if personalResults[0].NoteID == workspaceResults[0].NoteID {
+		t.Fatal("the same client_id in another workspace reused the personal note")
+	}
+
+	// 같은 scope에서 다시 보내면 새 노트를 만들지 않음
+	again, err := sync.Push(workspace, []SyncChange{change})
+	if err != nil || again[0].NoteID != workspaceResults[0].NoteID || again[0].Resolution != SyncResolutionUnchanged {
+		t.Fatalf("resend: %+v, %v", again[0], err)
+	}
+
+	synced, _, err := sync.Snapshot(personal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(synced) != 1 || synced[0].NoteID != personalResults[0].NoteID || synced[0].ClientID != "local-1" {
+		t.Fatalf("personal snapshot: %+v", synced)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"myapp/model"
+	"myapp/repository"
+	"myapp/utils"
+	"time"
+)
+
+// TaskService 구조체 정의
+type TaskService struct {
+	Repo    *repository.TaskRepository
+	Notes   *NoteService
+	Prompts *PromptService
+	Gemini  *GeminiService
+}
+
+// NewTaskService 함수 정의
+func NewTaskService(repo *repository.TaskRepository, notes *NoteService, prompts *PromptService, gemini *GeminiService) *TaskService {
+	return &TaskService{Repo: repo, Notes: notes, Prompts: prompts, Gemini: gemini}
+}
+
+// GetAllTasks 함수 정의 (done이 nil이면 전체)
+func (s *TaskService) GetAllTasks(scope model.Scope, done *bool) ([]*model.Task, error) {
+	return s.Repo.GetAll(scope, done)
+}
+
+// GetTasksByNoteID 함수 정의
+func (s *TaskService) GetTasksByNoteID(scope model.Scope, noteID int) ([]*model.Task, error) {
+	if _, err := s.Notes.GetNoteByID(scope, noteID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetByNoteID(noteID)
+}
+
+// GetTaskByID 함수 정의 (다른 사용자의 노트에 속한 할 일은 찾을 수 없음으로 처리)
+func (s *TaskService) GetTaskByID(scope model.Scope, id int) (*model.Task, error) {
+	task, err := s.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Notes.GetNoteByID(scope, task.NoteID); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// SetTaskDone 함수 정의 (체크박스 할 일은 노트 내용의 체크 표시를 바꿔 새 리비전으로 저장)
+func (s *TaskService) SetTaskDone(scope model.Scope, id int, done bool) (*model.Task, error) {
+	task, err := s.GetTaskByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Source == model.TaskSourceCheckbox {
+		var updated *model.Task
+		err := s.Notes.inTx(func(notes *NoteService) error {
+			note, err := notes.GetNoteByID(scope, task.NoteID)
+			if err != nil {
+				return err
+			}
+			content, err := utils.SetChecklistItemDone(note.Content, task.SpanStart, task.SpanEnd, done)
+			if err != nil {
+				// 노트 내용이 바뀌어 체크박스 위치가 맞지 않음
+				return model.Conflict(err.Error()).Wrap(err)
+			}
+			// UpdateNote에서 할 일 목록을 다시 맞추므로 여기서는 저장만
+			if _, err := notes.UpdateNoteIfVersion(scope, note.ID, note.Version, note.Title, content, note.Img); err != nil {
+				return err
+			}
+			updated, err = notes.Tasks.GetByID(id)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+
+	now := time.Now()
+	task.Done = done
+	task.UpdatedTime = &now
+	if err := s.Repo.Update(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ExtractTasksWithAI 함수 정의 (AI로 추출한 할 일로 기존 AI 할 일을 교체)
+func (s *TaskService) ExtractTasksWithAI(ctx context.Context, scope model.Scope, noteID int) ([]*model.Task, error) {
+	note, err := s.Notes.GetNoteByID(scope, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.Prompts.RenderForNote("extract_action_items", note, "", "")
+	if err != nil {
+		return nil, err
+	}
+	ctx = WithUsageInfo(ctx, UsageInfo{NoteID: noteID})
+	checklist, err := s.Gemini.GenerateText(ctx, "extract_tasks", prompt, GenerationOverrides{})
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.Repo.GetByNoteID(noteID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range existing {
+		if task.Source == model.TaskSourceAI {
+			if err := s.Repo.Delete(task.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// AI 할 일은 노트 내용에 위치가 없음
+	now := time.Now()
+	for _, item := range utils.ParseChecklist(checklist) {
+		_, err := s.Repo.Create(&model.Task{
+			NoteID:      noteID,
+			Text:        item.Text,
+			Done:        item.Done,
+			DueDate:     item.DueDate,
+			Source:      model.TaskSourceAI,
+			SpanStart:   -1,
+			SpanEnd:     -1,
+			CreatedTime: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.Repo.GetByNoteID(noteID)
+}
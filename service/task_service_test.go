@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"myapp/model"
+	"testing"
+	"time"
+)
+
+// checkboxTasks 함수 정의 (노트의 체크박스 할 일을 내용 순서대로)
+func checkboxTasks(t *testing.T, notes *NoteService, noteID int) []*model.Task {
+	t.Helper()
+	tasks, err := notes.Tasks.GetByNoteID(noteID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checkbox []*model.Task
+	for _, task := range tasks {
+		if task.Source == model.TaskSourceCheckbox {
+			checkbox = append(checkbox, task)
+		}
+	}
+	return checkbox
+}
+
+func TestNoteChangesKeepCheckboxTasksInSync(t *testing.T) {
+	notes := newTestNoteService(newTestDB(t))
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "todo", "- [ ] 우유\n- [ ] 빵 due:2024-05-01\n- [ ] 계란", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := checkboxTasks(t, notes, note.ID)
+	if len(before) != 3 || before[1].Text != "빵" || before[1].DueDate == nil {
+		t.Fatalf("tasks after create = %+v", before)
+	}
+	aiTaskID, err := notes.Tasks.Create(&model.Task{NoteID: note.ID, Text: "AI가 찾은 할 일", Source: model.TaskSourceAI, CreatedTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 순서를 바꾸고 체크하고 하나는 지우고 하나는 추가
+	if _, err := notes.UpdateNote(scope, note.ID, "todo", "머리말\n- [x] 계란\n- [ ] 우유\n- [ ] 치즈", ""); err != nil {
+		t.Fatal(err)
+	}
+	after := checkboxTasks(t, notes, note.ID)
+	if len(after) != 3 {
+		t.Fatalf("tasks after update = %+v, want 계란, 우유 and 치즈", after)
+	}
+	if after[0].ID != before[2].ID || !after[0].Done || after[0].Text != "계란" {
+		t.Fatalf("계란 = %+v, want the same task checked", after[0])
+	}
+	if after[1].ID != before[0].ID || after[1].Done {
+		t.Fatalf("우유 = %+v, want the same task still open", after[1])
+	}
+	if after[2].Text != "치즈" || after[2].ID == before[1].ID {
+		t.Fatalf("치즈 = %+v, want a new task", after[2])
+	}
+	if _, err := notes.Tasks.GetByID(before[1].ID); !errors.Is(err, model.NotFound("task not found")) {
+		t.Fatalf("removed checkbox: %v, want its task deleted", err)
+	}
+	if _, err := notes.Tasks.GetByID(aiTaskID); err != nil {
+		t.Fatalf("AI task after update: %v, want it kept", err)
+	}
+}
+
+func TestSetTaskDoneTogglesTheCheckboxInTheNote(t *testing.T) {
+	notes := newTestNoteService(newTestDB(t))
+	tasks := NewTaskService(notes.Tasks, notes, nil, nil)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "todo", "메모\n- [ ] 우유\n- [ ] 빵\n", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bread := checkboxTasks(t, notes, note.ID)[1]
+
+	if _, err := tasks.SetTaskDone(model.Scope{UserID: 2}, bread.ID, true); !errors.Is(err, model.NotFound("note not found")) {
+		t.Fatalf("toggle by another user: %v, want not found", err)
+	}
+	task, err := tasks.SetTaskDone(scope, bread.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.ID != bread.ID || !task.Done {
+		t.Fatalf("task = %+v, want the same task done", task)
+	}
+	updated, err := notes.GetNoteByID(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Content != "메모\n- [ ] 우유\n- [x] 빵\n" || updated.Version != note.Version+1 {
+		t.Fatalf("note = %q version %d, want only the 빵 checkbox checked in a new version", updated.Content, updated.Version)
+	}
+	revisions, err := notes.GetNoteRevisions(scope, note.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("revisions = %d, want the toggle saved as a new revision", len(revisions))
+	}
+
+	// 기록된 위치가 내용과 맞지 않으면 노트를 바꾸지 않음
+	bread.SpanStart, bread.SpanEnd = 0, len("메모")
+	if err := notes.Tasks.Update(bread); err != nil {
+		t.Fatal(err)
+	}
+	var conflict *model.Error
+	if _, err := tasks.SetTaskDone(scope, bread.ID, false); !errors.As(err, &conflict) || conflict.Code != model.ErrCodeConflict {
+		t.Fatalf("toggle at a stale position: %v, want a conflict", err)
+	}
+	if current, _ := notes.GetNoteByID(scope, note.ID); current.Version != updated.Version {
+		t.Fatalf("note version = %d after a rejected toggle, want %d", current.Version, updated.Version)
+	}
+}
+
+func TestSetTaskDoneUpdatesAITasksDirectly(t *testing.T) {
+	notes := newTestNoteService(newTestDB(t))
+	tasks := NewTaskService(notes.Tasks, notes, nil, nil)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "meeting", "회의록", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := notes.Tasks.Create(&model.Task{NoteID: note.ID, Text: "자료 보내기", Source: model.TaskSourceAI, CreatedTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := tasks.SetTaskDone(scope, id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !task.Done || task.UpdatedTime == nil {
+		t.Fatalf("task = %+v, want done", task)
+	}
+	if current, _ := notes.GetNoteByID(scope, note.ID); current.Version != note.Version || current.Content != "회의록" {
+		t.Fatalf("note changed to %q version %d by an AI task toggle", current.Content, current.Version)
+	}
+}
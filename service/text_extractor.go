@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// TextExtractor 인터페이스 정의 (이미지에서 텍스트 추출)
+type TextExtractor interface {
+	ExtractText(ctx context.Context, imgFormat string, data []byte) (string, error)
+}
+
+// GeminiTextExtractor 구조체 정의 (멀티모달 모델로 OCR 수행)
+type GeminiTextExtractor struct {
+	Gemini *GeminiService
+}
+
+// NewGeminiTextExtractor 함수 정의
+func NewGeminiTextExtractor(gemini *GeminiService) *GeminiTextExtractor {
+	return &GeminiTextExtractor{Gemini: gemini}
+}
+
+// ExtractText 함수 정의
+func (e *GeminiTextExtractor) ExtractText(ctx context.Context, imgFormat string, data []byte) (string, error) {
+	prompt := []genai.Part{
+		genai.ImageData(imgFormat, data),
+		genai.Text("Extract all readable text from this image. Return only the text, preserving line breaks. If there is no text, return an empty response."),
+	}
+
+	settings, err := e.Gemini.Catalog.VisionSettings()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := e.Gemini.generate(ctx, "extract_text", settings, prompt...)
+	if err != nil {
+		return "", err
+	}
+	return responseText(resp), nil
+}
+
+// responseText 함수 정의 (첫 번째 후보의 텍스트 파트만 이어 붙임)
+func responseText(resp *genai.GenerateContentResponse) string {
+	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if text, ok := part.(genai.Text); ok {
+			sb.WriteString(string(text))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"myapp/model"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 언어 코드 형식 (ko, en, zh-TW 등)
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+
+// 프롬프트에 넣을 언어 이름
+var languageNames = map[string]string{
+	"ko": "Korean",
+	"en": "English",
+	"ja": "Japanese",
+	"zh": "Chinese",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+}
+
+// NormalizeLanguage 함수 정의 (소문자 언어 코드로 정리하고 형식 확인)
+func NormalizeLanguage(language string) (string, error) {
+	language = strings.TrimSpace(language)
+	if parts := strings.SplitN(language, "-", 2); len(parts) == 2 {
+		language = strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+	} else {
+		language = strings.ToLower(language)
+	}
+	if !languageCodePattern.MatchString(language) {
+		return "", model.Validation(fmt.Sprintf("invalid language code %q", language))
+	}
+	return language, nil
+}
+
+func languageName(code string) string {
+	base := strings.SplitN(code, "-", 2)[0]
+	if name, ok := languageNames[base]; ok {
+		return fmt.Sprintf("%s (%s)", name, code)
+	}
+	return code
+}
+
+// TranslationService 구조체 정의
+type TranslationService struct {
+	Notes   *NoteService
+	Prompts *PromptService
+	Gemini  *GeminiService
+}
+
+// NewTranslationService 함수 정의
+func NewTranslationService(notes *NoteService, prompts *PromptService, gemini *GeminiService) *TranslationService {
+	return &TranslationService{Notes: notes, Prompts: prompts, Gemini: gemini}
+}
+
+// TranslateNote 함수 정의 (번역 후 현재 리비전 기준 번역본으로 저장)
+func (s *TranslationService) TranslateNote(ctx context.Context, scope model.Scope, noteID int, language string) (*model.NoteTranslation, error) {
+	language, err := NormalizeLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.Notes.GetNoteByID(scope, noteID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.Notes.Revisions.LatestRevision(noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.Prompts.RenderForNote("translate_variant", note, "", languageName(language))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Title   string `json:"title"`
+		Content string `json:"content"`
+	}
+	ctx = WithUsageInfo(ctx, UsageInfo{NoteID: noteID})
+	if err := s.Gemini.GenerateJSON(ctx, "translate", prompt, GenerationOverrides{}, &result); err != nil {
+		return nil, err
+	}
+
+	translation := &model.NoteTranslation{
+		NoteID:         noteID,
+		Language:       language,
+		Title:          result.Title,
+		Content:        result.Content,
+		SourceRevision: revision,
+		CreatedTime:    time.Now(),
+	}
+	if err := s.Notes.Translations.Upsert(translation); err != nil {
+		return nil, err
+	}
+	return s.Notes.Translations.GetByNoteAndLanguage(noteID, language)
+}
+
+// GetTranslation 함수 정의
+func (s *TranslationService) GetTranslation(noteID int, language string) (*model.NoteTranslation, error) {
+	language, err := NormalizeLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	return s.Notes.Translations.GetByNoteAndLanguage(noteID, language)
+}
+
+// GetTranslations 함수 정의
+func (s *TranslationService) GetTranslations(scope model.Scope, noteID int) ([]*model.NoteTranslation, error) {
+	if _, err := s.Notes.GetNoteByID(scope, noteID); err != nil {
+		return nil, err
+	}
+	return s.Notes.Translations.GetByNoteID(noteID)
+}
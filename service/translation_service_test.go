@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"myapp/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+)
+
+// newTestGemini 함수 정의 (reply가 프롬프트마다 돌려줄 텍스트를 정하는 가짜 Gemini API)
+func newTestGemini(t *testing.T, reply func(prompt string) string) *GeminiService {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Contents []struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Contents) == 0 || len(req.Contents[0].Parts) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"candidates": []interface{}{map[string]interface{}{
+				"content":      map[string]interface{}{"role": "model", "parts": []interface{}{map[string]string{"text": reply(req.Contents[0].Parts[0].Text)}}},
+				"finishReason": "STOP",
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := genai.NewClient(context.Background(), option.WithAPIKey("test"), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	catalog, err := NewModelCatalog(ModelCatalogOptions{Models: []string{"gemini-1.5-flash"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &GeminiService{Client: client, Catalog: catalog}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	for input, want := range map[string]string{"KO": "ko", " en ": "en", "zh-tw": "zh-TW", "pt-br": "pt-BR"} {
+		if got, err := NormalizeLanguage(input); err != nil || got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "korean", "k", "en_US", "en-", "../ko"} {
+		if _, err := NormalizeLanguage(input); err == nil {
+			t.Errorf("NormalizeLanguage(%q) accepted", input)
+		}
+	}
+}
+
+func TestTranslationIsStoredAndMarkedStale(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	var prompts []string
+	gemini := newTestGemini(t, func(prompt string) string {
+		prompts = append(prompts, prompt)
+		return `{"title": "Groceries", "content": "milk and bread"}`
+	})
+	translations := NewTranslationService(notes, newTestPromptService(t), gemini)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "장보기", "우유와 빵", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := translations.TranslateNote(context.Background(), model.Scope{UserID: 2}, note.ID, "en"); !errors.Is(err, model.NotFound("note not found")) {
+		t.Fatalf("translate another user's note: %v, want not found", err)
+	}
+	if len(prompts) != 0 {
+		t.Fatal("AI was called for a note the user cannot see")
+	}
+
+	translation, err := translations.TranslateNote(context.Background(), scope, note.ID, "EN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translation.Language != "en" || translation.Title != "Groceries" || translation.Content != "milk and bread" || translation.SourceRevision != 1 || translation.Stale {
+		t.Fatalf("translation = %+v, want a fresh English variant of revision 1", translation)
+	}
+	if len(prompts) != 1 || !strings.Contains(prompts[0], "English (en)") || !strings.Contains(prompts[0], "우유와 빵") {
+		t.Fatalf("prompts = %q, want the note and the target language", prompts)
+	}
+
+	// 원본이 바뀌면 기존 번역본은 stale
+	if _, err := notes.UpdateNote(scope, note.ID, "장보기", "우유, 빵, 계란", ""); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := translations.GetTranslation(note.ID, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale.Stale || stale.SourceRevision != 1 {
+		t.Fatalf("translation after an edit = %+v, want stale on revision 1", stale)
+	}
+
+	// 다시 번역하면 같은 번역본을 최신 리비전으로 갱신
+	refreshed, err := translations.TranslateNote(context.Background(), scope, note.ID, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.ID != translation.ID || refreshed.Stale || refreshed.SourceRevision != 2 {
+		t.Fatalf("retranslation = %+v, want the same variant refreshed to revision 2", refreshed)
+	}
+	all, err := translations.GetTranslations(scope, note.ID)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("translations = %v, %v; want one variant", all, err)
+	}
+	if _, err := translations.GetTranslations(model.Scope{UserID: 2}, note.ID); !errors.Is(err, model.NotFound("note not found")) {
+		t.Fatalf("list by another user: %v, want not found", err)
+	}
+	if _, err := translations.GetTranslation(note.ID, "ja"); !errors.Is(err, model.NotFound("translation not found")) {
+		t.Fatalf("missing language: %v, want not found", err)
+	}
+
+	if err := notes.DeleteNote(scope, note.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := translations.GetTranslation(note.ID, "en"); !errors.Is(err, model.NotFound("translation not found")) {
+		t.Fatalf("translation of a deleted note: %v, want not found", err)
+	}
+}
+
+func TestTranslationRejectsInvalidAIResponse(t *testing.T) {
+	db := newTestDB(t)
+	notes := newTestNoteService(db)
+	gemini := newTestGemini(t, func(string) string { return "Here is your translation: Groceries" })
+	translations := NewTranslationService(notes, newTestPromptService(t), gemini)
+	scope := model.Scope{UserID: 1}
+
+	note, err := notes.CreateNote(scope, "장보기", "우유", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var aiErr *AIError
+	if _, err := translations.TranslateNote(context.Background(), scope, note.ID, "en"); !errors.As(err, &aiErr) || !errors.Is(aiErr.Kind, ErrUpstreamFailed) {
+		t.Fatalf("non-JSON response: %v, want ErrUpstreamFailed", err)
+	}
+	if all, _ := translations.GetTranslations(scope, note.ID); len(all) != 0 {
+		t.Fatalf("stored %d translations from an invalid response", len(all))
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log"
+	"myapp/model"
+	"myapp/repository"
+	"sort"
+	"time"
+)
+
+// ErrQuotaExceeded 일일 토큰 한도를 넘었을 때 반환
+var ErrQuotaExceeded = model.NewError(model.ErrCodeRateLimited, "daily AI token quota exceeded")
+
+type usageInfoKey struct{}
+
+// UsageInfo 구조체 정의 (사용량 기록에 남길 호출 주체 정보)
+type UsageInfo struct {
+	UserID      int
+	WorkspaceID int
+	NoteID      int
+}
+
+// WithUsageInfo 함수 정의 (컨텍스트에 호출 주체 정보 저장, 비어 있는 필드는 기존 값 유지)
+func WithUsageInfo(ctx context.Context, info UsageInfo) context.Context {
+	prev := usageInfoFrom(ctx)
+	if info.UserID == 0 {
+		info.UserID = prev.UserID
+	}
+	if info.WorkspaceID == 0 {
+		info.WorkspaceID = prev.WorkspaceID
+	}
+	if info.NoteID == 0 {
+		info.NoteID = prev.NoteID
+	}
+	return context.WithValue(ctx, usageInfoKey{}, info)
+}
+
+func usageInfoFrom(ctx context.Context) UsageInfo {
+	info, _ := ctx.Value(usageInfoKey{}).(UsageInfo)
+	return info
+}
+
+// UsageService 구조체 정의 (AI 토큰 사용량 기록 및 한도 적용)
+type UsageService struct {
+	Repo            *repository.AIUsageRepository
+	DailyTokenQuota int
+	Pricing         map[string]model.ModelPrice
+}
+
+// NewUsageService 함수 정의 (dailyTokenQuota가 0이면 무제한, pricing은 비용 추정에 쓰는 모델별 가격)
+func NewUsageService(repo *repository.AIUsageRepository, dailyTokenQuota int, pricing map[string]model.ModelPrice) *UsageService {
+	return &UsageService{Repo: repo, DailyTokenQuota: dailyTokenQuota, Pricing: pricing}
+}
+
+// startOfDay 함수 정의 (UTC 기준 오늘 0시)
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CheckQuota 함수 정의 (사용자별 일일 한도, 사용자 정보가 없으면 전체 합계로 판단)
+func (s *UsageService) CheckQuota(ctx context.Context) error {
+	if s == nil || s.DailyTokenQuota <= 0 {
+		return nil
+	}
+	used, err := s.Repo.SumTokensSince(usageInfoFrom(ctx).UserID, startOfDay(time.Now()))
+	if err != nil {
+		return err
+	}
+	if used >= s.DailyTokenQuota {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Record 함수 정의 (기록 실패는 호출 결과에 영향을 주지 않음)
+func (s *UsageService) Record(ctx context.Context, usage *model.AIUsage) {
+	if s == nil {
+		return
+	}
+	info := usageInfoFrom(ctx)
+	usage.UserID = info.UserID
+	usage.WorkspaceID = info.WorkspaceID
+	if info.NoteID != 0 {
+		noteID := info.NoteID
+		usage.NoteID = &noteID
+	}
+	usage.CreatedTime = time.Now().UTC()
+	if _, err := s.Repo.Create(usage); err != nil {
+		log.Printf("could not record AI usage: %v", err)
+	}
+}
+
+// Summarize 함수 정의 (모델별 집계에 가격을 적용한 뒤 key별로 합침, 가격이 없는 모델 목록도 반환, scope가 nil이면 전체)
+func (s *UsageService) Summarize(scope *model.Scope, groupBy string, from, to time.Time) ([]*model.AIUsageSummary, []string, error) {
+	rows, err := s.Repo.Summarize(scope, groupBy, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summaries := []*model.AIUsageSummary{}
+	byKey := make(map[string]*model.AIUsageSummary)
+	unpriced := make(map[string]bool)
+	latencySum := make(map[string]int64)
+	for _, row := range rows {
+		cost := 0.0
+		if price, ok := s.Pricing[row.Model]; ok {
+			cost = price.Cost(row.PromptTokens, row.CandidateTokens)
+		} else {
+			unpriced[row.Model] = true
+		}
+
+		summary, ok := byKey[row.Key]
+		if !ok {
+			summary = &model.AIUsageSummary{Key: row.Key}
+			byKey[row.Key] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Calls += row.Calls
+		summary.PromptTokens += row.PromptTokens
+		summary.CandidateTokens += row.CandidateTokens
+		summary.TotalTokens += row.TotalTokens
+		summary.EstimatedCostUSD += cost
+		latencySum[row.Key] += row.AvgLatencyMs * int64(row.Calls)
+	}
+	for _, summary := range summaries {
+		if summary.Calls > 0 {
+			summary.AvgLatencyMs = latencySum[summary.Key] / int64(summary.Calls)
+		}
+	}
+
+	models := make([]string, 0, len(unpriced))
+	for m := range unpriced {
+		models = append(models, m)
+	}
+	sort.Strings(models)
+	return summaries, models, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"math"
+	"myapp/model"
+	"myapp/repository"
+	"testing"
+	"time"
+)
+
+func TestUsageSummaryIsScopedAndPriced(t *testing.T) {
+	db := newTestDB(t)
+	usage := NewUsageService(repository.NewAIUsageRepository(db), 0, map[string]model.ModelPrice{
+		"flash": {InputPerMillion: 1, OutputPerMillion: 2},
+	})
+
+	record := func(userID int, workspaceID int, modelName string, prompt, candidate int) {
+		ctx := WithUsageInfo(context.Background(), UsageInfo{UserID: userID, WorkspaceID: workspaceID})
+		usage.Record(ctx, &model.AIUsage{Operation: "analyze", Model: modelName, PromptTokens: prompt, CandidateTokens: candidate,
+			TotalTokens: prompt + candidate, Success: true})
+	}
+	record(1, 0, "flash", 1_000_000, 500_000)
+	record(1, 0, "pro", 10, 10)
+	record(2, 0, "flash", 100, 100)
+	record(2, 7, "flash", 300, 0)
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+
+	summaries, unpriced, err := usage.Summarize(&model.Scope{UserID: 1}, "user", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].Key != "1" || summaries[0].Calls != 2 {
+		t.Fatalf("personal summaries = %+v, want only user 1 with 2 calls", summaries)
+	}
+	if math.Abs(summaries[0].EstimatedCostUSD-2) > 1e-9 {
+		t.Fatalf("cost = %v, want 2", summaries[0].EstimatedCostUSD)
+	}
+	if len(unpriced) != 1 || unpriced[0] != "pro" {
+		t.Fatalf("unpriced = %v, want [pro]", unpriced)
+	}
+
+	summaries, _, err = usage.Summarize(&model.Scope{UserID: 1, WorkspaceID: 7}, "user", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].Key != "2" || summaries[0].TotalTokens != 300 {
+		t.Fatalf("workspace summaries = %+v, want only the workspace call", summaries)
+	}
+
+	summaries, _, err = usage.Summarize(nil, "user", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("global summaries = %+v, want 2 users", summaries)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errWebhookAddressBlocked 웹훅 대상이 내부 주소일 때 (연결 시점에 확인, 재시도해도 결과가 같으므로 영구 실패)
+var errWebhookAddressBlocked = errors.New("webhook target resolves to a loopback, private, link-local or metadata address")
+
+// blockedWebhookNetworks net.IP의 분류 함수로 잡히지 않는 내부용 주소 범위
+var blockedWebhookNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // 현재 네트워크
+	"100.64.0.0/10", // 통신사 NAT (일부 클라우드의 메타데이터 주소 포함)
+	"192.0.0.0/24",  // IETF 프로토콜 할당
+	"198.18.0.0/15", // 벤치마크
+	"240.0.0.0/4",   // 예약
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
+
+// isBlockedWebhookIP 함수 정의 (루프백, 사설, 링크 로컬(169.254.169.254 메타데이터 포함), 멀티캐스트, 미지정 주소)
+func isBlockedWebhookIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range blockedWebhookNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookGuard 구조체 정의 (웹훅이 서버 내부망으로 요청을 보내지 않도록 대상 주소 확인, AllowedHosts는 로컬 수신기처럼 예외로 허용할 호스트)
+//
+// 등록할 때 호스트를 조회해 확인하고, DNS가 나중에 바뀌어도 막히도록 연결할 때 실제 주소를 다시 확인함.
+type WebhookGuard struct {
+	AllowedHosts map[string]bool
+	Resolver     *net.Resolver
+}
+
+// NewWebhookGuard 함수 정의 (allowedHosts는 호스트 이름 또는 IP)
+func NewWebhookGuard(allowedHosts []string) *WebhookGuard {
+	allowed := make(map[string]bool)
+	for _, host := range allowedHosts {
+		allowed[normalizeHost(host)] = true
+	}
+	return &WebhookGuard{AllowedHosts: allowed, Resolver: net.DefaultResolver}
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Trim(host, "[]")), ".")
+}
+
+// CheckURL 함수 정의 (http, https URL이고 호스트의 모든 주소가 외부 주소인지 확인)
+func (g *WebhookGuard) CheckURL(ctx context.Context, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", model.Validation("url must be an http(s) URL")
+	}
+	if u.User != nil {
+		return "", model.Validation("url must not contain credentials")
+	}
+	host := normalizeHost(u.Hostname())
+	if g.AllowedHosts[host] {
+		return raw, nil
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := g.Resolver.LookupIPAddr(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return "", model.Validation(fmt.Sprintf("url host %q could not be resolved", host))
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if isBlockedWebhookIP(ip) {
+			return "", model.Validation("url must not point to a loopback, private, link-local or metadata address")
+		}
+	}
+	return raw, nil
+}
+
+// Client 함수 정의 (연결할 때 주소를 다시 확인하고, 프록시와 리다이렉트를 따르지 않는 전송용 클라이언트)
+func (g *WebhookGuard) Client(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         g.dialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        20,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		// 리다이렉트로 내부 주소에 접근하지 못하도록 3xx 응답을 그대로 결과로 기록 (2xx가 아니므로 실패)
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// dialContext 함수 정의 (허용한 호스트가 아니면 조회된 주소로 연결하기 직전에 확인)
+func (g *WebhookGuard) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if !g.AllowedHosts[normalizeHost(host)] {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			ipHost, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(ipHost); ip == nil || isBlockedWebhookIP(ip) {
+				return errWebhookAddressBlocked
+			}
+			return nil
+		}
+	}
+	return dialer.DialContext(ctx, network, addr)
+}
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"myapp/model"
+	"myapp/repository"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 웹훅 요청 헤더 (서명은 "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)))
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookSecretPrefix 서버가 만든 비밀 키 접두사
+const WebhookSecretPrefix = "whsec_"
+
+// 직접 정한 비밀 키 최소 길이, 전송 기록에 남기는 응답 본문 최대 길이, 한 번에 전달할 이벤트 수
+const (
+	minWebhookSecretLength = 16
+	maxWebhookResponseBody = 1024
+	webhookDispatchBatch   = 100
+)
+
+// ErrWebhookDisabled 비활성 웹훅으로 전송하려 할 때 반환
+var ErrWebhookDisabled = model.Conflict("webhook is disabled")
+
+// ErrWebhookCreatorRemoved 워크스페이스 웹훅을 만든 사용자가 멤버에서 빠졌거나 admin보다 낮은 역할이 되었을 때 반환
+var ErrWebhookCreatorRemoved = model.Forbidden("the webhook creator is no longer an admin of the workspace; recreate the webhook")
+
+// WebhookPayload 구조체 정의 (전송 본문, note는 전송 기록을 만들 때의 노트 상태이고 삭제 이벤트와 ping에는 없음)
+type WebhookPayload struct {
+	ID          int64            `json:"id"`
+	Type        string           `json:"type"`
+	WebhookID   int              `json:"webhook_id"`
+	CreatedTime time.Time        `json:"created_time"`
+	Event       *model.NoteEvent `json:"event,omitempty"`
+	Note        *model.Note      `json:"note,omitempty"`
+}
+
+// WebhookService 구조체 정의 (노트 이벤트를 웹훅으로 전송, 재시도와 백오프는 작업 큐가 담당)
+type WebhookService struct {
+	Repo         *repository.WebhookRepository
+	Events       *EventBus
+	Notes        *NoteService
+	Jobs         *JobService
+	Members      *repository.WorkspaceRepository
+	Guard        *WebhookGuard
+	Client       *http.Client
+	PollInterval time.Duration
+
+	cursor int64
+}
+
+// NewWebhookService 함수 정의 (timeout은 전송 한 번의 제한 시간, allowedHosts는 내부 주소라도 보낼 수 있는 호스트)
+func NewWebhookService(repo *repository.WebhookRepository, events *EventBus, notes *NoteService, jobs *JobService, members *repository.WorkspaceRepository, timeout time.Duration, allowedHosts []string) *WebhookService {
+	guard := NewWebhookGuard(allowedHosts)
+	return &WebhookService{
+		Repo:         repo,
+		Events:       events,
+		Notes:        notes,
+		Jobs:         jobs,
+		Members:      members,
+		Guard:        guard,
+		Client:       guard.Client(timeout),
+		PollInterval: 5 * time.Second,
+	}
+}
+
+// SignWebhook 함수 정의 (수신 측은 같은 방법으로 계산해 X-Webhook-Signature와 비교)
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeEventTypes 함수 정의 (비어 있으면 모든 이벤트, 알 수 없는 종류는 오류, 중복 제거)
+func normalizeEventTypes(types []string) ([]string, error) {
+	if len(types) == 0 {
+		return append([]string(nil), model.NoteEventTypes...), nil
+	}
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		known := false
+		for _, k := range model.NoteEventTypes {
+			known = known || k == t
+		}
+		if !known {
+			return nil, model.Validation(fmt.Sprintf("unknown event type %q (must be one of %s)", t, strings.Join(model.NoteEventTypes, ", ")))
+		}
+		if !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized, nil
+}
+
+// CreateWebhook 함수 정의 (secret이 비어 있으면 만들어서 반환, 비밀 키는 이때만 반환)
+func (s *WebhookService) CreateWebhook(ctx context.Context, scope model.Scope, rawURL, secret string, eventTypes []string, active bool) (*model.Webhook, string, error) {
+	target, err := s.Guard.CheckURL(ctx, rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	eventTypes, err = normalizeEventTypes(eventTypes)
+	if err != nil {
+		return nil, "", err
+	}
+	if secret == "" {
+		random, err := randomToken()
+		if err != nil {
+			return nil, "", err
+		}
+		secret = WebhookSecretPrefix + random
+	} else if len(secret) < minWebhookSecretLength {
+		return nil, "", model.Validation(fmt.Sprintf("secret must be at least %d characters", minWebhookSecretLength))
+	}
+
+	webhook := &model.Webhook{
+		UserID:      scope.UserID,
+		WorkspaceID: scope.WorkspaceID,
+		URL:         target,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Active:      active,
+		CreatedTime: time.Now(),
+	}
+	id, err := s.Repo.Create(webhook)
+	if err != nil {
+		return nil, "", err
+	}
+	webhook.ID = id
+	return webhook, secret, nil
+}
+
+// checkCreator 함수 정의 (워크스페이스 웹훅은 만든 사용자가 지금도 admin 이상일 때만 전송, 아니면 ErrWebhookCreatorRemoved)
+func (s *WebhookService) checkCreator(webhook *model.Webhook) error {
+	if webhook.WorkspaceID == 0 {
+		return nil
+	}
+	role, err := s.Members.GetMemberRole(webhook.WorkspaceID, webhook.UserID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !model.WorkspaceRoleAtLeast(role, model.WorkspaceRoleAdmin)) {
+		return ErrWebhookCreatorRemoved
+	}
+	return err
+}
+
+// GetWebhooks 함수 정의
+func (s *WebhookService) GetWebhooks(scope model.Scope) ([]*model.Webhook, error) {
+	return s.Repo.GetAll(scope)
+}
+
+// GetWebhook 함수 정의
+func (s *WebhookService) GetWebhook(scope model.Scope, id int) (*model.Webhook, error) {
+	return s.Repo.GetByID(scope, id)
+}
+
+// UpdateWebhook 함수 정의 (secret이 비어 있거나 active가 nil이면 기존 값 유지)
+func (s *WebhookService) UpdateWebhook(ctx context.Context, scope model.Scope, id int, rawURL, secret string, eventTypes []string, active *bool) (*model.Webhook, error) {
+	webhook, err := s.Repo.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.URL, err = s.Guard.CheckURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+	if webhook.EventTypes, err = normalizeEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+	if secret != "" {
+		if len(secret) < minWebhookSecretLength {
+			return nil, model.Validation(fmt.Sprintf("secret must be at least %d characters", minWebhookSecretLength))
+		}
+		webhook.Secret = secret
+	}
+	if active != nil {
+		webhook.Active = *active
+	}
+	now := time.Now()
+	webhook.UpdatedTime = &now
+	if err := s.Repo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook 함수 정의 (대기 중인 전송 작업은 웹훅을 찾지 못해 재시도 없이 끝남)
+func (s *WebhookService) DeleteWebhook(scope model.Scope, id int) error {
+	if _, err := s.Repo.GetByID(scope, id); err != nil {
+		return err
+	}
+	return s.Repo.Delete(id)
+}
+
+// GetDeliveries 함수 정의 (최근 전송 기록부터)
+func (s *WebhookService) GetDeliveries(scope model.Scope, webhookID, limit int) ([]*model.WebhookDelivery, error) {
+	if _, err := s.Repo.GetByID(scope, webhookID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetDeliveries(webhookID, limit)
+}
+
+// TestWebhook 함수 정의 (ping을 바로 한 번 보내고 결과를 전송 기록으로 반환, 재시도 없음)
+func (s *WebhookService) TestWebhook(ctx context.Context, scope model.Scope, id int) (*model.WebhookDelivery, error) {
+	webhook, err := s.Repo.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCreator(webhook); err != nil {
+		return nil, err
+	}
+	delivery, err := s.createDelivery(webhook, WebhookPayload{Type: model.WebhookEventPing}, 0)
+	if err != nil {
+		return nil, err
+	}
+	sendErr := s.attempt(ctx, webhook, delivery, 1)
+	delivery.Status = model.WebhookDeliverySucceeded
+	if sendErr != nil {
+		delivery.Status = model.WebhookDeliveryFailed
+	}
+	if err := s.Repo.RecordAttempt(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ReplayDelivery 함수 정의 (같은 본문으로 새 전송 기록을 만들어 다시 전송, 서명 시각은 새로 계산)
+func (s *WebhookService) ReplayDelivery(scope model.Scope, webhookID, deliveryID int) (*model.WebhookDelivery, *model.Job, error) {
+	webhook, err := s.Repo.GetByID(scope, webhookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !webhook.Active {
+		return nil, nil, ErrWebhookDisabled
+	}
+	if err := s.checkCreator(webhook); err != nil {
+		return nil, nil, err
+	}
+	original, err := s.Repo.GetDelivery(deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if original.WebhookID != webhook.ID {
+		return nil, nil, model.NotFound("delivery not found").Wrap(sql.ErrNoRows)
+	}
+
+	delivery := &model.WebhookDelivery{
+		WebhookID:   webhook.ID,
+		EventID:     original.EventID,
+		EventType:   original.EventType,
+		Payload:     original.Payload,
+		Status:      model.WebhookDeliveryPending,
+		ReplayOf:    original.ID,
+		CreatedTime: time.Now(),
+	}
+	job, err := s.enqueue(webhook, delivery)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, job, nil
+}
+
+// createDelivery 함수 정의 (본문을 만들어 대기 상태의 전송 기록 저장)
+func (s *WebhookService) createDelivery(webhook *model.Webhook, payload WebhookPayload, eventID int64) (*model.WebhookDelivery, error) {
+	payload.ID = eventID
+	payload.WebhookID = webhook.ID
+	if payload.CreatedTime.IsZero() {
+		payload.CreatedTime = time.Now().UTC()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &model.WebhookDelivery{
+		WebhookID:   webhook.ID,
+		EventID:     eventID,
+		EventType:   payload.Type,
+		Payload:     string(body),
+		Status:      model.WebhookDeliveryPending,
+		CreatedTime: time.Now(),
+	}
+	id, err := s.Repo.CreateDelivery(delivery)
+	if err != nil {
+		return nil, err
+	}
+	delivery.ID = id
+	return delivery, nil
+}
+
+// enqueue 함수 정의 (전송 기록이 없으면 저장하고 전송 작업 등록)
+func (s *WebhookService) enqueue(webhook *model.Webhook, delivery *model.WebhookDelivery) (*model.Job, error) {
+	if delivery.ID == 0 {
+		id, err := s.Repo.CreateDelivery(delivery)
+		if err != nil {
+			return nil, err
+		}
+		delivery.ID = id
+	}
+	job, err := s.Jobs.Enqueue(webhook.Scope(), JobTypeWebhookDelivery, WebhookJobPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		return nil, err
+	}
+	delivery.JobID = job.ID
+	return job, s.Repo.SetDeliveryJob(delivery.ID, job.ID)
+}
+
+// attempt 함수 정의 (서명한 요청을 한 번 보내고 결과를 delivery에 기록, 2xx가 아니면 오류)
+func (s *WebhookService) attempt(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery, attempts int) error {
+	now := time.Now()
+	delivery.Attempts = attempts
+	delivery.UpdatedTime = &now
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+	delivery.LastError = ""
+
+	err := s.send(ctx, webhook, delivery)
+	if err != nil {
+		delivery.LastError = err.Error()
+	}
+	return err
+}
+
+// send 함수 정의
+func (s *WebhookService) send(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "myapp-webhooks/1.0")
+	req.Header.Set(WebhookHeaderEvent, delivery.EventType)
+	req.Header.Set(WebhookHeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, SignWebhook(webhook.Secret, timestamp, body))
+
+	resp, err := s.Client.Do(req)
+	if errors.Is(err, errWebhookAddressBlocked) {
+		return Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = string(respBody)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Deliver 함수 정의 (전송 작업 한 번 실행, 마지막 시도까지 실패하면 failed로 기록)
+func (s *WebhookService) Deliver(ctx context.Context, job *model.Job, deliveryID int) (string, error) {
+	delivery, err := s.Repo.GetDelivery(deliveryID)
+	if err != nil {
+		return "", permanentIfNotFound(err)
+	}
+	webhook, err := s.Repo.GetForDelivery(delivery.WebhookID)
+	if err != nil {
+		return "", permanentIfNotFound(err)
+	}
+
+	// 등록 후 비활성화되었거나 만든 사용자의 권한이 없어진 웹훅은 보내지 않음
+	sendErr := s.checkCreator(webhook)
+	if sendErr == nil && !webhook.Active {
+		sendErr = ErrWebhookDisabled
+	}
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+		sendErr = Permanent(sendErr)
+	} else {
+		sendErr = s.attempt(ctx, webhook, delivery, job.Attempts)
+	}
+
+	var perm *permanentError
+	switch {
+	case sendErr == nil:
+		delivery.Status = model.WebhookDeliverySucceeded
+	case errors.As(sendErr, &perm) || job.Attempts >= job.MaxAttempts:
+		delivery.Status = model.WebhookDeliveryFailed
+	default:
+		delivery.Status = model.WebhookDeliveryPending
+	}
+	if err := s.Repo.RecordAttempt(delivery); err != nil {
+		log.Printf("webhooks: could not record delivery %d: %v", delivery.ID, err)
+	}
+	if sendErr != nil {
+		return "", sendErr
+	}
+	return fmt.Sprintf("delivered with status %d", delivery.ResponseStatus), nil
+}
+
+// Start 함수 정의 (저장된 노트 이벤트를 따라가며 구독 중인 웹훅마다 전송 작업 등록, 처음 시작하면 이후 이벤트부터 전달)
+func (s *WebhookService) Start(ctx context.Context) error {
+	cursor, err := s.Repo.GetCursor()
+	if errors.Is(err, sql.ErrNoRows) {
+		if cursor, err = s.Events.Repo.LatestID(); err == nil {
+			err = s.Repo.SetCursor(cursor, time.Now())
+		}
+	}
+	if err != nil {
+		return err
+	}
+	s.cursor = cursor
+
+	notify := s.Events.Notify()
+	go func() {
+		for {
+			if err := s.dispatch(); err != nil {
+				log.Printf("webhooks: dispatch failed: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			case <-time.After(s.PollInterval):
+			}
+		}
+	}()
+	return nil
+}
+
+// dispatch 함수 정의 (마지막으로 전달한 이벤트 이후의 이벤트를 모두 전달, 이벤트마다 위치 저장)
+func (s *WebhookService) dispatch() error {
+	for {
+		events, err := s.Events.Repo.GetAllAfter(s.cursor, webhookDispatchBatch)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		webhooks, err := s.dispatchTargets()
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			for _, webhook := range webhooks {
+				if !webhook.Subscribes(event) {
+					continue
+				}
+				if err := s.dispatchEvent(webhook, event); err != nil {
+					return err
+				}
+			}
+			if err := s.Repo.SetCursor(event.ID, time.Now()); err != nil {
+				return err
+			}
+			s.cursor = event.ID
+		}
+	}
+}
+
+// dispatchTargets 함수 정의 (활성 웹훅 중 만든 사용자가 아직 권한을 가진 웹훅)
+func (s *WebhookService) dispatchTargets() ([]*model.Webhook, error) {
+	webhooks, err := s.Repo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	targets := webhooks[:0]
+	for _, webhook := range webhooks {
+		err := s.checkCreator(webhook)
+		if errors.Is(err, ErrWebhookCreatorRemoved) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, webhook)
+	}
+	return targets, nil
+}
+
+// dispatchEvent 함수 정의 (삭제되지 않은 노트는 현재 내용을 본문에 포함)
+func (s *WebhookService) dispatchEvent(webhook *model.Webhook, event *model.NoteEvent) error {
+	payload := WebhookPayload{Type: event.Type, CreatedTime: event.CreatedTime, Event: event}
+	if event.Type != model.NoteEventDeleted {
+		// 웹훅 범위에서 조회하므로 범위 밖으로 옮겨진 노트(note.moved)는 내용 없이 이벤트만 보냄
+		note, err := s.Notes.GetNoteByID(webhook.Scope(), event.NoteID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		payload.Note = note
+	}
+
+	delivery, err := s.createDelivery(webhook, payload, event.ID)
+	if err != nil {
+		return err
+	}
+	_, err = s.enqueue(webhook, delivery)
+	return err
+}
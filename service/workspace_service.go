@@ -0,0 +1,267 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"strings"
+	"time"
+)
+
+// 워크스페이스 오류
+var (
+	ErrNotWorkspaceMember   = model.Forbidden("not a member of this workspace")
+	ErrWorkspaceForbidden   = model.Forbidden("insufficient workspace role")
+	ErrLastWorkspaceOwner   = model.Conflict("a workspace must keep at least one owner")
+	ErrInvitationInvalid    = model.NewError(model.ErrCodeGone, "invitation is invalid, expired or already used")
+	ErrInvitationWrongEmail = model.Forbidden("invitation was sent to a different email address")
+)
+
+// InvitationPrefix 초대 토큰 접두사
+const InvitationPrefix = "inv_"
+
+// InvitationTTL 초대 유효 기간
+const InvitationTTL = 7 * 24 * time.Hour
+
+// WorkspaceService 구조체 정의 (워크스페이스, 멤버, 초대 관리)
+type WorkspaceService struct {
+	Repo  *repository.WorkspaceRepository
+	Users *repository.UserRepository
+}
+
+// NewWorkspaceService 함수 정의
+func NewWorkspaceService(repo *repository.WorkspaceRepository, users *repository.UserRepository) *WorkspaceService {
+	return &WorkspaceService{Repo: repo, Users: users}
+}
+
+// CreateWorkspace 함수 정의 (만든 사용자가 owner)
+func (s *WorkspaceService) CreateWorkspace(userID int, name string) (*model.Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, model.Validation("name is required")
+	}
+	workspace := &model.Workspace{
+		Name:        name,
+		Role:        model.WorkspaceRoleOwner,
+		CreatedTime: time.Now(),
+	}
+	id, err := s.Repo.Create(workspace, userID)
+	if err != nil {
+		return nil, err
+	}
+	workspace.ID = id
+	return workspace, nil
+}
+
+// GetWorkspaces 함수 정의 (userID가 속한 워크스페이스)
+func (s *WorkspaceService) GetWorkspaces(userID int) ([]*model.Workspace, error) {
+	return s.Repo.GetByUserID(userID)
+}
+
+// GetRole 함수 정의 (멤버가 아니면 ErrNotWorkspaceMember)
+func (s *WorkspaceService) GetRole(workspaceID, userID int) (string, error) {
+	role, err := s.Repo.GetMemberRole(workspaceID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotWorkspaceMember
+	}
+	return role, err
+}
+
+// requireRole 함수 정의 (userID가 min 이상의 역할인지 확인 후 역할 반환)
+func (s *WorkspaceService) requireRole(workspaceID, userID int, min string) (string, error) {
+	role, err := s.GetRole(workspaceID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !model.WorkspaceRoleAtLeast(role, min) {
+		return "", ErrWorkspaceForbidden
+	}
+	return role, nil
+}
+
+// GetMembers 함수 정의 (멤버라면 누구나 조회 가능)
+func (s *WorkspaceService) GetMembers(userID, workspaceID int) ([]*model.WorkspaceMember, error) {
+	if _, err := s.GetRole(workspaceID, userID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetMembers(workspaceID)
+}
+
+// ChangeMemberRole 함수 정의 (admin 이상 가능, owner 역할을 주거나 빼는 것은 owner만 가능)
+func (s *WorkspaceService) ChangeMemberRole(actorID, workspaceID, targetID int, role string) error {
+	if !model.IsWorkspaceRole(role) {
+		return model.Validation(fmt.Sprintf("unknown role %q", role))
+	}
+	actorRole, err := s.requireRole(workspaceID, actorID, model.WorkspaceRoleAdmin)
+	if err != nil {
+		return err
+	}
+	targetRole, err := s.Repo.GetMemberRole(workspaceID, targetID)
+	if err != nil {
+		return err
+	}
+	if (role == model.WorkspaceRoleOwner || targetRole == model.WorkspaceRoleOwner) && actorRole != model.WorkspaceRoleOwner {
+		return ErrWorkspaceForbidden
+	}
+	if targetRole == model.WorkspaceRoleOwner && role != model.WorkspaceRoleOwner {
+		if err := s.ensureAnotherOwner(workspaceID); err != nil {
+			return err
+		}
+	}
+	return s.Repo.UpdateMemberRole(workspaceID, targetID, role)
+}
+
+// RemoveMember 함수 정의 (admin 이상 또는 본인 탈퇴, owner 제거는 owner만 가능)
+func (s *WorkspaceService) RemoveMember(actorID, workspaceID, targetID int) error {
+	actorRole, err := s.GetRole(workspaceID, actorID)
+	if err != nil {
+		return err
+	}
+	targetRole, err := s.Repo.GetMemberRole(workspaceID, targetID)
+	if err != nil {
+		return err
+	}
+	if actorID != targetID {
+		if !model.WorkspaceRoleAtLeast(actorRole, model.WorkspaceRoleAdmin) {
+			return ErrWorkspaceForbidden
+		}
+		if targetRole == model.WorkspaceRoleOwner && actorRole != model.WorkspaceRoleOwner {
+			return ErrWorkspaceForbidden
+		}
+	}
+	if targetRole == model.WorkspaceRoleOwner {
+		if err := s.ensureAnotherOwner(workspaceID); err != nil {
+			return err
+		}
+	}
+	return s.Repo.DeleteMember(workspaceID, targetID)
+}
+
+func (s *WorkspaceService) ensureAnotherOwner(workspaceID int) error {
+	owners, err := s.Repo.CountOwners(workspaceID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastWorkspaceOwner
+	}
+	return nil
+}
+
+// Invite 함수 정의 (admin 이상 가능, 토큰 원문은 이때 한 번만 반환)
+func (s *WorkspaceService) Invite(actorID, workspaceID int, email, role string) (*model.WorkspaceInvitation, string, error) {
+	actorRole, err := s.requireRole(workspaceID, actorID, model.WorkspaceRoleAdmin)
+	if err != nil {
+		return nil, "", err
+	}
+	if !model.IsWorkspaceRole(role) {
+		return nil, "", model.Validation(fmt.Sprintf("unknown role %q", role))
+	}
+	if role == model.WorkspaceRoleOwner && actorRole != model.WorkspaceRoleOwner {
+		return nil, "", ErrWorkspaceForbidden
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, "", model.Validation("email is required")
+	}
+
+	secret, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	raw := InvitationPrefix + secret
+
+	now := time.Now()
+	inv := &model.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       email,
+		Role:        role,
+		Prefix:      raw[:len(InvitationPrefix)+8],
+		TokenHash:   hashToken(raw),
+		InvitedBy:   actorID,
+		ExpiresTime: now.Add(InvitationTTL),
+		CreatedTime: now,
+	}
+	id, err := s.Repo.CreateInvitation(inv)
+	if err != nil {
+		return nil, "", err
+	}
+	inv.ID = id
+	return inv, raw, nil
+}
+
+// GetInvitations 함수 정의 (admin 이상, 수락 전 초대 목록)
+func (s *WorkspaceService) GetInvitations(actorID, workspaceID int) ([]*model.WorkspaceInvitation, error) {
+	if _, err := s.requireRole(workspaceID, actorID, model.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetPendingInvitations(workspaceID)
+}
+
+// RevokeInvitation 함수 정의 (admin 이상)
+func (s *WorkspaceService) RevokeInvitation(actorID, workspaceID, invitationID int) error {
+	if _, err := s.requireRole(workspaceID, actorID, model.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+	ok, err := s.Repo.DeleteInvitation(workspaceID, invitationID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.NotFound("invitation not found").Wrap(sql.ErrNoRows)
+	}
+	return nil
+}
+
+// AcceptInvitation 함수 정의 (초대받은 이메일의 사용자만 수락 가능, 이미 멤버면 역할 유지)
+func (s *WorkspaceService) AcceptInvitation(userID int, raw string) (*model.Workspace, error) {
+	inv, err := s.Repo.GetInvitationByHash(hashToken(raw))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if inv.AcceptedTime != nil || now.After(inv.ExpiresTime) {
+		return nil, ErrInvitationInvalid
+	}
+
+	user, err := s.Users.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != inv.Email {
+		return nil, ErrInvitationWrongEmail
+	}
+
+	ok, err := s.Repo.MarkInvitationAccepted(inv.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvitationInvalid
+	}
+	err = s.Repo.AddMember(&model.WorkspaceMember{
+		WorkspaceID: inv.WorkspaceID,
+		UserID:      userID,
+		Role:        inv.Role,
+		CreatedTime: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.Repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, workspace := range workspaces {
+		if workspace.ID == inv.WorkspaceID {
+			return workspace, nil
+		}
+	}
+	return nil, ErrNotWorkspaceMember
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"myapp/model"
+	"myapp/repository"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newTestWorkspaces 함수 정의 (사용자 1~4와 사용자 1이 owner인 워크스페이스 하나)
+func newTestWorkspaces(t *testing.T) (*sql.DB, *WorkspaceService, int) {
+	t.Helper()
+	db := newTestDB(t)
+	users := repository.NewUserRepository(db)
+	for i := 1; i <= 4; i++ {
+		if _, err := users.Create(&model.User{Email: fmt.Sprintf("user%d@example.com", i), PasswordHash: "x", CreatedTime: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	workspaces := NewWorkspaceService(repository.NewWorkspaceRepository(db), users)
+	workspace, err := workspaces.CreateWorkspace(1, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, workspaces, workspace.ID
+}
+
+func addMember(t *testing.T, workspaces *WorkspaceService, workspaceID, userID int, role string) {
+	t.Helper()
+	err := workspaces.Repo.AddMember(&model.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: role, CreatedTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// noteIDs 함수 정의 (scope에서 보이는 노트 ID, 오름차순)
+func noteIDs(t *testing.T, notes *NoteService, scope model.Scope) []int {
+	t.Helper()
+	all, err := notes.GetAllNotes(scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []int{}
+	for _, note := range all {
+		ids = append(ids, note.ID)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func createNote(t *testing.T, notes *NoteService, scope model.Scope, content string) *model.Note {
+	t.Helper()
+	note, err := notes.CreateNote(scope, "note", content, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return note
+}
+
+func TestNotesAreIsolatedByScope(t *testing.T) {
+	db, workspaces, team := newTestWorkspaces(t)
+	other, err := workspaces.CreateWorkspace(1, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addMember(t, workspaces, team, 2, model.WorkspaceRoleMember)
+	notes := newTestNoteService(db)
+
+	personal1 := model.Scope{UserID: 1}
+	personal2 := model.Scope{UserID: 2}
+	teamOf1 := model.Scope{UserID: 1, WorkspaceID: team}
+	teamOf2 := model.Scope{UserID: 2, WorkspaceID: team}
+	otherOf1 := model.Scope{UserID: 1, WorkspaceID: other.ID}
+
+	mine := createNote(t, notes, personal1, "- [ ] 개인 할 일")
+	theirs := createNote(t, notes, personal2, "- [ ] 다른 사람 할 일")
+	shared := createNote(t, notes, teamOf2, "- [ ] 팀 할 일")
+	elsewhere := createNote(t, notes, otherOf1, "- [ ] 다른 팀 할 일")
+
+	for _, tt := range []struct {
+		name  string
+		scope model.Scope
+		want  []int
+	}{
+		{"personal notes of user 1", personal1, []int{mine.ID}},
+		{"personal notes of user 2", personal2, []int{theirs.ID}},
+		{"team seen by user 1", teamOf1, []int{shared.ID}},
+		{"team seen by user 2", teamOf2, []int{shared.ID}},
+		{"other workspace", otherOf1, []int{elsewhere.ID}},
+	} {
+		if got := noteIDs(t, notes, tt.scope); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: notes %v, want %v", tt.name, got, tt.want)
+		}
+		tasks, err := notes.Tasks.GetAll(tt.scope, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 || tasks[0].NoteID != tt.want[0] {
+			t.Errorf("%s: tasks %+v, want only the task of note %d", tt.name, tasks, tt.want[0])
+		}
+	}
+
+	// 다른 범위의 노트는 ID를 알아도 찾을 수 없음
+	notFound := model.NotFound("note not found")
+	for _, tt := range []struct {
+		scope model.Scope
+		id    int
+	}{
+		{teamOf1, mine.ID},
+		{personal1, shared.ID},
+		{teamOf2, elsewhere.ID},
+		{personal2, mine.ID},
+	} {
+		if _, err := notes.GetNoteByID(tt.scope, tt.id); !errors.Is(err, notFound) {
+			t.Errorf("get note %d in %+v: %v, want not found", tt.id, tt.scope, err)
+		}
+		if _, err := notes.UpdateNote(tt.scope, tt.id, "note", "changed", ""); !errors.Is(err, notFound) {
+			t.Errorf("update note %d in %+v: %v, want not found", tt.id, tt.scope, err)
+		}
+		if err := notes.DeleteNote(tt.scope, tt.id); !errors.Is(err, notFound) {
+			t.Errorf("delete note %d in %+v: %v, want not found", tt.id, tt.scope, err)
+		}
+	}
+}
+
+func TestWorkspaceRoleChecks(t *testing.T) {
+	_, workspaces, team := newTestWorkspaces(t)
+	addMember(t, workspaces, team, 2, model.WorkspaceRoleAdmin)
+	addMember(t, workspaces, team, 3, model.WorkspaceRoleMember)
+
+	if _, err := workspaces.GetMembers(4, team); !errors.Is(err, ErrNotWorkspaceMember) {
+		t.Fatalf("members seen by an outsider: %v", err)
+	}
+	if _, _, err := workspaces.Invite(3, team, "user4@example.com", model.WorkspaceRoleGuest); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("invite by a member: %v", err)
+	}
+	if _, _, err := workspaces.Invite(2, team, "user4@example.com", model.WorkspaceRoleOwner); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("owner invite by an admin: %v", err)
+	}
+	if err := workspaces.ChangeMemberRole(3, team, 3, model.WorkspaceRoleAdmin); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("member promoting themselves: %v", err)
+	}
+	if err := workspaces.ChangeMemberRole(2, team, 3, model.WorkspaceRoleOwner); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("admin granting owner: %v", err)
+	}
+	if err := workspaces.ChangeMemberRole(2, team, 1, model.WorkspaceRoleMember); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("admin demoting the owner: %v", err)
+	}
+	if err := workspaces.RemoveMember(2, team, 1); !errors.Is(err, ErrWorkspaceForbidden) {
+		t.Fatalf("admin removing the owner: %v", err)
+	}
+	if err := workspaces.ChangeMemberRole(1, team, 1, model.WorkspaceRoleAdmin); !errors.Is(err, ErrLastWorkspaceOwner) {
+		t.Fatalf("last owner stepping down: %v", err)
+	}
+	if err := workspaces.RemoveMember(1, team, 1); !errors.Is(err, ErrLastWorkspaceOwner) {
+		t.Fatalf("last owner leaving: %v", err)
+	}
+
+	if err := workspaces.ChangeMemberRole(2, team, 3, model.WorkspaceRoleGuest); err != nil {
+		t.Fatalf("admin changing a member: %v", err)
+	}
+	if err := workspaces.RemoveMember(3, team, 3); err != nil {
+		t.Fatalf("guest leaving: %v", err)
+	}
+	if _, err := workspaces.GetRole(team, 3); !errors.Is(err, ErrNotWorkspaceMember) {
+		t.Fatalf("role after leaving: %v", err)
+	}
+	if err := workspaces.ChangeMemberRole(1, team, 2, model.WorkspaceRoleOwner); err != nil {
+		t.Fatal(err)
+	}
+	if err := workspaces.RemoveMember(1, team, 1); err != nil {
+		t.Fatalf("owner leaving while another owner remains: %v", err)
+	}
+}
+
+func TestMoveNoteBetweenScopes(t *testing.T) {
+	db, workspaces, team := newTestWorkspaces(t)
+	other, err := workspaces.CreateWorkspace(2, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addMember(t, workspaces, team, 2, model.WorkspaceRoleMember)
+	addMember(t, workspaces, team, 3, model.WorkspaceRoleGuest)
+	notes := newTestNoteService(db)
+	batch := NewBatchService(db, notes, workspaces)
+	move := func(scope model.Scope, id, workspaceID int) BatchResult {
+		t.Helper()
+		results, _, err := batch.Execute(scope, []BatchOperation{{Op: BatchOpMove, ID: id, WorkspaceID: workspaceID}}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return results[0]
+	}
+
+	personal1 := model.Scope{UserID: 1}
+	teamOf2 := model.Scope{UserID: 2, WorkspaceID: team}
+	note := createNote(t, notes, personal1, "회의 준비")
+	if err := notes.Shares.Upsert(&model.NoteShare{NoteID: note.ID, UserID: 4, Role: model.ShareRoleViewer, CreatedTime: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 대상 워크스페이스의 멤버가 아니거나 guest면 옮길 수 없음
+	if result := move(personal1, note.ID, other.ID); !errors.Is(result.Err, ErrNotWorkspaceMember) {
+		t.Fatalf("move into a workspace the user is not in: %v", result.Err)
+	}
+	guestNote := createNote(t, notes, model.Scope{UserID: 3}, "guest note")
+	if result := move(model.Scope{UserID: 3}, guestNote.ID, team); !errors.Is(result.Err, ErrWorkspaceForbidden) {
+		t.Fatalf("move by a guest: %v", result.Err)
+	}
+	if result := move(model.Scope{UserID: 2}, note.ID, team); !errors.Is(result.Err, model.NotFound("note not found")) {
+		t.Fatalf("move of another user's note: %v", result.Err)
+	}
+
+	result := move(personal1, note.ID, team)
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if result.Note.WorkspaceID != team || result.Note.OwnerID != 1 || result.Note.Version != note.Version+1 {
+		t.Fatalf("moved note = %+v, want it in the team with the same owner and a new version", result.Note)
+	}
+	if ids := noteIDs(t, notes, personal1); len(ids) != 0 {
+		t.Fatalf("personal notes after the move = %v, want none", ids)
+	}
+	if ids := noteIDs(t, notes, teamOf2); len(ids) != 1 || ids[0] != note.ID {
+		t.Fatalf("team notes after the move = %v, want the moved note", ids)
+	}
+	if shares, err := notes.Shares.GetByNoteID(note.ID); err != nil || len(shares) != 0 {
+		t.Fatalf("shares after moving into a workspace = %v, %v; want them removed", shares, err)
+	}
+	if _, _, err := notes.GetAccessibleNote(model.Scope{UserID: 4}, note.ID); err == nil {
+		t.Fatal("former share recipient can still open the workspace note")
+	}
+
+	// 개인 노트로 옮기면 옮긴 사용자의 노트가 됨
+	result = move(teamOf2, note.ID, 0)
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if result.Note.WorkspaceID != 0 || result.Note.OwnerID != 2 {
+		t.Fatalf("note moved out = %+v, want a personal note of user 2", result.Note)
+	}
+	if ids := noteIDs(t, notes, model.Scope{UserID: 2}); len(ids) != 1 || ids[0] != note.ID {
+		t.Fatalf("personal notes of user 2 = %v, want the moved note", ids)
+	}
+	if ids := noteIDs(t, notes, teamOf2); len(ids) != 0 {
+		t.Fatalf("team notes after moving out = %v, want none", ids)
+	}
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 마크다운 체크박스 줄 ("- [ ] 할 일", "* [x] 완료")
+var checkboxPattern = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s+)(.*\S)\s*$`)
+
+// 마감일 표기 ("due:2024-05-01" 또는 "@due(2024-05-01)")
+var dueDatePattern = regexp.MustCompile(`\s*(?:due:|@due\()(\d{4}-\d{2}-\d{2})\)?`)
+
+// ChecklistItem 구조체 정의 (Start, End는 내용에서 해당 줄의 바이트 위치)
+type ChecklistItem struct {
+	Text    string
+	Done    bool
+	DueDate *time.Time
+	Start   int
+	End     int
+}
+
+// ParseChecklist 함수 정의 (내용에서 체크박스 항목 추출)
+func ParseChecklist(content string) []ChecklistItem {
+	var items []ChecklistItem
+	offset := 0
+	for _, line := range strings.SplitAfter(content, "\n") {
+		start := offset
+		offset += len(line)
+		line = strings.TrimRight(line, "\r\n")
+
+		m := checkboxPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		item := ChecklistItem{
+			Text:  m[4],
+			Done:  m[2] != " ",
+			Start: start,
+			End:   start + len(line),
+		}
+		if due := dueDatePattern.FindStringSubmatch(item.Text); due != nil {
+			if t, err := time.Parse("2006-01-02", due[1]); err == nil {
+				item.DueDate = &t
+				item.Text = strings.TrimSpace(dueDatePattern.ReplaceAllString(item.Text, ""))
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// SetChecklistItemDone 함수 정의 (start~end 위치의 체크박스 상태 변경, 줄이 바뀌었으면 오류)
+func SetChecklistItemDone(content string, start, end int, done bool) (string, error) {
+	if start < 0 || end > len(content) || start > end {
+		return "", fmt.Errorf("checkbox position is out of range")
+	}
+	m := checkboxPattern.FindStringSubmatchIndex(content[start:end])
+	if m == nil {
+		return "", fmt.Errorf("checkbox not found at the recorded position")
+	}
+
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	markStart, markEnd := start+m[4], start+m[5]
+	return content[:markStart] + mark + content[markEnd:], nil
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseChecklist(t *testing.T) {
+	content := "# 장보기\r\n- [ ] 우유 due:2024-05-01\n* [x] 빵\n+ [X] 계란 @due(2024-06-30)\n- [] 빈 칸\n-[ ] 공백 없음\n  - [ ]   \n  - [ ] 들여쓴 항목  "
+	items := ParseChecklist(content)
+
+	want := []struct {
+		text string
+		done bool
+		due  string
+		line string
+	}{
+		{"우유", false, "2024-05-01", "- [ ] 우유 due:2024-05-01"},
+		{"빵", true, "", "* [x] 빵"},
+		{"계란", true, "2024-06-30", "+ [X] 계란 @due(2024-06-30)"},
+		{"들여쓴 항목", false, "", "  - [ ] 들여쓴 항목  "},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("parsed %d items (%+v), want %d", len(items), items, len(want))
+	}
+	for i, w := range want {
+		item := items[i]
+		if item.Text != w.text || item.Done != w.done {
+			t.Errorf("item %d = %q done %v, want %q done %v", i, item.Text, item.Done, w.text, w.done)
+		}
+		due := ""
+		if item.DueDate != nil {
+			due = item.DueDate.Format("2006-01-02")
+		}
+		if due != w.due {
+			t.Errorf("item %d due date = %q, want %q", i, due, w.due)
+		}
+		// 줄 위치는 줄바꿈 문자를 포함하지 않음
+		if got := content[item.Start:item.End]; got != w.line {
+			t.Errorf("item %d span = %q, want %q", i, got, w.line)
+		}
+	}
+}
+
+func TestParseChecklistKeepsInvalidDueDateInText(t *testing.T) {
+	items := ParseChecklist("- [ ] 보고서 due:2024-13-45")
+	if len(items) != 1 || items[0].DueDate != nil || items[0].Text != "보고서 due:2024-13-45" {
+		t.Fatalf("items = %+v, want the invalid date left in the text", items)
+	}
+	if items := ParseChecklist("- [ ] 회의 due:2024-02-29"); items[0].DueDate == nil || !items[0].DueDate.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("due date = %v, want 2024-02-29", items[0].DueDate)
+	}
+}
+
+func TestSetChecklistItemDone(t *testing.T) {
+	content := "메모\n- [ ] 첫째\n- [x] 둘째\n"
+	items := ParseChecklist(content)
+
+	checked, err := SetChecklistItemDone(content, items[0].Start, items[0].End, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checked != "메모\n- [x] 첫째\n- [x] 둘째\n" {
+		t.Fatalf("checked = %q", checked)
+	}
+	unchecked, err := SetChecklistItemDone(content, items[1].Start, items[1].End, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unchecked != "메모\n- [ ] 첫째\n- [ ] 둘째\n" {
+		t.Fatalf("unchecked = %q", unchecked)
+	}
+	// 이미 같은 상태여도 내용은 그대로
+	if same, err := SetChecklistItemDone(content, items[1].Start, items[1].End, true); err != nil || same != content {
+		t.Fatalf("setting the current state = %q, %v; want the content unchanged", same, err)
+	}
+}
+
+func TestSetChecklistItemDoneRejectsStalePositions(t *testing.T) {
+	content := "- [ ] 첫째\n"
+	items := ParseChecklist(content)
+	edited := "머리말\n" + content
+
+	for name, span := range map[string][2]int{
+		"moved line":   {items[0].Start, items[0].End},
+		"out of range": {0, len(edited) + 1},
+		"negative":     {-1, 3},
+		"reversed":     {5, 2},
+	} {
+		if _, err := SetChecklistItemDone(edited, span[0], span[1], true); err == nil {
+			t.Errorf("%s: stale position %v accepted", name, span)
+		}
+	}
+}
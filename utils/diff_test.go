@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines("a\nb\nc\nd", "a\nx\nc\nd\ne")
+	want := []DiffLine{
+		{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}, {DiffEqual, "d"}, {DiffInsert, "e"},
+	}
+	if len(diff) != len(want) {
+		t.Fatalf("diff = %v, want %v", diff, want)
+	}
+	for i := range want {
+		if diff[i] != want[i] {
+			t.Fatalf("diff[%d] = %v, want %v", i, diff[i], want[i])
+		}
+	}
+}
+
+func TestDiffLinesLargeInputFallsBackToReplace(t *testing.T) {
+	// 한 글자 줄 5만 개씩이면 LCS 표는 25억 칸
+	before := strings.Repeat("a\n", 50000) + "end"
+	after := "start\n" + strings.Repeat("b\n", 50000) + "end"
+
+	diff := DiffLines(before, after)
+	deletes, inserts := 0, 0
+	for _, line := range diff {
+		switch line.Op {
+		case DiffDelete:
+			deletes++
+		case DiffInsert:
+			inserts++
+		}
+	}
+	if deletes != 50000 || inserts != 50001 || diff[len(diff)-1] != (DiffLine{DiffEqual, "end"}) {
+		t.Fatalf("deletes=%d inserts=%d last=%v, want 50000 deletes, 50001 inserts and the common last line", deletes, inserts, diff[len(diff)-1])
+	}
+}
@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrPatchTestFailed JSON Patch의 test 연산이 실패했을 때 반환
+var ErrPatchTestFailed = errors.New("patch test operation failed")
+
+// ApplyMergePatch 함수 정의 (RFC 7386 JSON Merge Patch, null은 항목 삭제)
+func ApplyMergePatch(doc map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %v", err)
+	}
+	obj, ok := p.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("merge patch must be a JSON object")
+	}
+	return mergeObject(doc, obj), nil
+}
+
+func mergeObject(target, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(target))
+	for k, v := range target {
+		result[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		if sub, ok := v.(map[string]interface{}); ok {
+			existing, _ := result[k].(map[string]interface{})
+			result[k] = mergeObject(existing, sub)
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// PatchOperation 구조체 정의 (RFC 6902 JSON Patch 연산)
+type PatchOperation struct {
+	Op    string           `json:"op"`
+	Path  string           `json:"path"`
+	From  string           `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch 함수 정의 (RFC 6902 JSON Patch, 객체 경로만 지원하고 하나라도 실패하면 원본 유지)
+func ApplyJSONPatch(doc map[string]interface{}, patch []byte) (map[string]interface{}, error) {
+	var ops []PatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("JSON patch must be an array of operations: %v", err)
+	}
+
+	result := deepCopy(doc).(map[string]interface{})
+	for i, op := range ops {
+		if err := applyOperation(result, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return result, nil
+}
+
+func applyOperation(doc map[string]interface{}, op PatchOperation) error {
+	var value interface{}
+	if op.Value != nil {
+		if err := json.Unmarshal(*op.Value, &value); err != nil {
+			return err
+		}
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return fmt.Errorf("value is required")
+		}
+	}
+
+	switch op.Op {
+	case "add":
+		return setPointer(doc, op.Path, value, false)
+	case "replace":
+		return setPointer(doc, op.Path, value, true)
+	case "remove":
+		_, err := removePointer(doc, op.Path)
+		return err
+	case "test":
+		current, err := getPointer(doc, op.Path)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return ErrPatchTestFailed
+		}
+		return nil
+	case "move":
+		moved, err := removePointer(doc, op.From)
+		if err != nil {
+			return err
+		}
+		return setPointer(doc, op.Path, moved, false)
+	case "copy":
+		copied, err := getPointer(doc, op.From)
+		if err != nil {
+			return err
+		}
+		return setPointer(doc, op.Path, deepCopy(copied), false)
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+}
+
+// splitPointer 함수 정의 (RFC 6901 JSON Pointer를 부모 객체와 마지막 키로 분리)
+func splitPointer(doc map[string]interface{}, pointer string) (map[string]interface{}, string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, "", fmt.Errorf("path must start with /")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	parent := doc
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := parent[token].(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("path %s does not exist", pointer)
+		}
+		parent = next
+	}
+	return parent, tokens[len(tokens)-1], nil
+}
+
+func getPointer(doc map[string]interface{}, pointer string) (interface{}, error) {
+	parent, key, err := splitPointer(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := parent[key]
+	if !ok {
+		return nil, fmt.Errorf("path %s does not exist", pointer)
+	}
+	return value, nil
+}
+
+func setPointer(doc map[string]interface{}, pointer string, value interface{}, mustExist bool) error {
+	parent, key, err := splitPointer(doc, pointer)
+	if err != nil {
+		return err
+	}
+	if _, ok := parent[key]; mustExist && !ok {
+		return fmt.Errorf("path %s does not exist", pointer)
+	}
+	parent[key] = value
+	return nil
+}
+
+func removePointer(doc map[string]interface{}, pointer string) (interface{}, error) {
+	parent, key, err := splitPointer(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := parent[key]
+	if !ok {
+		return nil, fmt.Errorf("path %s does not exist", pointer)
+	}
+	delete(parent, key)
+	return value, nil
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			copied[k] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func noteDoc() map[string]interface{} {
+	return map[string]interface{}{"title": "groceries", "content": "milk", "img": "uploads/cat.png"}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	doc := noteDoc()
+	patched, err := ApplyMergePatch(doc, []byte(`{"title": "shopping", "img": null, "meta": {"pinned": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"title": "shopping", "content": "milk", "meta": map[string]interface{}{"pinned": true}}
+	if !reflect.DeepEqual(patched, want) {
+		t.Fatalf("patched = %v, want %v", patched, want)
+	}
+	// null은 항목을 지우고 원본 문서는 바뀌지 않음
+	if !reflect.DeepEqual(doc, noteDoc()) {
+		t.Fatalf("merge patch modified the original document: %v", doc)
+	}
+
+	for _, patch := range []string{`["title"]`, `"title"`, `{"title": }`} {
+		if _, err := ApplyMergePatch(noteDoc(), []byte(patch)); err == nil {
+			t.Fatalf("merge patch %s accepted", patch)
+		}
+	}
+}
+
+func TestApplyJSONPatchPointerEscapes(t *testing.T) {
+	doc := map[string]interface{}{"a/b": "slash", "m~n": "tilde", "~1": "literal"}
+	patched, err := ApplyJSONPatch(doc, []byte(`[
+		{"op": "replace", "path": "/a~1b", "value": "slash!"},
+		{"op": "replace", "path": "/m~0n", "value": "tilde!"},
+		{"op": "test", "path": "/~01", "value": "literal"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"a/b": "slash!", "m~n": "tilde!", "~1": "literal"}
+	if !reflect.DeepEqual(patched, want) {
+		t.Fatalf("patched = %v, want %v", patched, want)
+	}
+}
+
+func TestApplyJSONPatchMoveAndCopy(t *testing.T) {
+	patched, err := ApplyJSONPatch(noteDoc(), []byte(`[
+		{"op": "copy", "from": "/title", "path": "/content"},
+		{"op": "move", "from": "/img", "path": "/thumbnail"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"title": "groceries", "content": "groceries", "thumbnail": "uploads/cat.png"}
+	if !reflect.DeepEqual(patched, want) {
+		t.Fatalf("patched = %v, want %v", patched, want)
+	}
+
+	if _, err := ApplyJSONPatch(noteDoc(), []byte(`[{"op": "move", "from": "/missing", "path": "/title"}]`)); err == nil {
+		t.Fatal("move from a missing path accepted")
+	}
+}
+
+func TestApplyJSONPatchFailedTestKeepsDocument(t *testing.T) {
+	doc := noteDoc()
+	_, err := ApplyJSONPatch(doc, []byte(`[
+		{"op": "replace", "path": "/title", "value": "changed"},
+		{"op": "test", "path": "/content", "value": "eggs"}
+	]`))
+	if !errors.Is(err, ErrPatchTestFailed) {
+		t.Fatalf("error = %v, want ErrPatchTestFailed", err)
+	}
+	if !reflect.DeepEqual(doc, noteDoc()) {
+		t.Fatalf("failed patch modified the document: %v", doc)
+	}
+}
+
+func TestApplyJSONPatchRejectsInvalidOperations(t *testing.T) {
+	for _, patch := range []string{
+		`{"op": "remove", "path": "/title"}`,
+		`[{"op": "frobnicate", "path": "/title"}]`,
+		`[{"op": "replace", "path": "title", "value": "x"}]`,
+		`[{"op": "replace", "path": "/missing", "value": "x"}]`,
+		`[{"op": "add", "path": "/title"}]`,
+		`[{"op": "remove", "path": "/missing"}]`,
+	} {
+		if _, err := ApplyJSONPatch(noteDoc(), []byte(patch)); err == nil {
+			t.Fatalf("patch %s accepted", patch)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// NormalizeText 함수 정의 (대소문자, 공백 차이를 무시하기 위한 정규화)
+func NormalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), unicode.IsSpace), " ")
+}
+
+// ContentHash 함수 정의 (정규화한 텍스트의 SHA-256)
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(NormalizeText(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Shingles 함수 정의 (단어 k-gram 집합, 단어가 k개보다 적으면 글자 k-gram 사용)
+func Shingles(text string, k int) map[string]struct{} {
+	shingles := make(map[string]struct{})
+	words := strings.Fields(NormalizeText(text))
+	if len(words) >= k {
+		for i := 0; i+k <= len(words); i++ {
+			shingles[strings.Join(words[i:i+k], " ")] = struct{}{}
+		}
+		return shingles
+	}
+
+	runes := []rune(strings.Join(words, " "))
+	if len(runes) < k {
+		if len(runes) > 0 {
+			shingles[string(runes)] = struct{}{}
+		}
+		return shingles
+	}
+	for i := 0; i+k <= len(runes); i++ {
+		shingles[string(runes[i:i+k])] = struct{}{}
+	}
+	return shingles
+}
+
+// MinHash 함수 정의 (shingle 집합의 MinHash 서명, size개의 해시 함수 사용)
+func MinHash(shingles map[string]struct{}, size int) []uint64 {
+	signature := make([]uint64, size)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+	for shingle := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		base := h.Sum64()
+		for i := range signature {
+			// 해시 함수 i: 기본 해시에 시드를 섞은 값
+			v := mix64(base ^ uint64(i)*0x9e3779b97f4a7c15)
+			if v < signature[i] {
+				signature[i] = v
+			}
+		}
+	}
+	return signature
+}
+
+// mix64 함수 정의 (splitmix64 finalizer)
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// EstimateJaccard 함수 정의 (두 MinHash 서명이 일치하는 비율)
+func EstimateJaccard(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// lshRecall 유사도가 정확히 threshold인 쌍이 후보로 뽑힐 최소 확률
+const lshRecall = 0.99
+
+// LSHRows 함수 정의 (서명 길이 size를 나눌 밴드당 행 수, threshold 유사도의 쌍을 놓칠 확률이 1%를 넘지 않는 가장 큰 값)
+// 밴드 b개, 행 r개일 때 유사도 s인 쌍이 후보가 될 확률은 1-(1-s^r)^b
+func LSHRows(size int, threshold float64) int {
+	for rows := size; rows > 1; rows-- {
+		if size%rows != 0 {
+			continue
+		}
+		bands := float64(size / rows)
+		if 1-math.Pow(1-math.Pow(threshold, float64(rows)), bands) >= lshRecall {
+			return rows
+		}
+	}
+	return 1
+}
+
+// LSHBands 함수 정의 (MinHash 서명을 rows개씩 묶은 밴드별 해시, 한 밴드라도 같으면 후보 쌍)
+func LSHBands(signature []uint64, rows int) []uint64 {
+	bands := make([]uint64, 0, len(signature)/rows)
+	for start := 0; start+rows <= len(signature); start += rows {
+		h := uint64(start)
+		for _, v := range signature[start : start+rows] {
+			h = mix64(h ^ v)
+		}
+		bands = append(bands, h)
+	}
+	return bands
+}
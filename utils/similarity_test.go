@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLSHRowsKeepsRecallAtThreshold(t *testing.T) {
+	for _, threshold := range []float64{0.3, 0.5, 0.8, 0.9, 1} {
+		rows := LSHRows(128, threshold)
+		if 128%rows != 0 {
+			t.Fatalf("threshold %v: %d rows do not divide the signature", threshold, rows)
+		}
+		bands := float64(128 / rows)
+		if recall := 1 - math.Pow(1-math.Pow(threshold, float64(rows)), bands); rows > 1 && recall < lshRecall {
+			t.Fatalf("threshold %v: recall %v with %d rows", threshold, recall, rows)
+		}
+	}
+	// 높은 기준일수록 밴드가 길어져 후보가 줄어듦
+	if LSHRows(128, 0.9) <= LSHRows(128, 0.5) {
+		t.Fatalf("rows for 0.9 (%d) should exceed rows for 0.5 (%d)", LSHRows(128, 0.9), LSHRows(128, 0.5))
+	}
+}
+
+func TestLSHBandsMatchOnlyForSimilarSignatures(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog while the cat sleeps on the warm mat near the door"
+	a := MinHash(Shingles(text, 3), 128)
+	b := MinHash(Shingles(text+" today", 3), 128)
+	c := MinHash(Shingles(strings.Repeat("completely different words here ", 3), 3), 128)
+
+	rows := LSHRows(128, 0.7)
+	shared := func(x, y []uint64) bool {
+		bx, by := LSHBands(x, rows), LSHBands(y, rows)
+		if len(bx) != 128/rows {
+			t.Fatalf("%d bands, want %d", len(bx), 128/rows)
+		}
+		for i := range bx {
+			if bx[i] == by[i] {
+				return true
+			}
+		}
+		return false
+	}
+	if !shared(a, b) {
+		t.Fatalf("near-identical texts (similarity %v) share no band", EstimateJaccard(a, b))
+	}
+	if shared(a, c) {
+		t.Fatalf("unrelated texts (similarity %v) share a band", EstimateJaccard(a, c))
+	}
+}
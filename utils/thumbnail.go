@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxImagePixels 디코딩을 허용하는 최대 픽셀 수 (작은 파일로 큰 메모리를 쓰게 하는 이미지 방지)
+const MaxImagePixels = 40_000_000
+
+// ErrImageTooLarge 이미지의 가로, 세로 크기가 MaxImagePixels를 넘음
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// CheckImageSize 함수 정의 (헤더만 읽어 크기 확인, 디코딩할 수 없는 형식이면 image.ErrFormat)
+func CheckImageSize(r io.Reader) error {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return fmt.Errorf("invalid image dimensions %dx%d", cfg.Width, cfg.Height)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxImagePixels {
+		return ErrImageTooLarge
+	}
+	return nil
+}
+
+// CreateThumbnail 함수 정의 (긴 변이 maxSize가 되도록 축소한 PNG 썸네일 생성)
+func CreateThumbnail(srcPath string, maxSize int) (string, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	// 전체를 디코딩하기 전에 크기 확인
+	if err := CheckImageSize(src); err != nil {
+		return "", err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return "", err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = height * maxSize / width
+			width = maxSize
+		} else {
+			width = width * maxSize / height
+			height = maxSize
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	// 최근접 이웃 방식으로 축소
+	thumb := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY := bounds.Min.Y + y*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*bounds.Dx()/width
+			thumb.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+
+	ext := filepath.Ext(srcPath)
+	thumbPath := strings.TrimSuffix(srcPath, ext) + "_thumb.png"
+	dst, err := os.Create(thumbPath)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	if err := png.Encode(dst, thumb); err != nil {
+		return "", err
+	}
+	return thumbPath, nil
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// pngWithSize 함수 정의 (1x1 PNG의 IHDR 크기만 바꿔 큰 이미지처럼 보이는 파일 생성)
+func pngWithSize(t *testing.T, width, height uint32) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	// 시그니처 8바이트, 길이 4바이트, "IHDR" 4바이트 뒤에 가로, 세로
+	binary.BigEndian.PutUint32(data[16:], width)
+	binary.BigEndian.PutUint32(data[20:], height)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestCheckImageSize(t *testing.T) {
+	if err := CheckImageSize(bytes.NewReader(pngWithSize(t, 1, 1))); err != nil {
+		t.Fatalf("small image: %v", err)
+	}
+	if err := CheckImageSize(bytes.NewReader(pngWithSize(t, 50000, 50000))); !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("large image: err = %v, want ErrImageTooLarge", err)
+	}
+	if err := CheckImageSize(bytes.NewReader([]byte("not an image"))); !errors.Is(err, image.ErrFormat) {
+		t.Fatalf("unknown format: err = %v, want image.ErrFormat", err)
+	}
+}
+
+func TestCreateThumbnailRejectsLargeImageBeforeDecoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bomb.png")
+	if err := os.WriteFile(path, pngWithSize(t, 50000, 50000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateThumbnail(path, 256); !errors.Is(err, ErrImageTooLarge) {
+		t.Fatalf("err = %v, want ErrImageTooLarge", err)
+	}
+}